@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api/apitest"
+	"github.com/stustirling/lnr/internal/config"
+)
+
+var update = flag.Bool("update", false, "Record cassettes against the fake server and rewrite golden files")
+
+// goldenCases run lnr end to end. Normally each is replayed from its
+// cassette in testdata/cassettes, with no network access or API key, and
+// its output compared with testdata/golden. With -update each is recorded
+// against a fresh demo workspace instead:
+//
+//	go test ./cmd -run TestGolden -update
+//
+// Each case runs with an empty home directory, which holds the user config
+// and cache directories. Paths under it are written as $HOME in the golden
+// file. Not covered: config edit, which opens an editor, and auth login
+// --oauth, which waits for a browser to complete the OAuth flow.
+var goldenCases = []struct {
+	name string
+	args []string
+	// config, when set, is written to the user config file first
+	config string
+	// stdin is what lnr reads from standard input
+	stdin string
+}{
+	{name: "auth-status", args: []string{"auth", "status"}},
+	{name: "user-list", args: []string{"user", "list"}},
+	{name: "user-me", args: []string{"user", "me"}},
+	{name: "team-list", args: []string{"team", "list"}},
+	{name: "team-view", args: []string{"team", "view", "ENG"}},
+	{name: "label-list", args: []string{"label", "list"}},
+	{name: "state-list", args: []string{"state", "list", "--team", "ENG"}},
+	{name: "cycle-list", args: []string{"cycle", "list", "--team", "ENG"}},
+	{name: "cycle-active", args: []string{"cycle", "active", "ENG"}},
+	{name: "cycle-view", args: []string{"cycle", "view", "00000000-0000-4000-8000-000000000022"}},
+	{name: "project-list", args: []string{"project", "list"}},
+	{name: "project-view", args: []string{"project", "view", "Public launch"}},
+	{name: "initiative-list", args: []string{"initiative", "list"}},
+	{name: "initiative-view", args: []string{"initiative", "view", "00000000-0000-4000-8000-000000000025"}},
+	{name: "issue-list", args: []string{"issue", "list"}},
+	{name: "issue-list-filtered", args: []string{"issue", "list", "--team", "ENG", "-q", "label:q3 OR priority<=1"}},
+	{name: "issue-list-json", args: []string{"issue", "list", "--team", "DES", "--json"}},
+	{name: "issue-view", args: []string{"issue", "view", "ENG-1", "--comments"}},
+	{name: "issue-view-not-found", args: []string{"issue", "view", "ENG-999"}},
+	{name: "issue-tree", args: []string{"issue", "tree", "ENG-1"}},
+	{name: "issue-branch", args: []string{"issue", "branch", "ENG-1"}},
+	{name: "issue-search", args: []string{"issue", "search", "dark"}},
+	{name: "issue-create", args: []string{"issue", "create", "--team", "ENG", "--title", "Golden path", "--label", "bug", "--priority", "high", "--assignee", "@me"}},
+	{name: "issue-edit", args: []string{"issue", "edit", "ENG-3", "--title", "Handle expired sessions", "--add-label", "bug", "--state", "Todo"}},
+	{name: "issue-start", args: []string{"issue", "start", "ENG-2", "ENG-3"}},
+	{name: "issue-close", args: []string{"issue", "close", "ENG-1"}},
+	{name: "issue-move", args: []string{"issue", "move", "ENG-4", "--state", "In Review"}},
+	{name: "issue-relate", args: []string{"issue", "relate", "ENG-1", "--blocks", "ENG-4"}},
+	{name: "issue-unrelate", args: []string{"issue", "unrelate", "ENG-4", "DES-1"}},
+	{name: "issue-bulk-edit", args: []string{"issue", "bulk-edit", "--team", "ENG", "-q", "label:q3", "--set-priority", "urgent", "--yes"}},
+	{name: "comment-list", args: []string{"issue", "comment", "list", "ENG-1"}},
+	{name: "comment-add", args: []string{"issue", "comment", "add", "ENG-2", "--body", "Looks good to me"}},
+	{name: "comment-reply", args: []string{"issue", "comment", "reply", "00000000-0000-4000-8000-000000000034", "--body", "Same on production"}},
+	{name: "comment-edit", args: []string{"issue", "comment", "edit", "00000000-0000-4000-8000-000000000034", "--body", "Reproduced on staging with Okta and Azure AD."}},
+	{name: "comment-delete", args: []string{"issue", "comment", "delete", "00000000-0000-4000-8000-000000000035", "--yes"}},
+	{name: "issue-reopen", args: []string{"issue", "reopen", "ENG-5"}},
+	{name: "issue-cancel", args: []string{"issue", "cancel", "ENG-4"}},
+	{name: "cache-status", args: []string{"cache", "status"}},
+	{name: "cache-clear", args: []string{"cache", "clear"}},
+	{name: "config-get", args: []string{"config", "get", "team"}, config: goldenConfig},
+	{name: "config-set", args: []string{"config", "set", "limit", "20"}, config: goldenConfig},
+	{name: "config-unset", args: []string{"config", "unset", "team"}, config: goldenConfig},
+	{name: "config-list", args: []string{"config", "list"}, config: goldenConfig},
+	{name: "auth-login", args: []string{"auth", "login", "--profile", "work", "--api-url", "$LINEAR_API_URL"}, stdin: "golden-test-key\n"},
+	{name: "auth-switch", args: []string{"auth", "switch", "work"}, config: goldenConfig},
+	{name: "auth-logout", args: []string{"auth", "logout"}, config: goldenConfig},
+	{name: "api-graphql", args: []string{"api", "graphql", "-f", "query=query($after: String) { users(first: 2, after: $after) { nodes { name } pageInfo { hasNextPage endCursor } } }", "--paginate"}},
+}
+
+// goldenConfig is the user config file for cases that read or change it
+const goldenConfig = `team: ENG
+limit: 10
+profile: work
+profiles:
+  work:
+    api_key: golden-test-key
+  personal:
+    api_key: personal-key
+    team: DES
+`
+
+func TestGolden(t *testing.T) {
+	// Comment times are shown in local time
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cassette := filepath.Join(testdata, "cassettes", tc.name+".json")
+			golden := filepath.Join(testdata, "golden", tc.name+".golden")
+
+			// Run outside the checkout, so no .lnr.yaml above it applies
+			chdir(t, t.TempDir())
+
+			// Start from a clean environment whatever the shell has set, in
+			// an empty home directory
+			for _, key := range []string{
+				config.EnvAPIURL, config.EnvAPIKey, config.EnvNoCache, config.EnvRetries, config.EnvDebug,
+				config.EnvDebugFile, config.EnvTimeout, config.EnvRecord, config.EnvReplay,
+				config.EnvConfigHome, "XDG_CACHE_HOME",
+			} {
+				t.Setenv(key, "")
+			}
+			for _, k := range config.Keys {
+				t.Setenv(k.Env, "")
+			}
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+
+			userConfig := filepath.Join(home, ".config", "lnr", "config.yaml")
+			if tc.config != "" {
+				require.NoError(t, os.MkdirAll(filepath.Dir(userConfig), 0o700))
+				require.NoError(t, os.WriteFile(userConfig, []byte(tc.config), 0o600))
+			}
+
+			if *update {
+				srv := apitest.NewServer(apitest.NewDemoWorkspace())
+				defer srv.Close()
+				require.NoError(t, os.MkdirAll(filepath.Dir(cassette), 0o755))
+				if err := os.Remove(cassette); err != nil && !os.IsNotExist(err) {
+					t.Fatal(err)
+				}
+				t.Setenv(config.EnvAPIURL, srv.URL)
+				// Cases with a config file log in with its profile
+				if tc.config == "" {
+					t.Setenv(config.EnvAPIKey, "golden-test-key")
+				}
+				t.Setenv(config.EnvRecord, cassette)
+			} else {
+				// A replayed request goes nowhere, but commands see an
+				// endpoint as they did while recording
+				t.Setenv(config.EnvAPIURL, "http://localhost/graphql")
+				t.Setenv(config.EnvReplay, cassette)
+			}
+
+			// The fake server's address changes from run to run
+			apiURL := os.Getenv(config.EnvAPIURL)
+			args := make([]string, len(tc.args))
+			for i, arg := range tc.args {
+				if arg == "$"+config.EnvAPIURL {
+					arg = apiURL
+				}
+				args[i] = arg
+			}
+
+			got := runGolden(t, args, tc.stdin)
+			// Show what the command did to the user config file
+			if data, err := os.ReadFile(userConfig); err == nil && string(data) != tc.config {
+				got += "--- config.yaml\n" + string(data)
+			}
+			got = strings.ReplaceAll(got, home, "$HOME")
+			got = strings.ReplaceAll(got, apiURL, "$"+config.EnvAPIURL)
+
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), got)
+		})
+	}
+}
+
+// runGolden runs lnr with args and stdin as standard input, and returns
+// what it wrote to stdout, then anything written to stderr and the error
+// main would print
+func runGolden(t *testing.T, args []string, stdin string) string {
+	t.Helper()
+
+	in, err := os.CreateTemp(t.TempDir(), "stdin")
+	require.NoError(t, err)
+	_, err = in.WriteString(stdin)
+	require.NoError(t, err)
+	_, err = in.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	original := os.Stdin
+	os.Stdin = in
+	defer func() {
+		os.Stdin = original
+		in.Close()
+	}()
+
+	stdout, stderr := capture(t, &os.Stdout), capture(t, &os.Stderr)
+	root := NewCmdRoot()
+	root.SetArgs(args)
+	err = root.ExecuteContext(context.Background())
+
+	var out strings.Builder
+	out.WriteString(stdout())
+	if errOut := stderr(); errOut != "" || err != nil {
+		out.WriteString("--- stderr\n")
+		out.WriteString(errOut)
+		if err != nil {
+			out.WriteString("Error: " + err.Error() + "\n")
+		}
+	}
+	return out.String()
+}
+
+// capture redirects *f to a pipe until the returned function is called,
+// which restores it and returns everything written
+func capture(t *testing.T, f **os.File) func() string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	original := *f
+	*f = w
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	return func() string {
+		*f = original
+		_ = w.Close()
+		return <-done
+	}
+}
@@ -1,10 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
 	"github.com/spf13/cobra"
 
+	"github.com/stustirling/lnr/internal/api"
+	apicmd "github.com/stustirling/lnr/internal/cmd/api"
 	"github.com/stustirling/lnr/internal/cmd/auth"
+	"github.com/stustirling/lnr/internal/cmd/cache"
+	configcmd "github.com/stustirling/lnr/internal/cmd/config"
 	"github.com/stustirling/lnr/internal/cmd/cycle"
+	"github.com/stustirling/lnr/internal/cmd/dev"
 	"github.com/stustirling/lnr/internal/cmd/initiative"
 	"github.com/stustirling/lnr/internal/cmd/issue"
 	"github.com/stustirling/lnr/internal/cmd/label"
@@ -12,48 +26,284 @@ import (
 	"github.com/stustirling/lnr/internal/cmd/state"
 	"github.com/stustirling/lnr/internal/cmd/team"
 	"github.com/stustirling/lnr/internal/cmd/user"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
 // Version is set by goreleaser via ldflags
 var Version = "dev"
 
-var rootCmd = &cobra.Command{
-	Use:     "lnr",
-	Short:   "Linear CLI - A command-line interface for Linear",
-	Version: Version,
-	Long: `lnr is a command-line tool for interacting with Linear.
+var rootCmd = NewCmdRoot()
+
+// NewCmdRoot creates the lnr command with all of its subcommands
+func NewCmdRoot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "lnr",
+		Short:   "Linear CLI - A command-line interface for Linear",
+		Version: Version,
+		Long: `lnr is a command-line tool for interacting with Linear.
 
-It provides read-only access to your Linear workspace, allowing you to
-view issues, projects, initiatives, teams, and more from the terminal.
+It lets you work with your Linear workspace from the terminal: list and
+view issues, projects, initiatives, teams and more; create, edit and move
+issues, or edit many at once with lnr issue bulk-edit; add, edit and delete
+comments; and link issues with lnr issue relate.
 
-To get started, set your Linear API key:
-  export LINEAR_API_KEY=your_api_key
+To get started, log in with a Linear API key, which is saved as a profile:
+  lnr auth login
+
+Save a profile for each workspace and choose one with lnr auth switch or
+--profile. LINEAR_API_KEY, when set, takes precedence over the profile.
 
 Then verify your authentication:
   lnr auth status`,
+		// main prints errors itself, as JSON when --json is set
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Arguments are valid from here on, so failures are not usage
+			// mistakes and need no usage text
+			cmd.SilenceUsage = true
+
+			overrides, err := parseOverrides(cmd)
+			if err != nil {
+				return err
+			}
+			cmd.SetContext(config.WithOverrides(cmd.Context(), overrides))
+			if err := applySettings(cmd); err != nil {
+				return err
+			}
+			return applyTimeout(cmd)
+		},
+	}
+
+	cmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &cmdutil.FlagError{Err: err}
+	})
+	cmd.PersistentFlags().Bool("no-cache", false, "Bypass the local cache of teams, users, labels and states")
+	cmd.PersistentFlags().Bool("debug", false, "Log API requests and responses to stderr")
+	cmd.PersistentFlags().String("debug-file", "", "Append a JSON trace of API requests and responses to a file")
+	cmd.PersistentFlags().Int("retries", api.DefaultMaxRetries, "Times to retry after rate limits and transient failures (0 disables)")
+	cmd.PersistentFlags().String("record", "", "Record API requests and responses to a cassette file")
+	cmd.PersistentFlags().String("replay", "", "Answer API requests from a cassette file instead of Linear")
+	cmd.PersistentFlags().String("profile", "", "Use a profile saved by lnr auth login")
+	cmd.PersistentFlags().Duration("timeout", 0, "Give up after this long, e.g. 30s or 5m (default no limit, 30s per request)")
+
+	// Add commands
+	cmd.AddCommand(apicmd.NewCmdAPI())
+	cmd.AddCommand(auth.NewCmdAuth())
+	cmd.AddCommand(cache.NewCmdCache())
+	cmd.AddCommand(configcmd.NewCmdConfig())
+	cmd.AddCommand(cycle.NewCmdCycle())
+	cmd.AddCommand(dev.NewCmdDev())
+	cmd.AddCommand(initiative.NewCmdInitiative())
+	cmd.AddCommand(issue.NewCmdIssue())
+	cmd.AddCommand(label.NewCmdLabel())
+	cmd.AddCommand(project.NewCmdProject())
+	cmd.AddCommand(state.NewCmdState())
+	cmd.AddCommand(team.NewCmdTeam())
+	cmd.AddCommand(user.NewCmdUser())
+
+	// Add completion command (built into Cobra)
+	cmd.AddCommand(newCompletionCmd())
+
+	usageArgs(cmd)
+
+	return cmd
 }
 
-// Execute runs the root command
+// usageArgs makes wrong arguments usage errors, like bad flags, for cmd and
+// its subcommands. Commands that only group subcommands reject unknown ones
+// rather than printing their help.
+func usageArgs(cmd *cobra.Command) {
+	if !cmd.Runnable() && cmd.HasSubCommands() {
+		cmd.Args = unknownCommand
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		}
+	} else if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return &cmdutil.FlagError{Err: err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		usageArgs(sub)
+	}
+}
+
+// unknownCommand rejects any argument to a command that only groups
+// subcommands, suggesting the subcommand that was probably meant
+func unknownCommand(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	// The suggestions say more than the full usage would
+	cmd.SilenceUsage = true
+	msg := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+	// cobra only sets the default distance for the root command
+	if cmd.SuggestionsMinimumDistance <= 0 {
+		cmd.SuggestionsMinimumDistance = 2
+	}
+	if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+		msg += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t")
+	}
+	return &cmdutil.FlagError{Err: errors.New(msg)}
+}
+
+// cancelTimeout releases the --timeout deadline once the command finishes
+var cancelTimeout context.CancelFunc = func() {}
+
+// stopPager waits for the pager, if there is one, once the command finishes
+var stopPager = func() error { return nil }
+
+// Execute runs the root command. Ctrl-C or SIGTERM cancels the command's
+// context, so requests in flight stop and commands can print what they have;
+// a second Ctrl-C exits immediately.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// Restore the default handlers for the second signal
+		stop()
+	}()
+	defer func() { cancelTimeout() }()
+	defer func() { _ = stopPager() }()
+
+	return rootCmd.ExecuteContext(ctx)
 }
 
-func init() {
-	rootCmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+// JSONOutput reports whether --json was passed, so errors can be printed
+// in the same format as output
+func JSONOutput() bool {
+	jsonOutput, _ := rootCmd.PersistentFlags().GetBool("json")
+	return jsonOutput
+}
 
-	// Add commands
-	rootCmd.AddCommand(auth.NewCmdAuth())
-	rootCmd.AddCommand(cycle.NewCmdCycle())
-	rootCmd.AddCommand(initiative.NewCmdInitiative())
-	rootCmd.AddCommand(issue.NewCmdIssue())
-	rootCmd.AddCommand(label.NewCmdLabel())
-	rootCmd.AddCommand(project.NewCmdProject())
-	rootCmd.AddCommand(state.NewCmdState())
-	rootCmd.AddCommand(team.NewCmdTeam())
-	rootCmd.AddCommand(user.NewCmdUser())
+// parseOverrides reads the global flags that configure the API client.
+// Commands find them on their context, rather than in the environment,
+// which child processes such as the editor would inherit.
+func parseOverrides(cmd *cobra.Command) (config.Overrides, error) {
+	var o config.Overrides
+	o.NoCache, _ = cmd.Flags().GetBool("no-cache")
+	o.Debug, _ = cmd.Flags().GetBool("debug")
+	o.Profile, _ = cmd.Flags().GetString("profile")
+	o.DebugFile, _ = cmd.Flags().GetString("debug-file")
+	o.Record, _ = cmd.Flags().GetString("record")
+	o.Replay, _ = cmd.Flags().GetString("replay")
+	if o.Record != "" && o.Replay != "" {
+		return o, &cmdutil.FlagError{Err: errors.New("--record and --replay cannot be used together")}
+	}
+	if cmd.Flags().Changed("retries") {
+		retries, _ := cmd.Flags().GetInt("retries")
+		if retries < 0 {
+			return o, &cmdutil.FlagError{Err: fmt.Errorf("invalid --retries %d: must be at least 0", retries)}
+		}
+		o.Retries = &retries
+	}
+	if cmd.Flags().Changed("timeout") {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout <= 0 {
+			return o, &cmdutil.FlagError{Err: fmt.Errorf("invalid --timeout %s: must be positive", timeout)}
+		}
+		o.Timeout = timeout
+	}
+	return o, nil
+}
 
-	// Add completion command (built into Cobra)
-	rootCmd.AddCommand(newCompletionCmd())
+// settingFlags are the flags that settings supply a default for
+var settingFlags = map[string]string{
+	"team":  config.KeyTeam,
+	"limit": config.KeyLimit,
+}
+
+// contextFlags are settingFlags for commands annotated with
+// cmdutil.AnnotationContext only
+var contextFlags = map[string]string{
+	"project": config.KeyProject,
+	"label":   config.KeyLabels,
+}
+
+// applySettings applies the settings from config files and the environment.
+// They only fill in flags the user did not pass, so flags always win.
+func applySettings(cmd *cobra.Command) error {
+	settings, err := config.LoadSettingsWith(config.OverridesFrom(cmd.Context()))
+	if err != nil {
+		// lnr config has to work with a broken config file, to fix it
+		if cmd.HasParent() && cmd.Parent().Name() == "config" {
+			return nil
+		}
+		return err
+	}
+
+	if err := fillFlags(cmd, settings, settingFlags); err != nil {
+		return err
+	}
+	if cmd.Annotations[cmdutil.AnnotationContext] == "true" {
+		if err := fillFlags(cmd, settings, contextFlags); err != nil {
+			return err
+		}
+	}
+	if flag := cmd.Flags().Lookup("json"); flag != nil && !flag.Changed && settings.Get(config.KeyOutput).Value == "json" {
+		_ = flag.Value.Set("true")
+	}
+
+	// editor.Command looks for LNR_EDITOR before $VISUAL and $EDITOR
+	if editor := settings.Get(config.KeyEditor); editor.Value != "" {
+		if err := os.Setenv(config.EnvEditor, editor.Value); err != nil {
+			return err
+		}
+	}
+	if tz := settings.Get(config.KeyTimezone).Value; tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return err
+		}
+		time.Local = loc
+	}
+	if pager := settings.Get(config.KeyPager).Value; pager != "" && cmd.Annotations[cmdutil.AnnotationPager] == "true" {
+		stop, err := cmdutil.StartPager(pager)
+		if err != nil {
+			return err
+		}
+		stopPager = stop
+	}
+	return nil
+}
+
+// fillFlags sets the flags the user did not pass from their settings
+func fillFlags(cmd *cobra.Command, settings *config.Settings, flags map[string]string) error {
+	for name, key := range flags {
+		flag := cmd.Flags().Lookup(name)
+		value := settings.Get(key).Value
+		if flag == nil || flag.Changed || value == "" {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s setting %q: %w", key, value, err)
+		}
+	}
+	return nil
+}
+
+// applyTimeout puts the --timeout or LNR_TIMEOUT deadline on the command's
+// context. The same value lifts the per-request timeout, see
+// cmdutil.NewFactory.
+func applyTimeout(cmd *cobra.Command) error {
+	timeout, err := config.TimeoutFor(config.OverridesFrom(cmd.Context()))
+	if err != nil {
+		return err
+	}
+	if timeout == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	cancelTimeout = cancel
+	cmd.SetContext(ctx)
+	return nil
 }
 
 func newCompletionCmd() *cobra.Command {
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func TestApplySettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(config.EnvConfigHome, home)
+	for _, k := range config.Keys {
+		t.Setenv(k.Env, "")
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "lnr", "config.yaml"),
+		[]byte("team: ENG\nlimit: 10\noutput: json\neditor: nano\ntimezone: Asia/Tokyo\n"), 0o600))
+	t.Setenv("LNR_LIMIT", "20")
+	chdir(t, t.TempDir())
+	local := time.Local
+	t.Cleanup(func() { time.Local = local })
+
+	var team string
+	var limit int
+	var jsonOutput bool
+	cmd := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.Flags().StringVar(&team, "team", "", "")
+	cmd.Flags().IntVar(&limit, "limit", 50, "")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "")
+	require.NoError(t, cmd.ParseFlags([]string{"--team", "DES"}))
+
+	require.NoError(t, applySettings(cmd))
+	assert.Equal(t, "DES", team, "flags win")
+	assert.Equal(t, 20, limit, "the environment beats the config file")
+	assert.True(t, jsonOutput)
+	assert.Equal(t, "nano", os.Getenv(config.EnvEditor))
+	assert.Equal(t, "Asia/Tokyo", time.Local.String())
+	assert.False(t, cmd.Flags().Changed("limit"))
+}
+
+func TestApplySettings_Context(t *testing.T) {
+	t.Setenv(config.EnvConfigHome, t.TempDir())
+	for _, k := range config.Keys {
+		t.Setenv(k.Env, "")
+	}
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, config.RepoConfigName),
+		[]byte("team: ENG\nproject: Public launch\nlabels: [cli, backend]\n"), 0o644))
+	chdir(t, repo)
+
+	newCmd := func(annotations map[string]string) (*cobra.Command, *string, *string, *[]string) {
+		var team, project string
+		var labels []string
+		cmd := &cobra.Command{Use: "create", Annotations: annotations, RunE: func(*cobra.Command, []string) error { return nil }}
+		cmd.Flags().StringVar(&team, "team", "", "")
+		cmd.Flags().StringVar(&project, "project", "", "")
+		cmd.Flags().StringSliceVar(&labels, "label", nil, "")
+		return cmd, &team, &project, &labels
+	}
+
+	cmd, team, project, labels := newCmd(map[string]string{cmdutil.AnnotationContext: "true"})
+	require.NoError(t, applySettings(cmd))
+	assert.Equal(t, "ENG", *team)
+	assert.Equal(t, "Public launch", *project)
+	assert.Equal(t, []string{"cli", "backend"}, *labels)
+
+	cmd, _, project, _ = newCmd(map[string]string{cmdutil.AnnotationContext: "true"})
+	require.NoError(t, cmd.ParseFlags([]string{"--project", ""}))
+	require.NoError(t, applySettings(cmd))
+	assert.Equal(t, "", *project, "an empty flag turns the default off")
+
+	// Commands such as issue edit only get the team
+	cmd, team, project, labels = newCmd(nil)
+	require.NoError(t, applySettings(cmd))
+	assert.Equal(t, "ENG", *team)
+	assert.Equal(t, "", *project)
+	assert.Empty(t, *labels)
+}
+
+func TestGlobalFlagsStayOutOfTheEnvironment(t *testing.T) {
+	t.Setenv(config.EnvConfigHome, t.TempDir())
+	for _, k := range config.Keys {
+		t.Setenv(k.Env, "")
+	}
+	t.Setenv(config.EnvNoCache, "")
+	t.Setenv(config.EnvTimeout, "")
+	chdir(t, t.TempDir())
+
+	stdout := capture(t, &os.Stdout)
+	root := NewCmdRoot()
+	root.SetArgs([]string{"config", "get", "profile", "--profile", "work", "--no-cache", "--timeout", "1m"})
+	require.NoError(t, root.ExecuteContext(context.Background()))
+	t.Cleanup(cancelTimeout)
+
+	assert.Equal(t, "work\n", stdout())
+	for _, env := range []string{config.EnvProfile, config.EnvNoCache, config.EnvTimeout} {
+		assert.Empty(t, os.Getenv(env), env)
+	}
+}
+
+func TestParseOverrides(t *testing.T) {
+	parse := func(args ...string) (config.Overrides, error) {
+		root := NewCmdRoot()
+		require.NoError(t, root.ParseFlags(args))
+		return parseOverrides(root)
+	}
+
+	o, err := parse("--profile", "work", "--debug", "--retries", "0", "--replay", "run.json")
+	require.NoError(t, err)
+	assert.Equal(t, "work", o.Profile)
+	assert.True(t, o.Debug)
+	require.NotNil(t, o.Retries)
+	assert.Equal(t, 0, *o.Retries)
+	assert.Equal(t, "run.json", o.Replay)
+
+	o, err = parse()
+	require.NoError(t, err)
+	assert.Nil(t, o.Retries, "--retries only overrides LNR_RETRIES when given")
+
+	_, err = parse("--record", "a.json", "--replay", "b.json")
+	assert.ErrorContains(t, err, "cannot be used together")
+	_, err = parse("--timeout", "0s")
+	assert.ErrorContains(t, err, "must be positive")
+}
+
+func TestUsageArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"issue", "view"}, "accepts 1 arg(s), received 0"},
+		{[]string{"issu"}, "Did you mean this?\n\tissue"},
+		{[]string{"issue", "bogus"}, `unknown command "bogus" for "lnr issue"`},
+		{[]string{"issue", "comment", "lst"}, "Did you mean this?\n\tlist"},
+		{[]string{"completion", "bash", "zsh"}, "accepts 1 arg(s), received 2"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			root := NewCmdRoot()
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+			root.SetArgs(tt.args)
+			err := root.ExecuteContext(context.Background())
+
+			var flagErr *cmdutil.FlagError
+			require.ErrorAs(t, err, &flagErr)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	// A group command on its own still shows its help
+	t.Setenv(config.EnvConfigHome, t.TempDir())
+	chdir(t, t.TempDir())
+	stdout := capture(t, &os.Stdout)
+	root := NewCmdRoot()
+	root.SetArgs([]string{"issue"})
+	require.NoError(t, root.ExecuteContext(context.Background()))
+	assert.Contains(t, stdout(), "Available Commands:")
+}
+
+// chdir changes the working directory until the test ends
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
@@ -0,0 +1,98 @@
+package apitest
+
+import "time"
+
+// NewDemoWorkspace returns a small workspace with two teams, a few users,
+// labels, cycles, a project, an initiative and related issues, for trying
+// the CLI against the fake server. Dates are relative to now so the current
+// cycle is always active.
+func NewDemoWorkspace() *Workspace {
+	w := NewWorkspace()
+	w.Organization.Name = "Acme"
+	w.Organization.URLKey = "acme"
+	now := w.Now()
+	day := 24 * time.Hour
+
+	ada := w.AddUser(User{Name: "Ada Lovelace", Email: "ada@example.com", Admin: true})
+	grace := w.AddUser(User{Name: "Grace Hopper", Email: "grace@example.com"})
+	alan := w.AddUser(User{Name: "Alan Turing", Email: "alan@example.com"})
+
+	eng := w.AddTeam(Team{Name: "Engineering", Key: "ENG", Description: "Builds the product"})
+	des := w.AddTeam(Team{Name: "Design", Key: "DES"})
+	w.AddState(WorkflowState{Name: "In Review", Type: "started", Color: "#26b5ce", Position: 3, TeamID: eng.ID})
+
+	bug := w.AddLabel(Label{Name: "bug", Color: "#eb5757"})
+	feature := w.AddLabel(Label{Name: "feature", Color: "#5e6ad2"})
+	q3 := w.AddLabel(Label{Name: "q3", Color: "#f2994a", TeamID: eng.ID})
+
+	previous := w.AddCycle(Cycle{Number: 1, TeamID: eng.ID, Progress: 1, StartsAt: now.Add(-21 * day), EndsAt: now.Add(-7 * day)})
+	current := w.AddCycle(Cycle{Number: 2, TeamID: eng.ID, Progress: 0.4, StartsAt: now.Add(-7 * day), EndsAt: now.Add(7 * day)})
+	w.AddCycle(Cycle{Number: 3, TeamID: eng.ID, StartsAt: now.Add(7 * day), EndsAt: now.Add(21 * day)})
+
+	launch := w.AddProject(Project{
+		Name:        "Public launch",
+		Description: "Everything needed for the public launch",
+		State:       "started",
+		Progress:    0.3,
+		StartDate:   now.Add(-14 * day).Format("2006-01-02"),
+		TargetDate:  now.Add(30 * day).Format("2006-01-02"),
+		LeadID:      ada.ID,
+		TeamIDs:     []string{eng.ID, des.ID},
+	})
+	w.AddInitiative(Initiative{
+		Name:        "Grow adoption",
+		Description: "Get the product in front of more people",
+		TargetDate:  now.Add(90 * day).Format("2006-01-02"),
+		OwnerID:     ada.ID,
+		ProjectIDs:  []string{launch.ID},
+	})
+
+	state := func(teamID, name string) string {
+		for _, s := range w.States {
+			if s.TeamID == teamID && s.Name == name {
+				return s.ID
+			}
+		}
+		return ""
+	}
+	estimate := func(points float64) *float64 { return &points }
+
+	login := w.AddIssue(Issue{
+		TeamID: eng.ID, Title: "Login fails with SSO", Description: "Users signing in with SSO see a blank page.",
+		Priority: 1, StateID: state(eng.ID, "In Progress"), AssigneeID: ada.ID, CreatorID: grace.ID,
+		ProjectID: launch.ID, CycleID: current.ID, LabelIDs: []string{bug.ID}, Estimate: estimate(3),
+		CreatedAt: now.Add(-5 * day),
+	})
+	w.AddIssue(Issue{
+		TeamID: eng.ID, Title: "Add SAML metadata endpoint", Priority: 2, StateID: state(eng.ID, "Todo"),
+		AssigneeID: alan.ID, CreatorID: ada.ID, ParentID: login.ID, CycleID: current.ID, CreatedAt: now.Add(-4 * day),
+	})
+	w.AddIssue(Issue{
+		TeamID: eng.ID, Title: "Handle expired SSO sessions", Priority: 3, StateID: state(eng.ID, "Backlog"),
+		CreatorID: ada.ID, ParentID: login.ID, LabelIDs: []string{q3.ID}, CreatedAt: now.Add(-4 * day),
+	})
+	dark := w.AddIssue(Issue{
+		TeamID: eng.ID, Title: "Dark mode", Priority: 3, StateID: state(eng.ID, "Backlog"), CreatorID: grace.ID,
+		LabelIDs: []string{feature.ID, q3.ID}, DueDate: now.Add(10 * day).Format("2006-01-02"), CreatedAt: now.Add(-20 * day),
+	})
+	w.AddIssue(Issue{
+		TeamID: eng.ID, Title: "Upgrade database driver", Priority: 4, StateID: state(eng.ID, "Done"),
+		AssigneeID: grace.ID, CreatorID: grace.ID, CycleID: previous.ID, CreatedAt: now.Add(-18 * day),
+	})
+	palette := w.AddIssue(Issue{
+		TeamID: des.ID, Title: "Dark mode colour palette", Priority: 2, StateID: state(des.ID, "In Progress"),
+		AssigneeID: grace.ID, CreatorID: grace.ID, ProjectID: launch.ID, LabelIDs: []string{feature.ID},
+		CreatedAt: now.Add(-10 * day),
+	})
+	w.AddIssue(Issue{
+		TeamID: des.ID, Title: "Launch page illustrations", StateID: state(des.ID, "Todo"), CreatorID: ada.ID,
+		ProjectID: launch.ID, CreatedAt: now.Add(-2 * day),
+	})
+
+	w.AddRelation(IssueRelation{Type: "blocks", IssueID: palette.ID, RelatedIssueID: dark.ID})
+
+	thread := w.AddComment(Comment{IssueID: login.ID, UserID: grace.ID, Body: "Reproduced on staging with Okta.", CreatedAt: now.Add(-3 * day)})
+	w.AddComment(Comment{IssueID: login.ID, UserID: ada.ID, ParentID: thread.ID, Body: "Thanks, looking into it.", CreatedAt: now.Add(-2 * day)})
+
+	return w
+}
@@ -0,0 +1,526 @@
+package apitest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object is a GraphQL object. Values are scalars, nested objects, lists of
+// objects or resolvers for fields that are computed or take arguments.
+type object map[string]interface{}
+
+// resolver computes a field from its arguments
+type resolver func(args map[string]interface{}) (interface{}, error)
+
+// lazy wraps a field that takes no arguments so it is only built when
+// selected, which keeps cyclic references (issue → team → ...) finite
+func lazy(fn func() interface{}) resolver {
+	return func(map[string]interface{}) (interface{}, error) {
+		return fn(), nil
+	}
+}
+
+// asResolver returns v as a resolver when it is one. Root fields are
+// written as plain function literals, so both forms are accepted.
+func asResolver(v interface{}) (resolver, bool) {
+	switch fn := v.(type) {
+	case resolver:
+		return fn, true
+	case func(map[string]interface{}) (interface{}, error):
+		return fn, true
+	}
+	return nil, false
+}
+
+// gqlError is an entry in a response's errors list
+type gqlError struct {
+	Message    string                 `json:"message"`
+	Path       []string               `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *gqlError) Error() string {
+	return e.Message
+}
+
+// notFound is the error Linear returns for a missing entity
+func notFound(kind string) error {
+	return &gqlError{
+		Message:    "Entity not found: " + kind,
+		Extensions: map[string]interface{}{"code": "INVALID_INPUT"},
+	}
+}
+
+// invalidInput is the error Linear returns for a bad argument
+func invalidInput(format string, args ...interface{}) error {
+	return &gqlError{
+		Message:    "Argument Validation Error: " + fmt.Sprintf(format, args...),
+		Extensions: map[string]interface{}{"code": "INVALID_INPUT"},
+	}
+}
+
+// execute resolves the selections against root
+func execute(root object, selections []selection, vars map[string]interface{}) (map[string]interface{}, error) {
+	data := make(map[string]interface{}, len(selections))
+	for _, sel := range selections {
+		v, err := resolveField(root, sel, vars)
+		if err != nil {
+			if ge, ok := err.(*gqlError); ok && ge.Path == nil {
+				ge.Path = []string{sel.key()}
+			}
+			return nil, err
+		}
+		data[sel.key()] = v
+	}
+	return data, nil
+}
+
+func resolveField(obj object, sel selection, vars map[string]interface{}) (interface{}, error) {
+	if sel.name == "__typename" {
+		return "", nil
+	}
+	v, ok := obj[sel.name]
+	if !ok {
+		return nil, &gqlError{
+			Message:    fmt.Sprintf("Cannot query field %q", sel.name),
+			Extensions: map[string]interface{}{"code": "GRAPHQL_VALIDATION_FAILED"},
+		}
+	}
+	if fn, ok := asResolver(v); ok {
+		args, err := resolveArgs(sel.args, vars)
+		if err != nil {
+			return nil, err
+		}
+		v, err = fn(args)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return project(v, sel.selections, vars)
+}
+
+// project narrows v to the selected fields
+func project(v interface{}, selections []selection, vars map[string]interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case object:
+		if v == nil {
+			return nil, nil
+		}
+		if len(selections) == 0 {
+			return nil, fmt.Errorf("field of object type needs a selection")
+		}
+		out := make(map[string]interface{}, len(selections))
+		for _, sel := range selections {
+			fv, err := resolveField(v, sel, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[sel.key()] = fv
+		}
+		return out, nil
+	case []object:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			pv, err := project(item, selections, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = pv
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// resolveArgs substitutes variables and enums into argument values
+func resolveArgs(args map[string]value, vars map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		out[k] = resolveValue(v, vars)
+	}
+	return out, nil
+}
+
+func resolveValue(v value, vars map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case variable:
+		return vars[string(v)]
+	case enum:
+		return string(v)
+	case []value:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = resolveValue(item, vars)
+		}
+		return out
+	case map[string]value:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = resolveValue(item, vars)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// connection pages through nodes, applying the filter, first and after
+// arguments. Cursors are node IDs.
+func connection(nodes []object, args map[string]interface{}) (interface{}, error) {
+	if f, ok := args["filter"].(map[string]interface{}); ok {
+		filtered := nodes[:0:0]
+		for _, n := range nodes {
+			ok, err := matches(n, f)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				filtered = append(filtered, n)
+			}
+		}
+		nodes = filtered
+	}
+
+	hasPrevious := false
+	if after, ok := args["after"].(string); ok && after != "" {
+		start := -1
+		for i, n := range nodes {
+			if n["id"] == after {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, invalidInput("unknown cursor %q", after)
+		}
+		nodes = nodes[start:]
+		hasPrevious = start > 0
+	}
+
+	hasNext := false
+	if first, ok := toFloat(args["first"]); ok && int(first) < len(nodes) {
+		nodes = nodes[:int(first)]
+		hasNext = true
+	}
+
+	var startCursor, endCursor interface{}
+	if len(nodes) > 0 {
+		startCursor = nodes[0]["id"]
+		endCursor = nodes[len(nodes)-1]["id"]
+	}
+
+	return object{
+		"nodes": nodes,
+		"pageInfo": object{
+			"hasNextPage":     hasNext,
+			"hasPreviousPage": hasPrevious,
+			"startCursor":     startCursor,
+			"endCursor":       endCursor,
+		},
+	}, nil
+}
+
+// connectionOf builds a connection field over nodes computed on demand
+func connectionOf(nodes func() []object) resolver {
+	return func(args map[string]interface{}) (interface{}, error) {
+		return connection(nodes(), args)
+	}
+}
+
+// matches reports whether obj satisfies a Linear filter such as
+// {"team": {"id": {"eq": "..."}}, "or": [...]}
+func matches(obj object, filter map[string]interface{}) (bool, error) {
+	for key, cond := range filter {
+		switch key {
+		case "and", "or":
+			list, _ := cond.([]interface{})
+			matched := false
+			for _, item := range list {
+				sub, _ := item.(map[string]interface{})
+				ok, err := matches(obj, sub)
+				if err != nil {
+					return false, err
+				}
+				if key == "and" && !ok {
+					return false, nil
+				}
+				matched = matched || ok
+			}
+			if key == "or" && len(list) > 0 && !matched {
+				return false, nil
+			}
+			continue
+		}
+
+		v, ok := obj[key]
+		if !ok {
+			return false, invalidInput("unsupported filter field %q", key)
+		}
+		if fn, ok := asResolver(v); ok {
+			var err error
+			if v, err = fn(nil); err != nil {
+				return false, err
+			}
+		}
+		c, ok := cond.(map[string]interface{})
+		if !ok {
+			return false, invalidInput("filter on %q must be an object", key)
+		}
+		ok, err := matchValue(v, c)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchValue applies comparators, or a nested filter for relations
+func matchValue(v interface{}, cond map[string]interface{}) (bool, error) {
+	if isNull, ok := cond["null"].(bool); ok {
+		if isNil(v) != isNull {
+			return false, nil
+		}
+		if len(cond) == 1 {
+			return true, nil
+		}
+	}
+
+	if obj, ok := v.(object); ok && obj != nil {
+		if nodes, ok := obj["nodes"].([]object); ok {
+			return matchCollection(nodes, cond)
+		}
+		rest := make(map[string]interface{}, len(cond))
+		for k, c := range cond {
+			if k != "null" {
+				rest[k] = c
+			}
+		}
+		return matches(obj, rest)
+	}
+	if isNil(v) {
+		return false, nil
+	}
+
+	for op, arg := range cond {
+		if op == "null" {
+			continue
+		}
+		ok, err := compareScalar(v, op, arg)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchCollection applies some, every and none to a to-many relation
+func matchCollection(nodes []object, cond map[string]interface{}) (bool, error) {
+	for op, arg := range cond {
+		sub, _ := arg.(map[string]interface{})
+		count := 0
+		for _, n := range nodes {
+			ok, err := matches(n, sub)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				count++
+			}
+		}
+		switch op {
+		case "some":
+			if count == 0 {
+				return false, nil
+			}
+		case "every":
+			if count != len(nodes) {
+				return false, nil
+			}
+		case "none":
+			if count != 0 {
+				return false, nil
+			}
+		case "null":
+		default:
+			return false, invalidInput("unsupported collection comparator %q", op)
+		}
+	}
+	return true, nil
+}
+
+func compareScalar(v interface{}, op string, arg interface{}) (bool, error) {
+	switch op {
+	case "eq":
+		return equal(v, arg), nil
+	case "neq":
+		return !equal(v, arg), nil
+	case "in", "nin":
+		list, _ := arg.([]interface{})
+		found := false
+		for _, item := range list {
+			if equal(v, item) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "in"), nil
+	case "eqIgnoreCase", "neqIgnoreCase":
+		eq := strings.EqualFold(fmt.Sprint(v), fmt.Sprint(arg))
+		return eq == (op == "eqIgnoreCase"), nil
+	case "contains", "notContains":
+		ok := strings.Contains(fmt.Sprint(v), fmt.Sprint(arg))
+		return ok == (op == "contains"), nil
+	case "containsIgnoreCase", "notContainsIgnoreCase":
+		ok := strings.Contains(strings.ToLower(fmt.Sprint(v)), strings.ToLower(fmt.Sprint(arg)))
+		return ok == (op == "containsIgnoreCase"), nil
+	case "startsWith":
+		return strings.HasPrefix(fmt.Sprint(v), fmt.Sprint(arg)), nil
+	case "endsWith":
+		return strings.HasSuffix(fmt.Sprint(v), fmt.Sprint(arg)), nil
+	case "lt", "lte", "gt", "gte":
+		c, err := order(v, arg)
+		if err != nil {
+			return false, err
+		}
+		switch op {
+		case "lt":
+			return c < 0, nil
+		case "lte":
+			return c <= 0, nil
+		case "gt":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+	return false, invalidInput("unsupported comparator %q", op)
+}
+
+func equal(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return a == b
+}
+
+// order compares numbers, timestamps against dates or ISO 8601 durations
+// relative to now (-P7D), and otherwise strings
+func order(v, arg interface{}) (int, error) {
+	if fv, ok := toFloat(v); ok {
+		fa, ok := toFloat(arg)
+		if !ok {
+			return 0, invalidInput("cannot compare %v with %v", v, arg)
+		}
+		switch {
+		case fv < fa:
+			return -1, nil
+		case fv > fa:
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	s, _ := v.(string)
+	a, _ := arg.(string)
+	if tv, ok := parseTime(s); ok {
+		ta, ok := parseTime(a)
+		if !ok {
+			ta, ok = parseDuration(a)
+		}
+		if !ok {
+			return 0, invalidInput("invalid date %q", a)
+		}
+		return tv.Compare(ta), nil
+	}
+	return strings.Compare(s, a), nil
+}
+
+// isNil reports whether v is nil or a nil object
+func isNil(v interface{}) bool {
+	if obj, ok := v.(object); ok {
+		return obj == nil
+	}
+	return v == nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isoDuration matches the durations the filter package produces
+var isoDuration = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(\d+)H)?$`)
+
+// parseDuration turns a duration such as -P7D into a time relative to now
+func parseDuration(s string) (time.Time, bool) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n := func(i int) int {
+		v, _ := strconv.Atoi(m[i])
+		return v
+	}
+	sign := 1
+	if m[1] == "-" {
+		sign = -1
+	}
+	t := time.Now().AddDate(sign*n(2), sign*n(3), sign*(7*n(4)+n(5)))
+	return t.Add(time.Duration(sign*n(6)) * time.Hour), true
+}
+
+// timestamp formats t the way Linear does
+func timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// optionalTimestamp formats t, or returns nil when it is not set
+func optionalTimestamp(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// sortedBy returns objs sorted by a string field, newest first when desc
+func sortedBy(objs []object, field string, desc bool) []object {
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, _ := objs[i][field].(string)
+		b, _ := objs[j][field].(string)
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+	return objs
+}
@@ -0,0 +1,283 @@
+package apitest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// operation is a parsed GraphQL operation
+type operation struct {
+	kind       string // query or mutation
+	selections []selection
+}
+
+// selection is a field in a selection set
+type selection struct {
+	alias      string
+	name       string
+	args       map[string]value
+	selections []selection
+}
+
+// key is the name the field is returned under
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// value is an argument value, resolved against the request variables when
+// the field is executed
+type value interface{}
+
+// variable refers to a request variable by name
+type variable string
+
+// enum is a bare enum value such as createdAt
+type enum string
+
+// parseOperation parses a single-operation GraphQL document. Fragments and
+// directives are not supported; LinearClient doesn't send them.
+func parseOperation(src string) (*operation, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	op := &operation{kind: "query"}
+	if p.peek() != "{" {
+		kind := p.next()
+		if kind != "query" && kind != "mutation" {
+			return nil, fmt.Errorf("unsupported operation %q", kind)
+		}
+		op.kind = kind
+		if isName(p.peek()) {
+			p.next()
+		}
+		if p.peek() == "(" {
+			p.skipBalanced("(", ")")
+		}
+	}
+
+	op.selections, err = p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "" {
+		return nil, fmt.Errorf("unexpected %q after operation", p.peek())
+	}
+	return op, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(t string) error {
+	if got := p.next(); got != t {
+		return fmt.Errorf("expected %q, got %q", t, got)
+	}
+	return nil
+}
+
+// skipBalanced skips a bracketed group such as variable definitions
+func (p *parser) skipBalanced(open, close string) {
+	depth := 0
+	for {
+		switch p.next() {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return
+			}
+		case "":
+			return
+		}
+	}
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []selection
+	for p.peek() != "}" {
+		if p.peek() == "" {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+		s, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+	p.next()
+	return selections, nil
+}
+
+func (p *parser) field() (selection, error) {
+	var s selection
+	name := p.next()
+	if !isName(name) {
+		return s, fmt.Errorf("expected field name, got %q", name)
+	}
+	if p.peek() == ":" {
+		p.next()
+		s.alias = name
+		name = p.next()
+		if !isName(name) {
+			return s, fmt.Errorf("expected field name, got %q", name)
+		}
+	}
+	s.name = name
+
+	if p.peek() == "(" {
+		p.next()
+		s.args = map[string]value{}
+		for p.peek() != ")" {
+			arg := p.next()
+			if !isName(arg) {
+				return s, fmt.Errorf("expected argument name, got %q", arg)
+			}
+			if err := p.expect(":"); err != nil {
+				return s, err
+			}
+			v, err := p.value()
+			if err != nil {
+				return s, err
+			}
+			s.args[arg] = v
+		}
+		p.next()
+	}
+
+	if p.peek() == "{" {
+		var err error
+		s.selections, err = p.selectionSet()
+		if err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+func (p *parser) value() (value, error) {
+	t := p.next()
+	switch {
+	case t == "$":
+		return variable(p.next()), nil
+	case t == "[":
+		var list []value
+		for p.peek() != "]" {
+			if p.peek() == "" {
+				return nil, fmt.Errorf("unterminated list")
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		p.next()
+		return list, nil
+	case t == "{":
+		obj := map[string]value{}
+		for p.peek() != "}" {
+			key := p.next()
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = v
+		}
+		p.next()
+		return obj, nil
+	case strings.HasPrefix(t, `"`):
+		return strconv.Unquote(t)
+	case t == "true":
+		return true, nil
+	case t == "false":
+		return false, nil
+	case t == "null":
+		return nil, nil
+	case t != "" && (t[0] == '-' || unicode.IsDigit(rune(t[0]))):
+		return strconv.ParseFloat(t, 64)
+	case isName(t):
+		return enum(t), nil
+	}
+	return nil, fmt.Errorf("unexpected %q in value", t)
+}
+
+// tokenize splits a document into names, numbers, strings and punctuation.
+// Commas and comments are dropped, as GraphQL treats them as whitespace.
+func tokenize(src string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.ContainsRune("{}()[]:$!=@", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, src[i:j+1])
+			i = j + 1
+		case c == '-' || c == '_' || c == '.' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, src[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+func isName(t string) bool {
+	if t == "" {
+		return false
+	}
+	c := rune(t[0])
+	return c == '_' || unicode.IsLetter(c)
+}
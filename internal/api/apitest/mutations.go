@@ -0,0 +1,268 @@
+package apitest
+
+// mutationRoot returns the mutations LinearClient sends. Inputs follow
+// Linear's schema: omitted fields are left alone and null clears a field.
+func (w *Workspace) mutationRoot() object {
+	return object{
+		"issueCreate": func(args map[string]interface{}) (interface{}, error) {
+			input, _ := args["input"].(map[string]interface{})
+			i, err := w.createIssue(input)
+			if err != nil {
+				return nil, err
+			}
+			return payload("issue", w.issueObject(i)), nil
+		},
+		"issueUpdate": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			i := w.issue(id)
+			if i == nil {
+				return nil, notFound("Issue")
+			}
+			input, _ := args["input"].(map[string]interface{})
+			if err := w.updateIssue(i, input); err != nil {
+				return nil, err
+			}
+			return payload("issue", w.issueObject(i)), nil
+		},
+		"issueRelationCreate": func(args map[string]interface{}) (interface{}, error) {
+			input, _ := args["input"].(map[string]interface{})
+			issueID, _ := input["issueId"].(string)
+			relatedID, _ := input["relatedIssueId"].(string)
+			relationType, _ := input["type"].(string)
+			issue, related := w.issue(issueID), w.issue(relatedID)
+			if issue == nil || related == nil {
+				return nil, notFound("Issue")
+			}
+			switch relationType {
+			case "blocks", "duplicate", "related", "similar":
+			default:
+				return nil, invalidInput("invalid relation type %q", relationType)
+			}
+			r := w.addRelation(IssueRelation{Type: relationType, IssueID: issue.ID, RelatedIssueID: related.ID})
+			return payload("issueRelation", w.relationObject(r)), nil
+		},
+		"issueRelationDelete": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			for n, r := range w.Relations {
+				if r.ID == id {
+					w.Relations = append(w.Relations[:n], w.Relations[n+1:]...)
+					return object{"success": true}, nil
+				}
+			}
+			return nil, notFound("IssueRelation")
+		},
+		"commentCreate": func(args map[string]interface{}) (interface{}, error) {
+			input, _ := args["input"].(map[string]interface{})
+			issueID, _ := input["issueId"].(string)
+			body, _ := input["body"].(string)
+			parentID, _ := input["parentId"].(string)
+			issue := w.issue(issueID)
+			if issue == nil {
+				return nil, notFound("Issue")
+			}
+			if body == "" {
+				return nil, invalidInput("body must not be empty")
+			}
+			if parentID != "" && w.comment(parentID) == nil {
+				return nil, notFound("Comment")
+			}
+			c := w.addComment(Comment{IssueID: issue.ID, UserID: w.ViewerID, ParentID: parentID, Body: body})
+			return payload("comment", w.commentObject(c)), nil
+		},
+		"commentUpdate": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			c := w.comment(id)
+			if c == nil {
+				return nil, notFound("Comment")
+			}
+			input, _ := args["input"].(map[string]interface{})
+			if body, ok := input["body"].(string); ok {
+				now := w.Now()
+				c.Body = body
+				c.UpdatedAt = now
+				c.EditedAt = &now
+			}
+			return payload("comment", w.commentObject(c)), nil
+		},
+		"commentDelete": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			if w.comment(id) == nil {
+				return nil, notFound("Comment")
+			}
+			// Replies go with the comment they answer
+			kept := w.Comments[:0]
+			for _, c := range w.Comments {
+				if c.ID != id && c.ParentID != id {
+					kept = append(kept, c)
+				}
+			}
+			w.Comments = kept
+			return object{"success": true}, nil
+		},
+	}
+}
+
+// payload is a successful mutation result
+func payload(field string, entity object) object {
+	return object{"success": true, field: entity}
+}
+
+func (w *Workspace) createIssue(input map[string]interface{}) (*Issue, error) {
+	teamID, _ := input["teamId"].(string)
+	team := w.team(teamID)
+	if team == nil {
+		return nil, notFound("Team")
+	}
+	title, _ := input["title"].(string)
+	if title == "" {
+		return nil, invalidInput("title must not be empty")
+	}
+
+	i := &Issue{TeamID: team.ID, Title: title, CreatorID: w.ViewerID}
+	if err := w.applyIssueInput(i, input); err != nil {
+		return nil, err
+	}
+	created := w.addIssue(*i)
+	if s := w.state(created.StateID); s != nil {
+		w.setState(created, s)
+	}
+	return created, nil
+}
+
+func (w *Workspace) updateIssue(i *Issue, input map[string]interface{}) error {
+	// Validate on a copy so a bad input changes nothing
+	updated := *i
+	updated.LabelIDs = append([]string(nil), i.LabelIDs...)
+	if err := w.applyIssueInput(&updated, input); err != nil {
+		return err
+	}
+	if title, ok := input["title"]; ok {
+		s, _ := title.(string)
+		if s == "" {
+			return invalidInput("title must not be empty")
+		}
+		updated.Title = s
+	}
+	*i = updated
+	w.touch(i)
+	return nil
+}
+
+// applyIssueInput applies the fields shared by IssueCreateInput and
+// IssueUpdateInput
+func (w *Workspace) applyIssueInput(i *Issue, input map[string]interface{}) error {
+	for key, v := range input {
+		s, _ := v.(string)
+		switch key {
+		case "teamId", "title":
+			// Handled by the caller
+		case "description":
+			i.Description = s
+		case "priority":
+			p, _ := toFloat(v)
+			if p < 0 || p > 4 {
+				return invalidInput("priority must be between 0 and 4")
+			}
+			i.Priority = int(p)
+		case "estimate":
+			if e, ok := toFloat(v); ok {
+				i.Estimate = &e
+			} else {
+				i.Estimate = nil
+			}
+		case "dueDate":
+			if _, ok := parseTime(s); s != "" && !ok {
+				return invalidInput("invalid due date %q", s)
+			}
+			i.DueDate = s
+		case "stateId":
+			state := w.state(s)
+			if state == nil || state.TeamID != i.TeamID {
+				return notFound("WorkflowState")
+			}
+			w.setState(i, state)
+		case "assigneeId":
+			if s != "" && w.user(s) == nil {
+				return notFound("User")
+			}
+			i.AssigneeID = s
+		case "projectId":
+			if s != "" && w.project(s) == nil {
+				return notFound("Project")
+			}
+			i.ProjectID = s
+		case "cycleId":
+			if c := w.cycle(s); s != "" && (c == nil || c.TeamID != i.TeamID) {
+				return notFound("Cycle")
+			}
+			i.CycleID = s
+		case "parentId":
+			if s == "" {
+				i.ParentID = ""
+				continue
+			}
+			parent := w.issue(s)
+			if parent == nil {
+				return notFound("Issue")
+			}
+			if parent.ID == i.ID {
+				return invalidInput("an issue cannot be its own parent")
+			}
+			i.ParentID = parent.ID
+		case "labelIds":
+			ids, err := w.labelIDs(v)
+			if err != nil {
+				return err
+			}
+			i.LabelIDs = ids
+		case "addedLabelIds":
+			ids, err := w.labelIDs(v)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if !contains(i.LabelIDs, id) {
+					i.LabelIDs = append(i.LabelIDs, id)
+				}
+			}
+		case "removedLabelIds":
+			ids, err := w.labelIDs(v)
+			if err != nil {
+				return err
+			}
+			kept := i.LabelIDs[:0]
+			for _, id := range i.LabelIDs {
+				if !contains(ids, id) {
+					kept = append(kept, id)
+				}
+			}
+			i.LabelIDs = kept
+		default:
+			return invalidInput("unsupported issue field %q", key)
+		}
+	}
+	return nil
+}
+
+// labelIDs checks that every label in v exists
+func (w *Workspace) labelIDs(v interface{}) ([]string, error) {
+	list, _ := v.([]interface{})
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		id, _ := item.(string)
+		if w.label(id) == nil {
+			return nil, notFound("IssueLabel")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
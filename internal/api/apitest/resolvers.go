@@ -0,0 +1,452 @@
+package apitest
+
+import (
+	"sort"
+	"strings"
+)
+
+// The methods in this file expose the workspace as GraphQL objects. They
+// are called with w.mu held.
+
+// root returns the query or mutation root object
+func (w *Workspace) root(kind string) object {
+	if kind == "mutation" {
+		return w.mutationRoot()
+	}
+	return w.queryRoot()
+}
+
+func (w *Workspace) queryRoot() object {
+	return object{
+		"viewer": lazy(func() interface{} {
+			return w.userObject(w.user(w.ViewerID))
+		}),
+		"organization": lazy(func() interface{} {
+			return w.organizationObject()
+		}),
+		"users": connectionOf(func() []object {
+			objs := make([]object, 0, len(w.Users))
+			for _, u := range w.Users {
+				objs = append(objs, w.userObject(u).(object))
+			}
+			return objs
+		}),
+		"teams": connectionOf(w.teamObjects),
+		"team": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			for _, t := range w.Teams {
+				if t.ID == id || strings.EqualFold(t.Key, id) {
+					return w.teamObject(t), nil
+				}
+			}
+			return nil, notFound("Team")
+		},
+		"issueLabels": connectionOf(func() []object {
+			objs := make([]object, 0, len(w.Labels))
+			for _, l := range w.Labels {
+				objs = append(objs, w.labelObject(l))
+			}
+			return objs
+		}),
+		"workflowStates": connectionOf(func() []object {
+			objs := make([]object, 0, len(w.States))
+			for _, s := range w.States {
+				objs = append(objs, w.stateObject(s))
+			}
+			return objs
+		}),
+		"issues": connectionOf(func() []object {
+			return w.issueObjects(func(*Issue) bool { return true })
+		}),
+		"issue": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			i := w.issue(id)
+			if i == nil {
+				return nil, notFound("Issue")
+			}
+			return w.issueObject(i), nil
+		},
+		"comment": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			c := w.comment(id)
+			if c == nil {
+				return nil, notFound("Comment")
+			}
+			return w.commentObject(c), nil
+		},
+		"projects": connectionOf(func() []object {
+			objs := make([]object, 0, len(w.Projects))
+			for _, p := range w.Projects {
+				objs = append(objs, w.projectObject(p))
+			}
+			return objs
+		}),
+		"project": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			p := w.project(id)
+			if p == nil {
+				return nil, notFound("Project")
+			}
+			return w.projectObject(p), nil
+		},
+		"initiatives": connectionOf(func() []object {
+			objs := make([]object, 0, len(w.Initiatives))
+			for _, i := range w.Initiatives {
+				objs = append(objs, w.initiativeObject(i))
+			}
+			return objs
+		}),
+		"initiative": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			i := w.initiative(id)
+			if i == nil {
+				return nil, notFound("Initiative")
+			}
+			return w.initiativeObject(i), nil
+		},
+		"cycles": connectionOf(func() []object {
+			objs := make([]object, 0, len(w.Cycles))
+			for _, c := range w.Cycles {
+				objs = append(objs, w.cycleObject(c))
+			}
+			return objs
+		}),
+		"cycle": func(args map[string]interface{}) (interface{}, error) {
+			id, _ := args["id"].(string)
+			c := w.cycle(id)
+			if c == nil {
+				return nil, notFound("Cycle")
+			}
+			return w.cycleObject(c), nil
+		},
+	}
+}
+
+func (w *Workspace) organizationObject() object {
+	o := w.Organization
+	return object{
+		"id":        o.ID,
+		"name":      o.Name,
+		"urlKey":    o.URLKey,
+		"logoUrl":   o.LogoURL,
+		"userCount": len(w.Users),
+	}
+}
+
+func (w *Workspace) userObject(u *User) interface{} {
+	if u == nil {
+		return nil
+	}
+	return object{
+		"id":          u.ID,
+		"name":        u.Name,
+		"displayName": u.DisplayName,
+		"email":       u.Email,
+		"active":      u.Active,
+		"admin":       u.Admin,
+		"avatarUrl":   u.AvatarURL,
+		"isMe":        u.ID == w.ViewerID,
+	}
+}
+
+func (w *Workspace) teamObjects() []object {
+	objs := make([]object, 0, len(w.Teams))
+	for _, t := range w.Teams {
+		objs = append(objs, w.teamObject(t).(object))
+	}
+	return objs
+}
+
+func (w *Workspace) teamObject(t *Team) interface{} {
+	if t == nil {
+		return nil
+	}
+	return object{
+		"id":          t.ID,
+		"name":        t.Name,
+		"key":         t.Key,
+		"description": t.Description,
+		"private":     t.Private,
+		"activeCycle": lazy(func() interface{} {
+			for _, c := range w.Cycles {
+				if c.TeamID == t.ID && w.cycleIsActive(c) {
+					return w.cycleObject(c)
+				}
+			}
+			return nil
+		}),
+	}
+}
+
+func (w *Workspace) stateObject(s *WorkflowState) object {
+	return object{
+		"id":       s.ID,
+		"name":     s.Name,
+		"color":    s.Color,
+		"type":     s.Type,
+		"position": s.Position,
+		"team":     lazy(func() interface{} { return w.teamObject(w.team(s.TeamID)) }),
+	}
+}
+
+func (w *Workspace) labelObject(l *Label) object {
+	return object{
+		"id":          l.ID,
+		"name":        l.Name,
+		"description": l.Description,
+		"color":       l.Color,
+		"team":        lazy(func() interface{} { return w.teamObject(w.team(l.TeamID)) }),
+	}
+}
+
+// issueObjects returns the issues that keep reports true
+func (w *Workspace) issueObjects(keep func(*Issue) bool) []object {
+	var objs []object
+	for _, i := range w.Issues {
+		if keep(i) {
+			objs = append(objs, w.issueObject(i))
+		}
+	}
+	return objs
+}
+
+func (w *Workspace) issueObject(i *Issue) object {
+	var estimate interface{}
+	if i.Estimate != nil {
+		estimate = *i.Estimate
+	}
+	return object{
+		"id":          i.ID,
+		"identifier":  w.identifier(i),
+		"number":      i.Number,
+		"title":       i.Title,
+		"description": i.Description,
+		"priority":    i.Priority,
+		"estimate":    estimate,
+		"url":         w.issueURL(i),
+		"createdAt":   timestamp(i.CreatedAt),
+		"updatedAt":   timestamp(i.UpdatedAt),
+		"completedAt": optionalTimestamp(i.CompletedAt),
+		"dueDate":     optionalString(i.DueDate),
+		"state": lazy(func() interface{} {
+			if s := w.state(i.StateID); s != nil {
+				return w.stateObject(s)
+			}
+			return nil
+		}),
+		"assignee": lazy(func() interface{} { return w.userObject(w.user(i.AssigneeID)) }),
+		"creator":  lazy(func() interface{} { return w.userObject(w.user(i.CreatorID)) }),
+		"team":     lazy(func() interface{} { return w.teamObject(w.team(i.TeamID)) }),
+		"project": lazy(func() interface{} {
+			if p := w.project(i.ProjectID); p != nil {
+				return w.projectObject(p)
+			}
+			return nil
+		}),
+		"cycle": lazy(func() interface{} {
+			if c := w.cycle(i.CycleID); c != nil {
+				return w.cycleObject(c)
+			}
+			return nil
+		}),
+		"parent": lazy(func() interface{} { return w.issueRef(i.ParentID) }),
+		"labels": connectionOf(func() []object {
+			var objs []object
+			for _, id := range i.LabelIDs {
+				if l := w.label(id); l != nil {
+					objs = append(objs, w.labelObject(l))
+				}
+			}
+			return objs
+		}),
+		"attachments": connectionOf(func() []object { return nil }),
+		"children": connectionOf(func() []object {
+			return w.issueObjects(func(c *Issue) bool { return c.ParentID == i.ID })
+		}),
+		"relations": connectionOf(func() []object {
+			var objs []object
+			for _, r := range w.Relations {
+				if r.IssueID == i.ID {
+					objs = append(objs, w.relationObject(r))
+				}
+			}
+			return objs
+		}),
+		"inverseRelations": connectionOf(func() []object {
+			var objs []object
+			for _, r := range w.Relations {
+				if r.RelatedIssueID == i.ID {
+					objs = append(objs, w.relationObject(r))
+				}
+			}
+			return objs
+		}),
+		"comments": connectionOf(func() []object {
+			var objs []object
+			for _, c := range w.Comments {
+				if c.IssueID == i.ID {
+					objs = append(objs, w.commentObject(c))
+				}
+			}
+			// Linear lists the most recent comments first
+			return sortedBy(objs, "createdAt", true)
+		}),
+	}
+}
+
+// issueRef returns the issue with the given ID, or nil
+func (w *Workspace) issueRef(id string) interface{} {
+	if i := w.issue(id); i != nil {
+		return w.issueObject(i)
+	}
+	return nil
+}
+
+func (w *Workspace) issueURL(i *Issue) string {
+	return "https://linear.app/" + w.Organization.URLKey + "/issue/" + w.identifier(i)
+}
+
+func (w *Workspace) relationObject(r *IssueRelation) object {
+	return object{
+		"id":           r.ID,
+		"type":         r.Type,
+		"issue":        lazy(func() interface{} { return w.issueRef(r.IssueID) }),
+		"relatedIssue": lazy(func() interface{} { return w.issueRef(r.RelatedIssueID) }),
+	}
+}
+
+func (w *Workspace) commentObject(c *Comment) object {
+	return object{
+		"id":        c.ID,
+		"body":      c.Body,
+		"url":       w.commentURL(c),
+		"createdAt": timestamp(c.CreatedAt),
+		"updatedAt": timestamp(c.UpdatedAt),
+		"editedAt":  optionalTimestamp(c.EditedAt),
+		"user":      lazy(func() interface{} { return w.userObject(w.user(c.UserID)) }),
+		"parent": lazy(func() interface{} {
+			if p := w.comment(c.ParentID); p != nil {
+				return w.commentObject(p)
+			}
+			return nil
+		}),
+		"issue": lazy(func() interface{} { return w.issueRef(c.IssueID) }),
+	}
+}
+
+func (w *Workspace) commentURL(c *Comment) string {
+	anchor := "#comment-" + c.ID
+	if i := w.issue(c.IssueID); i != nil {
+		return w.issueURL(i) + anchor
+	}
+	return anchor
+}
+
+func (w *Workspace) projectObject(p *Project) object {
+	teams := connectionOf(func() []object {
+		var objs []object
+		for _, id := range p.TeamIDs {
+			if t := w.team(id); t != nil {
+				objs = append(objs, w.teamObject(t).(object))
+			}
+		}
+		return objs
+	})
+	return object{
+		"id":              p.ID,
+		"name":            p.Name,
+		"slugId":          p.SlugID,
+		"description":     p.Description,
+		"state":           p.State,
+		"progress":        p.Progress,
+		"startDate":       optionalString(p.StartDate),
+		"targetDate":      optionalString(p.TargetDate),
+		"url":             "https://linear.app/" + w.Organization.URLKey + "/project/" + p.SlugID,
+		"createdAt":       timestamp(p.CreatedAt),
+		"updatedAt":       timestamp(p.UpdatedAt),
+		"lead":            lazy(func() interface{} { return w.userObject(w.user(p.LeadID)) }),
+		"teams":           teams,
+		"accessibleTeams": teams,
+	}
+}
+
+func (w *Workspace) cycleObject(c *Cycle) object {
+	return object{
+		"id":          c.ID,
+		"name":        c.Name,
+		"number":      c.Number,
+		"description": c.Description,
+		"progress":    c.Progress,
+		"startsAt":    timestamp(c.StartsAt),
+		"endsAt":      timestamp(c.EndsAt),
+		"team":        lazy(func() interface{} { return w.teamObject(w.team(c.TeamID)) }),
+		"isActive":    w.cycleIsActive(c),
+		"isNext":      w.adjacentCycle(c.TeamID, 1) == c,
+		"isPrevious":  w.adjacentCycle(c.TeamID, -1) == c,
+	}
+}
+
+func (w *Workspace) cycleIsActive(c *Cycle) bool {
+	now := w.Now()
+	return !now.Before(c.StartsAt) && now.Before(c.EndsAt)
+}
+
+// adjacentCycle returns the team's next (direction 1) or previous
+// (direction -1) cycle relative to now
+func (w *Workspace) adjacentCycle(teamID string, direction int) *Cycle {
+	now := w.Now()
+	var cycles []*Cycle
+	for _, c := range w.Cycles {
+		if c.TeamID != teamID {
+			continue
+		}
+		if (direction > 0 && c.StartsAt.After(now)) || (direction < 0 && !c.EndsAt.After(now)) {
+			cycles = append(cycles, c)
+		}
+	}
+	if len(cycles) == 0 {
+		return nil
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].StartsAt.Before(cycles[j].StartsAt) })
+	if direction > 0 {
+		return cycles[0]
+	}
+	return cycles[len(cycles)-1]
+}
+
+func (w *Workspace) initiativeObject(i *Initiative) object {
+	return object{
+		"id":          i.ID,
+		"name":        i.Name,
+		"description": i.Description,
+		"targetDate":  optionalString(i.TargetDate),
+		"createdAt":   timestamp(i.CreatedAt),
+		"updatedAt":   timestamp(i.UpdatedAt),
+		"owner":       lazy(func() interface{} { return w.userObject(w.user(i.OwnerID)) }),
+		"projects": connectionOf(func() []object {
+			var objs []object
+			for _, id := range i.ProjectIDs {
+				if p := w.project(id); p != nil {
+					objs = append(objs, w.projectObject(p))
+				}
+			}
+			return objs
+		}),
+	}
+}
+
+// touch records a change to an issue
+func (w *Workspace) touch(i *Issue) {
+	i.UpdatedAt = w.Now()
+}
+
+// setState moves an issue to a state, tracking when it was completed
+func (w *Workspace) setState(i *Issue, s *WorkflowState) {
+	i.StateID = s.ID
+	if s.Type == "completed" {
+		now := w.Now()
+		i.CompletedAt = &now
+	} else {
+		i.CompletedAt = nil
+	}
+}
@@ -0,0 +1,110 @@
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Handler serves a workspace as a Linear GraphQL endpoint
+type Handler struct {
+	Workspace *Workspace
+	// APIKey, when set, must be sent in the Authorization header
+	APIKey string
+}
+
+// request is a GraphQL request body
+type request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// response is a GraphQL response body
+type response struct {
+	Data   interface{} `json:"data"`
+	Errors []*gqlError `json:"errors,omitempty"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "GraphQL requests must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.APIKey != "" && r.Header.Get("Authorization") != h.APIKey {
+		writeJSON(w, http.StatusUnauthorized, response{Errors: []*gqlError{{
+			Message:    "Authentication required, not authenticated",
+			Extensions: map[string]interface{}{"code": "AUTHENTICATION_ERROR"},
+		}}})
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Errors: []*gqlError{{Message: "invalid request body: " + err.Error()}}})
+		return
+	}
+
+	op, err := parseOperation(req.Query)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Errors: []*gqlError{{
+			Message:    "Syntax Error: " + err.Error(),
+			Extensions: map[string]interface{}{"code": "GRAPHQL_PARSE_FAILED"},
+		}}})
+		return
+	}
+
+	ws := h.Workspace
+	ws.mu.Lock()
+	data, err := execute(ws.root(op.kind), op.selections, req.Variables)
+	ws.mu.Unlock()
+	if err != nil {
+		ge, ok := err.(*gqlError)
+		if !ok {
+			ge = &gqlError{Message: err.Error()}
+		}
+		writeJSON(w, http.StatusOK, response{Errors: []*gqlError{ge}})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response{Data: data})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, "encode response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// Server is a fake Linear API listening on a local port
+type Server struct {
+	// URL is the GraphQL endpoint, for api.ClientOptions.Endpoint
+	URL       string
+	Workspace *Workspace
+
+	server *httptest.Server
+}
+
+// NewServer starts serving ws, or an empty workspace when ws is nil. Call
+// Close when done.
+func NewServer(ws *Workspace) *Server {
+	if ws == nil {
+		ws = NewWorkspace()
+	}
+	srv := httptest.NewServer(&Handler{Workspace: ws})
+	return &Server{
+		URL:       srv.URL + "/graphql",
+		Workspace: ws,
+		server:    srv,
+	}
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
@@ -0,0 +1,253 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/api/apitest"
+	"github.com/stustirling/lnr/internal/filter"
+)
+
+func newDemoClient(t *testing.T) (*api.LinearClient, *apitest.Workspace) {
+	t.Helper()
+	srv := apitest.NewServer(apitest.NewDemoWorkspace())
+	t.Cleanup(srv.Close)
+	return api.NewClientWithOptions("test-key", api.ClientOptions{Endpoint: srv.URL}), srv.Workspace
+}
+
+func TestServer_Reads(t *testing.T) {
+	client, _ := newDemoClient(t)
+	ctx := context.Background()
+
+	viewer, err := client.GetViewer(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", viewer.Name)
+
+	org, err := client.GetOrganisation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", org.Name)
+	assert.Equal(t, 3, org.UserCount)
+
+	users, err := client.GetUsers(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+
+	teams, err := client.GetTeams(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	eng := teams[0]
+	assert.Equal(t, "ENG", eng.Key)
+
+	team, err := client.GetTeam(ctx, eng.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Engineering", team.Name)
+
+	states, err := client.GetWorkflowStates(ctx, api.WorkflowStateListOptions{TeamID: &eng.ID, All: true})
+	require.NoError(t, err)
+	assert.Len(t, states, 6)
+
+	labels, err := client.GetLabels(ctx, api.LabelListOptions{All: true})
+	require.NoError(t, err)
+	assert.Len(t, labels, 3)
+
+	cycles, err := client.GetCycles(ctx, api.CycleListOptions{TeamID: &eng.ID, All: true})
+	require.NoError(t, err)
+	assert.Len(t, cycles, 3)
+
+	active, err := client.GetActiveCycle(ctx, eng.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, active.Number)
+
+	cycle, err := client.GetCycle(ctx, active.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ENG", cycle.Team.Key)
+
+	projects, err := client.GetProjects(ctx, api.ProjectListOptions{TeamID: &eng.ID, All: true})
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "Ada Lovelace", projects[0].Lead.Name)
+	assert.Len(t, projects[0].Teams, 2)
+
+	project, err := client.GetProject(ctx, projects[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Public launch", project.Name)
+
+	initiatives, err := client.GetInitiatives(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	require.Len(t, initiatives, 1)
+
+	initiative, err := client.GetInitiative(ctx, initiatives[0].ID)
+	require.NoError(t, err)
+	require.Len(t, initiative.Projects, 1)
+	assert.Equal(t, "Public launch", initiative.Projects[0].Name)
+}
+
+func TestServer_Issues(t *testing.T) {
+	client, _ := newDemoClient(t)
+	ctx := context.Background()
+
+	issue, err := client.GetIssue(ctx, "ENG-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Login fails with SSO", issue.Title)
+	assert.Equal(t, "In Progress", issue.State.Name)
+	assert.Equal(t, "Ada Lovelace", issue.Assignee.Name)
+	assert.Equal(t, "Grace Hopper", issue.Creator.Name)
+	assert.Equal(t, "Public launch", issue.Project.Name)
+	assert.Len(t, issue.Labels, 1)
+	assert.Len(t, issue.Children, 2)
+
+	children, err := client.GetSubIssues(ctx, issue.ID)
+	require.NoError(t, err)
+	assert.Len(t, children, 2)
+
+	dark, err := client.GetIssue(ctx, "ENG-4")
+	require.NoError(t, err)
+	require.Len(t, dark.Relations, 1)
+	assert.Equal(t, api.RelationBlockedBy, dark.Relations[0].Type)
+	assert.Equal(t, "DES-1", dark.Relations[0].Issue.Identifier)
+
+	_, err = client.GetIssue(ctx, "ENG-999")
+	assert.ErrorContains(t, err, "Entity not found: Issue")
+
+	results, err := client.SearchIssues(ctx, "dark", api.IssueListOptions{All: true})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestServer_IssueFiltersAndPagination(t *testing.T) {
+	client, _ := newDemoClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"assignee:@me", []string{"ENG-1"}},
+		{"team:DES", []string{"DES-1", "DES-2"}},
+		{"label:q3 state:backlog", []string{"ENG-3", "ENG-4"}},
+		{"-label:q3 team:ENG", []string{"ENG-1", "ENG-2", "ENG-5"}},
+		{"priority<=2", []string{"ENG-1", "ENG-2", "DES-1"}},
+		{"cycle:current", []string{"ENG-1", "ENG-2"}},
+		{"cycle:previous", []string{"ENG-5"}},
+		{"project:none team:ENG", []string{"ENG-2", "ENG-3", "ENG-4", "ENG-5"}},
+		{"due<2w", []string{"ENG-4"}},
+		{"created>7d", []string{"ENG-1", "ENG-2", "ENG-3", "DES-2"}},
+		{"(label:bug OR label:feature) -team:DES", []string{"ENG-1", "ENG-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			f, err := filter.ParseIssueFilter(tt.query)
+			require.NoError(t, err)
+
+			issues, err := client.GetIssues(ctx, api.IssueListOptions{Filter: f, All: true})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, identifiers(issues))
+		})
+	}
+
+	issues, err := client.GetIssues(ctx, api.IssueListOptions{Limit: 5})
+	require.NoError(t, err)
+	assert.Len(t, issues, 5)
+
+	state := "in progress"
+	issues, err = client.GetIssues(ctx, api.IssueListOptions{StateName: &state, All: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ENG-1", "DES-1"}, identifiers(issues))
+}
+
+func TestServer_Mutations(t *testing.T) {
+	client, ws := newDemoClient(t)
+	ctx := context.Background()
+
+	eng := ws.Teams[0]
+	bug := ws.Labels[0]
+	priority := 2
+	created, err := client.CreateIssue(ctx, api.IssueCreateInput{
+		TeamID:   eng.ID,
+		Title:    "New issue",
+		LabelIDs: []string{bug.ID},
+		Priority: &priority,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-6", created.Identifier)
+	assert.Equal(t, "Backlog", created.State.Name)
+	assert.Equal(t, "bug", created.Labels[0].Name)
+
+	title := "Renamed"
+	updated, err := client.UpdateIssue(ctx, "ENG-6", api.IssueUpdateInput{
+		Title:           &title,
+		RemovedLabelIDs: []string{bug.ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Title)
+	assert.Empty(t, updated.Labels)
+
+	before, err := client.GetIssue(ctx, "ENG-1")
+	require.NoError(t, err)
+	require.NotNil(t, before.Assignee)
+	require.NotNil(t, before.Cycle)
+	cleared, err := client.UpdateIssue(ctx, "ENG-1", api.IssueUpdateInput{Clear: []string{"assigneeId", "cycleId"}})
+	require.NoError(t, err)
+	assert.Nil(t, cleared.Assignee)
+	assert.Nil(t, cleared.Cycle)
+
+	relation, err := client.CreateIssueRelation(ctx, api.IssueRelationCreateInput{
+		IssueID:        created.ID,
+		RelatedIssueID: "ENG-1",
+		Type:           api.RelationBlocks,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-1", relation.Issue.Identifier)
+	require.NoError(t, client.DeleteIssueRelation(ctx, relation.ID))
+
+	comment, err := client.CreateComment(ctx, api.CommentCreateInput{IssueID: created.ID, Body: "First"})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, comment.IssueID)
+
+	reply, err := client.CreateComment(ctx, api.CommentCreateInput{IssueID: created.ID, Body: "Reply", ParentID: &comment.ID})
+	require.NoError(t, err)
+	assert.Equal(t, comment.ID, *reply.ParentID)
+
+	edited, err := client.UpdateComment(ctx, comment.ID, "First, edited")
+	require.NoError(t, err)
+	assert.NotNil(t, edited.EditedAt)
+
+	comments, err := client.GetComments(ctx, created.ID, api.ListOptions{All: true})
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "First, edited", comments[0].Body)
+
+	require.NoError(t, client.DeleteComment(ctx, comment.ID))
+	comments, err = client.GetComments(ctx, created.ID, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+
+	// States belong to a team
+	desBacklog := ws.States[5]
+	_, err = client.UpdateIssue(ctx, "ENG-6", api.IssueUpdateInput{StateID: &desBacklog.ID})
+	assert.ErrorContains(t, err, "Entity not found: WorkflowState")
+}
+
+func TestHandler_APIKey(t *testing.T) {
+	srv := httptest.NewServer(&apitest.Handler{Workspace: apitest.NewDemoWorkspace(), APIKey: "secret"})
+	defer srv.Close()
+
+	_, err := api.NewClientWithOptions("wrong", api.ClientOptions{Endpoint: srv.URL}).GetViewer(context.Background())
+	assert.Error(t, err)
+
+	viewer, err := api.NewClientWithOptions("secret", api.ClientOptions{Endpoint: srv.URL}).GetViewer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", viewer.Name)
+}
+
+func identifiers(issues []api.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.Identifier
+	}
+	return ids
+}
@@ -0,0 +1,523 @@
+// Package apitest provides an in-memory Linear workspace behind a GraphQL
+// endpoint that answers the queries and mutations LinearClient sends. It is
+// used by tests and by the hidden lnr dev fake-server command.
+package apitest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Organization is the workspace's organization
+type Organization struct {
+	ID      string
+	Name    string
+	URLKey  string
+	LogoURL string
+}
+
+// User is a workspace member
+type User struct {
+	ID          string
+	Name        string
+	DisplayName string
+	Email       string
+	Active      bool
+	Admin       bool
+	AvatarURL   string
+}
+
+// Team is a team. Adding one also adds Linear's default workflow states.
+type Team struct {
+	ID          string
+	Name        string
+	Key         string
+	Description string
+	Private     bool
+}
+
+// WorkflowState is a state in a team's workflow
+type WorkflowState struct {
+	ID       string
+	Name     string
+	Color    string
+	Type     string
+	Position int
+	TeamID   string
+}
+
+// Label is an issue label. Labels without a team are workspace labels.
+type Label struct {
+	ID          string
+	Name        string
+	Description string
+	Color       string
+	TeamID      string
+}
+
+// Issue is an issue. Empty IDs mean the relation is not set.
+type Issue struct {
+	ID          string
+	Number      int
+	TeamID      string
+	Title       string
+	Description string
+	Priority    int
+	Estimate    *float64
+	StateID     string
+	AssigneeID  string
+	CreatorID   string
+	ProjectID   string
+	CycleID     string
+	ParentID    string
+	LabelIDs    []string
+	DueDate     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// IssueRelation links two issues
+type IssueRelation struct {
+	ID             string
+	Type           string
+	IssueID        string
+	RelatedIssueID string
+}
+
+// Comment is a comment on an issue
+type Comment struct {
+	ID        string
+	IssueID   string
+	UserID    string
+	ParentID  string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	EditedAt  *time.Time
+}
+
+// Project is a project
+type Project struct {
+	ID          string
+	Name        string
+	SlugID      string
+	Description string
+	State       string
+	Progress    float64
+	StartDate   string
+	TargetDate  string
+	LeadID      string
+	TeamIDs     []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Cycle is a team's cycle
+type Cycle struct {
+	ID          string
+	Name        string
+	Number      int
+	TeamID      string
+	Description string
+	Progress    float64
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+// Initiative groups projects
+type Initiative struct {
+	ID          string
+	Name        string
+	Description string
+	TargetDate  string
+	OwnerID     string
+	ProjectIDs  []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Workspace is an in-memory Linear workspace. The Add methods fill in IDs
+// and timestamps and are safe to call while the workspace is served; read or
+// change the fields directly only while no requests are in flight.
+type Workspace struct {
+	mu sync.Mutex
+
+	Organization Organization
+	// ViewerID is the user the API key belongs to
+	ViewerID string
+	// Now returns the current time, for cycles and timestamps
+	Now func() time.Time
+
+	Teams       []*Team
+	Users       []*User
+	States      []*WorkflowState
+	Labels      []*Label
+	Issues      []*Issue
+	Relations   []*IssueRelation
+	Comments    []*Comment
+	Projects    []*Project
+	Cycles      []*Cycle
+	Initiatives []*Initiative
+
+	lastID int
+}
+
+// NewWorkspace returns an empty workspace
+func NewWorkspace() *Workspace {
+	w := &Workspace{Now: time.Now}
+	w.Organization = Organization{ID: w.newID(), Name: "Fake Workspace", URLKey: "fake"}
+	return w
+}
+
+// newID returns a unique ID in Linear's UUID format
+func (w *Workspace) newID() string {
+	w.lastID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", w.lastID)
+}
+
+func (w *Workspace) addUser(u User) *User {
+	if u.ID == "" {
+		u.ID = w.newID()
+	}
+	if u.DisplayName == "" {
+		u.DisplayName = strings.ToLower(strings.Fields(u.Name + " x")[0])
+	}
+	u.Active = true
+	w.Users = append(w.Users, &u)
+	if w.ViewerID == "" {
+		w.ViewerID = u.ID
+	}
+	return &u
+}
+
+func (w *Workspace) addTeam(t Team) *Team {
+	if t.ID == "" {
+		t.ID = w.newID()
+	}
+	w.Teams = append(w.Teams, &t)
+
+	defaults := []WorkflowState{
+		{Name: "Backlog", Type: "backlog", Color: "#bec2c8"},
+		{Name: "Todo", Type: "unstarted", Color: "#e2e2e2"},
+		{Name: "In Progress", Type: "started", Color: "#f2c94c"},
+		{Name: "Done", Type: "completed", Color: "#5e6ad2"},
+		{Name: "Canceled", Type: "canceled", Color: "#95a2b3"},
+	}
+	for i, s := range defaults {
+		s.TeamID = t.ID
+		s.Position = i
+		w.addState(s)
+	}
+	return &t
+}
+
+func (w *Workspace) addState(s WorkflowState) *WorkflowState {
+	if s.ID == "" {
+		s.ID = w.newID()
+	}
+	w.States = append(w.States, &s)
+	return &s
+}
+
+func (w *Workspace) addLabel(l Label) *Label {
+	if l.ID == "" {
+		l.ID = w.newID()
+	}
+	w.Labels = append(w.Labels, &l)
+	return &l
+}
+
+func (w *Workspace) addIssue(i Issue) *Issue {
+	if i.ID == "" {
+		i.ID = w.newID()
+	}
+	if i.Number == 0 {
+		i.Number = w.nextNumber(i.TeamID)
+	}
+	if i.StateID == "" {
+		if s := w.defaultState(i.TeamID); s != nil {
+			i.StateID = s.ID
+		}
+	}
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = w.Now()
+	}
+	if i.UpdatedAt.IsZero() {
+		i.UpdatedAt = i.CreatedAt
+	}
+	w.Issues = append(w.Issues, &i)
+	return &i
+}
+
+func (w *Workspace) addRelation(r IssueRelation) *IssueRelation {
+	if r.ID == "" {
+		r.ID = w.newID()
+	}
+	w.Relations = append(w.Relations, &r)
+	return &r
+}
+
+func (w *Workspace) addComment(c Comment) *Comment {
+	if c.ID == "" {
+		c.ID = w.newID()
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = w.Now()
+	}
+	if c.UpdatedAt.IsZero() {
+		c.UpdatedAt = c.CreatedAt
+	}
+	w.Comments = append(w.Comments, &c)
+	return &c
+}
+
+func (w *Workspace) addProject(p Project) *Project {
+	if p.ID == "" {
+		p.ID = w.newID()
+	}
+	if p.SlugID == "" {
+		p.SlugID = fmt.Sprintf("%s-%s", slugify(p.Name), p.ID[len(p.ID)-4:])
+	}
+	if p.State == "" {
+		p.State = "planned"
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = w.Now()
+	}
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = p.CreatedAt
+	}
+	w.Projects = append(w.Projects, &p)
+	return &p
+}
+
+func (w *Workspace) addCycle(c Cycle) *Cycle {
+	if c.ID == "" {
+		c.ID = w.newID()
+	}
+	w.Cycles = append(w.Cycles, &c)
+	return &c
+}
+
+func (w *Workspace) addInitiative(i Initiative) *Initiative {
+	if i.ID == "" {
+		i.ID = w.newID()
+	}
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = w.Now()
+	}
+	if i.UpdatedAt.IsZero() {
+		i.UpdatedAt = i.CreatedAt
+	}
+	w.Initiatives = append(w.Initiatives, &i)
+	return &i
+}
+
+// AddUser adds a user. The first user added becomes the viewer.
+func (w *Workspace) AddUser(u User) *User {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addUser(u)
+}
+
+// AddTeam adds a team with the Backlog, Todo, In Progress, Done and Canceled
+// states
+func (w *Workspace) AddTeam(t Team) *Team {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addTeam(t)
+}
+
+// AddState adds a workflow state
+func (w *Workspace) AddState(s WorkflowState) *WorkflowState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addState(s)
+}
+
+// AddLabel adds a label
+func (w *Workspace) AddLabel(l Label) *Label {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addLabel(l)
+}
+
+// AddIssue adds an issue. The number defaults to the team's next number and
+// the state to the team's first backlog or unstarted state.
+func (w *Workspace) AddIssue(i Issue) *Issue {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addIssue(i)
+}
+
+// AddRelation adds a relation from one issue to another
+func (w *Workspace) AddRelation(r IssueRelation) *IssueRelation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addRelation(r)
+}
+
+// AddComment adds a comment
+func (w *Workspace) AddComment(c Comment) *Comment {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addComment(c)
+}
+
+// AddProject adds a project. The slug defaults to one derived from the name.
+func (w *Workspace) AddProject(p Project) *Project {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addProject(p)
+}
+
+// AddCycle adds a cycle
+func (w *Workspace) AddCycle(c Cycle) *Cycle {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addCycle(c)
+}
+
+// AddInitiative adds an initiative
+func (w *Workspace) AddInitiative(i Initiative) *Initiative {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addInitiative(i)
+}
+
+// Issue returns an issue by ID or identifier (ENG-1), or nil
+func (w *Workspace) Issue(ref string) *Issue {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.issue(ref)
+}
+
+func (w *Workspace) issue(ref string) *Issue {
+	for _, i := range w.Issues {
+		if i.ID == ref || strings.EqualFold(w.identifier(i), ref) {
+			return i
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) identifier(i *Issue) string {
+	if t := w.team(i.TeamID); t != nil {
+		return fmt.Sprintf("%s-%d", t.Key, i.Number)
+	}
+	return fmt.Sprintf("%d", i.Number)
+}
+
+func (w *Workspace) nextNumber(teamID string) int {
+	n := 0
+	for _, i := range w.Issues {
+		if i.TeamID == teamID && i.Number > n {
+			n = i.Number
+		}
+	}
+	return n + 1
+}
+
+// defaultState is the state new issues start in
+func (w *Workspace) defaultState(teamID string) *WorkflowState {
+	var best *WorkflowState
+	for _, s := range w.States {
+		if s.TeamID != teamID || (s.Type != "backlog" && s.Type != "unstarted") {
+			continue
+		}
+		if best == nil || s.Position < best.Position {
+			best = s
+		}
+	}
+	return best
+}
+
+func (w *Workspace) team(id string) *Team {
+	for _, t := range w.Teams {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) user(id string) *User {
+	for _, u := range w.Users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) state(id string) *WorkflowState {
+	for _, s := range w.States {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) label(id string) *Label {
+	for _, l := range w.Labels {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) project(id string) *Project {
+	for _, p := range w.Projects {
+		if p.ID == id || p.SlugID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) cycle(id string) *Cycle {
+	for _, c := range w.Cycles {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) comment(id string) *Comment {
+	for _, c := range w.Comments {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) initiative(id string) *Initiative {
+	for _, i := range w.Initiatives {
+		if i.ID == id {
+			return i
+		}
+	}
+	return nil
+}
+
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrNotRecorded is returned when replaying a request the cassette has no
+// response for
+var ErrNotRecorded = errors.New("not in cassette")
+
+// Cassette is a recording of GraphQL requests and the responses Linear sent
+// back, for replaying commands without network access
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response. Requests are
+// matched on Query and Variables; Operation is there for readers.
+type Interaction struct {
+	Operation string            `json:"operation"`
+	Query     string            `json:"query"`
+	Variables json.RawMessage   `json:"variables,omitempty"`
+	Status    int               `json:"status"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	// Body holds JSON responses as is; anything else, such as an HTML
+	// error page from a gateway, is kept in Text
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// LoadCassette reads a cassette written by a recording transport
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("read cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, replacing it atomically so an
+// interrupted recording leaves the last complete version behind
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	data = append(data, '\n')
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cassette-*")
+	if err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// recordTransport passes requests on to transport and appends every
+// exchange to a cassette file. Request headers are never recorded, and the
+// secrets, such as the API key, are scrubbed from everything that is.
+type recordTransport struct {
+	transport http.RoundTripper
+	path      string
+	secrets   []string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecordTransport returns a transport that records to path, replacing
+// any cassette already there. Use it as ClientOptions.Transport.
+func NewRecordTransport(path string, transport http.RoundTripper, secrets ...string) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	kept := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		if s != "" {
+			kept = append(kept, s)
+		}
+	}
+	return &recordTransport{transport: transport, path: path, secrets: kept}
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := newInteraction(t.scrub(reqBody))
+	interaction.Status = resp.StatusCode
+	for _, key := range append([]string{"Content-Type"}, traceHeaders...) {
+		if value := resp.Header.Get(key); value != "" {
+			if interaction.Headers == nil {
+				interaction.Headers = map[string]string{}
+			}
+			interaction.Headers[key] = value
+		}
+	}
+	body := t.scrub(respBody)
+	if json.Valid(body) {
+		var compact bytes.Buffer
+		_ = json.Compact(&compact, body)
+		interaction.Body = compact.Bytes()
+	} else {
+		interaction.Text = string(body)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	if err := t.cassette.Save(t.path); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// scrub replaces every secret in data
+func (t *recordTransport) scrub(data []byte) []byte {
+	for _, s := range t.secrets {
+		data = bytes.ReplaceAll(data, []byte(s), []byte(redacted))
+	}
+	return data
+}
+
+// replayTransport answers requests from a cassette without touching the
+// network. Each recorded response is used once, in order, so a query
+// repeated after a mutation gets the later answer; once every match has
+// been used, the last one is served again.
+type replayTransport struct {
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayTransport returns a transport that replays the cassette at path.
+// Use it as ClientOptions.Transport.
+func NewReplayTransport(path string) (http.RoundTripper, error) {
+	c, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return &replayTransport{path: path, cassette: c, used: make([]bool, len(c.Interactions))}, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	want := newInteraction(reqBody)
+
+	t.mu.Lock()
+	match := -1
+	for i, recorded := range t.cassette.Interactions {
+		if !want.matches(recorded) {
+			continue
+		}
+		match = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if match >= 0 {
+		t.used[match] = true
+	}
+	t.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s has no response for %s with variables %s", ErrNotRecorded, t.path, want.Operation, orNull(want.Variables))
+	}
+
+	recorded := t.cassette.Interactions[match]
+	header := http.Header{}
+	for key, value := range recorded.Headers {
+		header.Set(key, value)
+	}
+	body := []byte(recorded.Text)
+	if recorded.Body != nil {
+		body = recorded.Body
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// newInteraction describes a GraphQL request body
+func newInteraction(body []byte) Interaction {
+	var gqlReq struct {
+		Query         string          `json:"query"`
+		OperationName string          `json:"operationName"`
+		Variables     json.RawMessage `json:"variables"`
+	}
+	_ = json.Unmarshal(body, &gqlReq)
+
+	variables := gqlReq.Variables
+	if string(variables) == "null" {
+		variables = nil
+	}
+	return Interaction{
+		Operation: describeOperation(gqlReq.Query, gqlReq.OperationName),
+		Query:     strings.TrimSpace(whitespace.ReplaceAllString(gqlReq.Query, " ")),
+		Variables: variables,
+	}
+}
+
+// matches reports whether a request is for the same query and variables as
+// a recorded one. Variables are compared as values, so key order and
+// formatting do not matter.
+func (i Interaction) matches(recorded Interaction) bool {
+	if i.Query != recorded.Query {
+		return false
+	}
+	var a, b interface{}
+	_ = json.Unmarshal(orNull(i.Variables), &a)
+	_ = json.Unmarshal(orNull(recorded.Variables), &b)
+	return reflect.DeepEqual(a, b)
+}
+
+func orNull(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return json.RawMessage("null")
+	}
+	return data
+}
+
+// readBody reads *body and replaces it with a copy, so it can be sent or
+// read again
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
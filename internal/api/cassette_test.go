@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(headerRequestsRemaining, "1499")
+		w.Header().Set("Set-Cookie", "session=secret-key")
+		if n == 1 {
+			_, _ = w.Write([]byte(`{"data":{"issue":{"title":"Hello","token":"secret-key"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"issue":{"title":"Renamed","token":"secret-key"}}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	query := `query GetIssue($id: String!) { issue(id: $id) { title } }`
+	vars := map[string]interface{}{"id": "ENG-1"}
+
+	recorder := NewClientWithOptions("secret-key", ClientOptions{
+		Endpoint:  server.URL,
+		Transport: NewRecordTransport(path, nil, "secret-key"),
+	})
+	for range 2 {
+		_, err := recorder.Raw(context.Background(), query, vars)
+		require.NoError(t, err)
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-key")
+	assert.NotContains(t, string(data), "Set-Cookie")
+
+	c, err := LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, c.Interactions, 2)
+	assert.Equal(t, "GetIssue", c.Interactions[0].Operation)
+	assert.Equal(t, "1499", c.Interactions[0].Headers[headerRequestsRemaining])
+
+	transport, err := NewReplayTransport(path)
+	require.NoError(t, err)
+	player := NewClientWithOptions("", ClientOptions{Transport: transport})
+
+	// Responses come back in the order they were recorded, then the last
+	// one repeats
+	for _, want := range []string{"Hello", "Renamed", "Renamed"} {
+		raw, err := player.Raw(context.Background(), query, map[string]interface{}{"id": "ENG-1"})
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), want)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 1499, player.RateLimit().RequestsRemaining)
+
+	_, err = player.Raw(context.Background(), query, map[string]interface{}{"id": "ENG-2"})
+	assert.ErrorIs(t, err, ErrNotRecorded)
+	assert.ErrorContains(t, err, `GetIssue with variables {"id":"ENG-2"}`)
+}
+
+func TestInteraction_Matches(t *testing.T) {
+	recorded := newInteraction([]byte(`{"query":"query { issue(id: $id) {\n  title\n} }","variables":{"id":"ENG-1","first":10}}`))
+
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"same", `{"query":"query { issue(id: $id) { title } }","variables":{"id":"ENG-1","first":10}}`, true},
+		{"key order", `{"query":"query { issue(id: $id) { title } }","variables":{"first":10,"id":"ENG-1"}}`, true},
+		{"other variables", `{"query":"query { issue(id: $id) { title } }","variables":{"id":"ENG-2","first":10}}`, false},
+		{"other query", `{"query":"query { issue(id: $id) { id } }","variables":{"id":"ENG-1","first":10}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newInteraction([]byte(tt.body)).matches(recorded))
+		})
+	}
+}
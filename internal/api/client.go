@@ -3,10 +3,13 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/hasura/go-graphql-client"
@@ -18,6 +21,9 @@ var ErrNoActiveCycle = errors.New("no active cycle for this team")
 const (
 	// LinearAPIEndpoint is the Linear GraphQL API endpoint
 	LinearAPIEndpoint = "https://api.linear.app/graphql"
+
+	// DefaultLimit is the number of results list methods return when no limit is set
+	DefaultLimit = 50
 )
 
 // Client is the interface for the Linear API client
@@ -27,35 +33,60 @@ type Client interface {
 	GetOrganisation(ctx context.Context) (*Organisation, error)
 
 	// Users
-	GetUsers(ctx context.Context) ([]User, error)
+	GetUsers(ctx context.Context, opts ListOptions) ([]User, error)
 
 	// Teams
-	GetTeams(ctx context.Context) ([]Team, error)
+	GetTeams(ctx context.Context, opts ListOptions) ([]Team, error)
 	GetTeam(ctx context.Context, id string) (*Team, error)
 
 	// Labels
-	GetLabels(ctx context.Context, teamID *string) ([]Label, error)
+	GetLabels(ctx context.Context, opts LabelListOptions) ([]Label, error)
 
 	// Workflow States
-	GetWorkflowStates(ctx context.Context, teamID *string) ([]WorkflowState, error)
+	GetWorkflowStates(ctx context.Context, opts WorkflowStateListOptions) ([]WorkflowState, error)
 
 	// Issues
 	GetIssues(ctx context.Context, opts IssueListOptions) ([]Issue, error)
 	GetIssue(ctx context.Context, id string) (*Issue, error)
 	SearchIssues(ctx context.Context, query string, opts IssueListOptions) ([]Issue, error)
+	CreateIssue(ctx context.Context, input IssueCreateInput) (*Issue, error)
+	UpdateIssue(ctx context.Context, id string, input IssueUpdateInput) (*Issue, error)
+	GetSubIssues(ctx context.Context, id string) ([]Issue, error)
+	CreateIssueRelation(ctx context.Context, input IssueRelationCreateInput) (*IssueRelation, error)
+	DeleteIssueRelation(ctx context.Context, id string) error
+
+	// Comments
+	GetComments(ctx context.Context, issueID string, opts ListOptions) ([]Comment, error)
+	GetComment(ctx context.Context, id string) (*Comment, error)
+	CreateComment(ctx context.Context, input CommentCreateInput) (*Comment, error)
+	UpdateComment(ctx context.Context, id string, body string) (*Comment, error)
+	DeleteComment(ctx context.Context, id string) error
 
 	// Projects
 	GetProjects(ctx context.Context, opts ProjectListOptions) ([]Project, error)
 	GetProject(ctx context.Context, id string) (*Project, error)
 
 	// Initiatives
-	GetInitiatives(ctx context.Context) ([]Initiative, error)
+	GetInitiatives(ctx context.Context, opts ListOptions) ([]Initiative, error)
 	GetInitiative(ctx context.Context, id string) (*Initiative, error)
 
 	// Cycles
-	GetCycles(ctx context.Context, teamID *string) ([]Cycle, error)
+	GetCycles(ctx context.Context, opts CycleListOptions) ([]Cycle, error)
 	GetActiveCycle(ctx context.Context, teamID string) (*Cycle, error)
 	GetCycle(ctx context.Context, id string) (*Cycle, error)
+
+	// Raw GraphQL
+	Raw(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error)
+
+	// RateLimit returns the most recently reported API quota, or nil
+	RateLimit() *RateLimit
+}
+
+// ListOptions contains pagination options shared by list methods.
+// Limit is the total number of results to return, not the page size.
+type ListOptions struct {
+	Limit int
+	All   bool
 }
 
 // IssueListOptions contains options for listing issues
@@ -63,38 +94,188 @@ type IssueListOptions struct {
 	TeamID     *string
 	AssigneeID *string
 	StateID    *string
-	ProjectID  *string
-	First      int
+	// StateName matches workflow states by name in any team, for when no
+	// team narrows a name down to a single state
+	StateName *string
+	ProjectID *string
+	// Filter is an additional IssueFilter, such as one compiled from a query
+	Filter map[string]interface{}
+	Limit  int
+	All    bool
 }
 
 // ProjectListOptions contains options for listing projects
 type ProjectListOptions struct {
 	TeamID *string
 	State  *string
-	First  int
+	Limit  int
+	All    bool
+}
+
+// LabelListOptions contains options for listing labels
+type LabelListOptions struct {
+	TeamID *string
+	Limit  int
+	All    bool
+}
+
+// WorkflowStateListOptions contains options for listing workflow states
+type WorkflowStateListOptions struct {
+	TeamID *string
+	Limit  int
+	All    bool
+}
+
+// CycleListOptions contains options for listing cycles
+type CycleListOptions struct {
+	TeamID *string
+	Limit  int
+	All    bool
+}
+
+// IssueCreateInput contains the fields for creating an issue. Unset fields
+// are left for Linear to default.
+type IssueCreateInput struct {
+	TeamID      string   `json:"teamId"`
+	Title       string   `json:"title"`
+	Description *string  `json:"description,omitempty"`
+	AssigneeID  *string  `json:"assigneeId,omitempty"`
+	LabelIDs    []string `json:"labelIds,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Estimate    *int     `json:"estimate,omitempty"`
+	ProjectID   *string  `json:"projectId,omitempty"`
+	CycleID     *string  `json:"cycleId,omitempty"`
+	ParentID    *string  `json:"parentId,omitempty"`
+	DueDate     *string  `json:"dueDate,omitempty"`
+}
+
+// IssueUpdateInput contains the fields to change on an issue. Unset fields
+// are left as they are.
+type IssueUpdateInput struct {
+	Title           *string  `json:"title,omitempty"`
+	Description     *string  `json:"description,omitempty"`
+	StateID         *string  `json:"stateId,omitempty"`
+	AssigneeID      *string  `json:"assigneeId,omitempty"`
+	Priority        *int     `json:"priority,omitempty"`
+	Estimate        *int     `json:"estimate,omitempty"`
+	DueDate         *string  `json:"dueDate,omitempty"`
+	ProjectID       *string  `json:"projectId,omitempty"`
+	CycleID         *string  `json:"cycleId,omitempty"`
+	ParentID        *string  `json:"parentId,omitempty"`
+	AddedLabelIDs   []string `json:"addedLabelIds,omitempty"`
+	RemovedLabelIDs []string `json:"removedLabelIds,omitempty"`
+	// Clear lists fields to send as null, which unsets them, by their JSON
+	// names such as "assigneeId". A nil pointer leaves a field unchanged.
+	Clear []string `json:"-"`
+}
+
+// MarshalJSON adds the fields to clear as nulls
+func (in IssueUpdateInput) MarshalJSON() ([]byte, error) {
+	type fields IssueUpdateInput
+	data, err := json.Marshal(fields(in))
+	if err != nil || len(in.Clear) == 0 {
+		return data, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, name := range in.Clear {
+		object[name] = json.RawMessage("null")
+	}
+	return json.Marshal(object)
+}
+
+// IssueRelationCreateInput contains the fields for linking two issues. The
+// relation reads "IssueID <Type> RelatedIssueID", e.g. ENG-1 blocks ENG-2.
+type IssueRelationCreateInput struct {
+	IssueID        string `json:"issueId"`
+	RelatedIssueID string `json:"relatedIssueId"`
+	Type           string `json:"type"`
+}
+
+// CommentCreateInput contains the fields for creating a comment. Set
+// ParentID to reply to an existing comment's thread.
+type CommentCreateInput struct {
+	IssueID  string  `json:"issueId"`
+	Body     string  `json:"body"`
+	ParentID *string `json:"parentId,omitempty"`
 }
 
 // LinearClient implements the Client interface
 type LinearClient struct {
-	gql *graphql.Client
+	gql     *graphql.Client
+	limiter *rateLimiter
+}
+
+// TokenSource supplies OAuth access tokens, which are sent instead of an
+// API key
+type TokenSource interface {
+	// Token returns the current access token
+	Token(ctx context.Context) (string, error)
+	// Refresh replaces a token the API rejected and returns the new one
+	Refresh(ctx context.Context, rejected string) (string, error)
 }
 
 // authTransport adds authorization header to requests
 type authTransport struct {
 	apiKey    string
+	tokens    TokenSource
+	userAgent string
 	transport http.RoundTripper
 }
 
 func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", t.apiKey)
 	req.Header.Set("Content-Type", "application/json")
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	if t.tokens == nil {
+		req.Header.Set("Authorization", t.apiKey)
+		return t.transport.RoundTrip(req)
+	}
+
+	token, err := t.tokens.Token(req.Context())
+	if err != nil {
+		return nil, &APIError{Kind: ErrUnauthenticated, Message: err.Error()}
+	}
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token expired or was revoked early: refresh it and try once more
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if token, err = t.tokens.Refresh(req.Context(), token); err != nil {
+		return nil, &APIError{Kind: ErrUnauthenticated, Message: err.Error()}
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
 	return t.transport.RoundTrip(req)
 }
 
-// retryTransport wraps a transport and retries on rate limit errors
+// retryTransport wraps a transport and retries rate limited requests and,
+// for queries and idempotent mutations, dropped connections, timeouts and
+// 502/503/504 responses. When it has a limiter it also records the quota
+// from every response and throttles requests before the quota runs out.
 type retryTransport struct {
 	transport  http.RoundTripper
 	maxRetries int
+	// timeout limits each attempt, leaving out the waits between them; zero
+	// means no limit
+	timeout time.Duration
+	// baseBackoff is the first backoff, doubled on every retry; zero means
+	// one second
+	baseBackoff time.Duration
+	limiter     *rateLimiter
+	// jitter randomises backoffs; nil uses the package default
+	jitter func(time.Duration) time.Duration
 }
 
 func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -108,6 +289,8 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 		_ = req.Body.Close()
 	}
+	ctx := req.Context()
+	retryable := isQuery(bodyBytes) || isIdempotent(ctx)
 
 	var resp *http.Response
 	var err error
@@ -118,13 +301,40 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		}
 
-		resp, err = t.transport.RoundTrip(req)
+		// Slow down proactively when the quota is nearly spent
+		if t.limiter != nil {
+			if err := sleep(ctx, t.limiter.delay()); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq, cancel := req, context.CancelFunc(func() {})
+		if t.timeout > 0 {
+			var attemptCtx context.Context
+			attemptCtx, cancel = context.WithTimeout(ctx, t.timeout)
+			attemptReq = req.WithContext(attemptCtx)
+		}
+		resp, err = t.transport.RoundTrip(attemptReq)
 		if err != nil {
-			return nil, err
+			cancel()
+			// Give up when the caller has, or the request may have been applied
+			if !retryable || !isTransientError(err) || ctx.Err() != nil || attempt == t.maxRetries {
+				return nil, err
+			}
+			if err := sleep(ctx, t.backoff(attempt, nil)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// The attempt's deadline covers reading the body too
+		resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+		if t.limiter != nil {
+			t.limiter.observe(resp.Header)
 		}
 
-		// If not rate limited, return immediately
-		if resp.StatusCode != http.StatusTooManyRequests {
+		// Rate limited requests were never run, so they are always safe to
+		// retry; gateway errors only when the request is retryable
+		if resp.StatusCode != http.StatusTooManyRequests && !(retryable && isTransientStatus(resp.StatusCode)) {
 			return resp, nil
 		}
 
@@ -133,46 +343,235 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			break
 		}
 
+		// A rate limit that lasts longer than maxThrottle, or past the
+		// caller's deadline, is reported rather than waited out
+		wait := t.backoff(attempt, resp)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			if d, ok := serverWait(resp, time.Now()); ok && d > maxThrottle {
+				return resp, nil
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return resp, nil
+			}
+		}
+
 		// Close the response body before retrying
 		_ = resp.Body.Close()
 
-		// Calculate backoff: 1s, 2s, 4s
-		backoff := time.Duration(1<<attempt) * time.Second
-
-		// Check for Retry-After header
-		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-			if seconds, parseErr := time.ParseDuration(retryAfter + "s"); parseErr == nil {
-				backoff = seconds
-			}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
 		}
+	}
 
-		// Wait before retrying
-		select {
-		case <-req.Context().Done():
-			return nil, req.Context().Err()
-		case <-time.After(backoff):
+	return resp, nil
+}
+
+// cancelBody releases an attempt's context once its body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// backoff returns how long to wait before another attempt: Retry-After when
+// the server sends it, otherwise the time until an exhausted quota resets,
+// otherwise exponential from baseBackoff. resp is nil after a transport
+// error.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	base := t.baseBackoff
+	if base <= 0 {
+		base = time.Second
+	}
+	backoff := base << attempt
+	if resp != nil {
+		if wait, ok := serverWait(resp, time.Now()); ok {
+			backoff = wait
 		}
 	}
+	if backoff > maxThrottle {
+		backoff = maxThrottle
+	}
 
-	return resp, nil
+	if t.jitter != nil {
+		return t.jitter(backoff)
+	}
+	return jitter(backoff)
+}
+
+// serverWait returns how long the server asked for before another
+// attempt: Retry-After, or the time until an exhausted quota resets
+func serverWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+		return retryAfter, true
+	}
+	if rl := parseRateLimit(resp.Header); rl != nil && resp.StatusCode == http.StatusTooManyRequests &&
+		rl.RequestsRemaining <= 0 && rl.RequestsReset.After(now) {
+		return rl.RequestsReset.Sub(now), true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ClientOptions configures a client created with NewClientWithOptions.
+// Zero values fall back to the defaults used by NewClient.
+type ClientOptions struct {
+	// Endpoint is the GraphQL endpoint, e.g. a local mock or proxy gateway
+	Endpoint string
+	// HTTPClient is used as the base for requests. It is copied, not
+	// modified; its transport is wrapped with authentication and retries.
+	HTTPClient *http.Client
+	// Transport replaces the base transport of HTTPClient
+	Transport http.RoundTripper
+	// Timeout overrides the timeout for each attempt at a request. Waits
+	// between retries do not count towards it.
+	Timeout time.Duration
+	// UserAgent is sent with every request
+	UserAgent string
+	// MaxRetries is the number of times a rate limited request, or a query
+	// or idempotent mutation that failed transiently, is retried. Negative
+	// values disable retries.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled on each
+	// further retry
+	RetryBackoff time.Duration
+	// Logger, when set, receives every request and response at debug level
+	Logger *slog.Logger
+	// TokenSource, when set, supplies OAuth tokens to send as Bearer tokens
+	// in place of the API key. A 401 response refreshes the token and
+	// retries the request once.
+	TokenSource TokenSource
 }
 
+const (
+	// DefaultTimeout is the timeout for each attempt at a request when
+	// none is set
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultMaxRetries is the number of retries used when none is set
+	DefaultMaxRetries = 3
+
+	// DefaultRetryBackoff is the wait before the first retry when none is set
+	DefaultRetryBackoff = time.Second
+
+	// DefaultUserAgent is the User-Agent sent when none is set
+	DefaultUserAgent = "lnr"
+)
+
 // NewClient creates a new Linear API client
 func NewClient(apiKey string) *LinearClient {
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &retryTransport{
-			maxRetries: 3,
-			transport: &authTransport{
-				apiKey:    apiKey,
-				transport: http.DefaultTransport,
-			},
+	return NewClientWithOptions(apiKey, ClientOptions{})
+}
+
+// NewClientWithOptions creates a Linear API client with a custom endpoint or
+// HTTP setup
+func NewClientWithOptions(apiKey string, opts ClientOptions) *LinearClient {
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = LinearAPIEndpoint
+	}
+
+	httpClient := &http.Client{Timeout: DefaultTimeout}
+	if opts.HTTPClient != nil {
+		base := *opts.HTTPClient
+		httpClient = &base
+		if httpClient.Timeout == 0 {
+			httpClient.Timeout = DefaultTimeout
+		}
+	}
+	if opts.Timeout > 0 {
+		httpClient.Timeout = opts.Timeout
+	}
+	// The timeout applies to each attempt, so waiting out a rate limit or
+	// backing off never counts against it
+	timeout := httpClient.Timeout
+	httpClient.Timeout = 0
+
+	transport := httpClient.Transport
+	if opts.Transport != nil {
+		transport = opts.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if opts.Logger != nil {
+		transport = &debugTransport{transport: transport, logger: opts.Logger}
+	}
+
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	maxRetries := opts.MaxRetries
+	switch {
+	case maxRetries == 0:
+		maxRetries = DefaultMaxRetries
+	case maxRetries < 0:
+		maxRetries = 0
+	}
+
+	retryBackoff := opts.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = DefaultRetryBackoff
+	}
+
+	limiter := newRateLimiter()
+	httpClient.Transport = &retryTransport{
+		maxRetries:  maxRetries,
+		timeout:     timeout,
+		baseBackoff: retryBackoff,
+		limiter:     limiter,
+		transport: &authTransport{
+			apiKey:    apiKey,
+			tokens:    opts.TokenSource,
+			userAgent: userAgent,
+			transport: transport,
 		},
 	}
 
 	return &LinearClient{
-		gql: graphql.NewClient(LinearAPIEndpoint, httpClient),
+		gql:     graphql.NewClient(endpoint, httpClient),
+		limiter: limiter,
+	}
+}
+
+// Raw sends a query or mutation as written and returns the data field of
+// the response. Queries are retried like any other; mutations only after
+// rate limits.
+func (c *LinearClient) Raw(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	data, err := c.gql.ExecRaw(ctx, query, variables)
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return data, nil
+}
+
+// RateLimit returns the quota Linear reported on the most recent response,
+// or nil before the first request
+func (c *LinearClient) RateLimit() *RateLimit {
+	if c.limiter == nil {
+		return nil
 	}
+	return c.limiter.snapshot()
 }
 
 // GetViewer returns the currently authenticated user
@@ -189,7 +588,7 @@ func (c *LinearClient) GetViewer(ctx context.Context) (*User, error) {
 		} `graphql:"viewer"`
 	}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
+	if err := c.query(ctx, &query, nil); err != nil {
 		return nil, fmt.Errorf("get viewer: %w", err)
 	}
 
@@ -216,7 +615,7 @@ func (c *LinearClient) GetOrganisation(ctx context.Context) (*Organisation, erro
 		} `graphql:"organization"`
 	}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
+	if err := c.query(ctx, &query, nil); err != nil {
 		return nil, fmt.Errorf("get organisation: %w", err)
 	}
 
@@ -229,70 +628,100 @@ func (c *LinearClient) GetOrganisation(ctx context.Context) (*Organisation, erro
 	}, nil
 }
 
-// GetUsers returns all users in the organisation
-func (c *LinearClient) GetUsers(ctx context.Context) ([]User, error) {
-	var query struct {
-		Users struct {
-			Nodes []struct {
-				ID          string `graphql:"id"`
-				Name        string `graphql:"name"`
-				Email       string `graphql:"email"`
-				DisplayName string `graphql:"displayName"`
-				Active      bool   `graphql:"active"`
-				Admin       bool   `graphql:"admin"`
-				AvatarURL   string `graphql:"avatarUrl"`
-			} `graphql:"nodes"`
-		} `graphql:"users(first: 100)"`
-	}
+// GetUsers returns users in the organisation
+func (c *LinearClient) GetUsers(ctx context.Context, opts ListOptions) ([]User, error) {
+	return c.IterateUsers(opts).Collect(ctx)
+}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
-		return nil, fmt.Errorf("get users: %w", err)
-	}
+// IterateUsers returns an iterator over users in the organisation
+func (c *LinearClient) IterateUsers(opts ListOptions) *Iterator[User] {
+	fetch := func(ctx context.Context, first int, after *string) ([]User, PageInfo, error) {
+		var query struct {
+			Users struct {
+				Nodes []struct {
+					ID          string `graphql:"id"`
+					Name        string `graphql:"name"`
+					Email       string `graphql:"email"`
+					DisplayName string `graphql:"displayName"`
+					Active      bool   `graphql:"active"`
+					Admin       bool   `graphql:"admin"`
+					AvatarURL   string `graphql:"avatarUrl"`
+				} `graphql:"nodes"`
+				PageInfo PageInfo `graphql:"pageInfo"`
+			} `graphql:"users(first: $first, after: $after)"`
+		}
+
+		vars := map[string]interface{}{
+			"first": graphql.Int(first),
+			"after": (*graphql.String)(after),
+		}
+
+		if err := c.query(ctx, &query, vars); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get users: %w", err)
+		}
 
-	users := make([]User, len(query.Users.Nodes))
-	for i, u := range query.Users.Nodes {
-		users[i] = User{
-			ID:          u.ID,
-			Name:        u.Name,
-			Email:       u.Email,
-			DisplayName: u.DisplayName,
-			Active:      u.Active,
-			Admin:       u.Admin,
-			AvatarURL:   u.AvatarURL,
+		users := make([]User, len(query.Users.Nodes))
+		for i, u := range query.Users.Nodes {
+			users[i] = User{
+				ID:          u.ID,
+				Name:        u.Name,
+				Email:       u.Email,
+				DisplayName: u.DisplayName,
+				Active:      u.Active,
+				Admin:       u.Admin,
+				AvatarURL:   u.AvatarURL,
+			}
 		}
+		return users, query.Users.PageInfo, nil
 	}
-	return users, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
-// GetTeams returns all teams in the organisation
-func (c *LinearClient) GetTeams(ctx context.Context) ([]Team, error) {
-	var query struct {
-		Teams struct {
-			Nodes []struct {
-				ID          string `graphql:"id"`
-				Name        string `graphql:"name"`
-				Key         string `graphql:"key"`
-				Description string `graphql:"description"`
-				Private     bool   `graphql:"private"`
-			} `graphql:"nodes"`
-		} `graphql:"teams(first: 100)"`
-	}
+// GetTeams returns teams in the organisation
+func (c *LinearClient) GetTeams(ctx context.Context, opts ListOptions) ([]Team, error) {
+	return c.IterateTeams(opts).Collect(ctx)
+}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
-		return nil, fmt.Errorf("get teams: %w", err)
-	}
+// IterateTeams returns an iterator over teams in the organisation
+func (c *LinearClient) IterateTeams(opts ListOptions) *Iterator[Team] {
+	fetch := func(ctx context.Context, first int, after *string) ([]Team, PageInfo, error) {
+		var query struct {
+			Teams struct {
+				Nodes []struct {
+					ID          string `graphql:"id"`
+					Name        string `graphql:"name"`
+					Key         string `graphql:"key"`
+					Description string `graphql:"description"`
+					Private     bool   `graphql:"private"`
+				} `graphql:"nodes"`
+				PageInfo PageInfo `graphql:"pageInfo"`
+			} `graphql:"teams(first: $first, after: $after)"`
+		}
+
+		vars := map[string]interface{}{
+			"first": graphql.Int(first),
+			"after": (*graphql.String)(after),
+		}
+
+		if err := c.query(ctx, &query, vars); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get teams: %w", err)
+		}
 
-	teams := make([]Team, len(query.Teams.Nodes))
-	for i, t := range query.Teams.Nodes {
-		teams[i] = Team{
-			ID:          t.ID,
-			Name:        t.Name,
-			Key:         t.Key,
-			Description: t.Description,
-			Private:     t.Private,
+		teams := make([]Team, len(query.Teams.Nodes))
+		for i, t := range query.Teams.Nodes {
+			teams[i] = Team{
+				ID:          t.ID,
+				Name:        t.Name,
+				Key:         t.Key,
+				Description: t.Description,
+				Private:     t.Private,
+			}
 		}
+		return teams, query.Teams.PageInfo, nil
 	}
-	return teams, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
 // GetTeam returns a single team by ID
@@ -311,7 +740,7 @@ func (c *LinearClient) GetTeam(ctx context.Context, id string) (*Team, error) {
 		"id": graphql.String(id),
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
+	if err := c.query(ctx, &query, vars); err != nil {
 		return nil, fmt.Errorf("get team: %w", err)
 	}
 
@@ -325,224 +754,360 @@ func (c *LinearClient) GetTeam(ctx context.Context, id string) (*Team, error) {
 }
 
 // GetLabels returns labels, optionally filtered by team
-func (c *LinearClient) GetLabels(ctx context.Context, teamID *string) ([]Label, error) {
-	var query struct {
-		IssueLabels struct {
-			Nodes []struct {
-				ID          string `graphql:"id"`
-				Name        string `graphql:"name"`
-				Description string `graphql:"description"`
-				Color       string `graphql:"color"`
-				Team        *struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-					Key  string `graphql:"key"`
-				} `graphql:"team"`
-			} `graphql:"nodes"`
-		} `graphql:"issueLabels(first: 100)"`
-	}
+func (c *LinearClient) GetLabels(ctx context.Context, opts LabelListOptions) ([]Label, error) {
+	return c.IterateLabels(opts).Collect(ctx)
+}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
-		return nil, fmt.Errorf("get labels: %w", err)
-	}
+// IterateLabels returns an iterator over labels, optionally filtered by team
+func (c *LinearClient) IterateLabels(opts LabelListOptions) *Iterator[Label] {
+	fetch := func(ctx context.Context, first int, after *string) ([]Label, PageInfo, error) {
+		var query struct {
+			IssueLabels struct {
+				Nodes []struct {
+					ID          string `graphql:"id"`
+					Name        string `graphql:"name"`
+					Description string `graphql:"description"`
+					Color       string `graphql:"color"`
+					Team        *struct {
+						ID   string `graphql:"id"`
+						Name string `graphql:"name"`
+						Key  string `graphql:"key"`
+					} `graphql:"team"`
+				} `graphql:"nodes"`
+				PageInfo PageInfo `graphql:"pageInfo"`
+			} `graphql:"issueLabels(first: $first, after: $after)"`
+		}
 
-	labels := make([]Label, 0, len(query.IssueLabels.Nodes))
-	for _, l := range query.IssueLabels.Nodes {
-		// Filter by team if specified
-		if teamID != nil && (l.Team == nil || l.Team.ID != *teamID) {
-			continue
+		vars := map[string]interface{}{
+			"first": graphql.Int(first),
+			"after": (*graphql.String)(after),
 		}
 
-		label := Label{
-			ID:          l.ID,
-			Name:        l.Name,
-			Description: l.Description,
-			Color:       l.Color,
+		if err := c.query(ctx, &query, vars); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get labels: %w", err)
 		}
-		if l.Team != nil {
-			label.Team = &Team{
-				ID:   l.Team.ID,
-				Name: l.Team.Name,
-				Key:  l.Team.Key,
+
+		labels := make([]Label, 0, len(query.IssueLabels.Nodes))
+		for _, l := range query.IssueLabels.Nodes {
+			// Filter by team if specified
+			if opts.TeamID != nil && (l.Team == nil || l.Team.ID != *opts.TeamID) {
+				continue
+			}
+
+			label := Label{
+				ID:          l.ID,
+				Name:        l.Name,
+				Description: l.Description,
+				Color:       l.Color,
 			}
+			if l.Team != nil {
+				label.Team = &Team{
+					ID:   l.Team.ID,
+					Name: l.Team.Name,
+					Key:  l.Team.Key,
+				}
+			}
+			labels = append(labels, label)
 		}
-		labels = append(labels, label)
+		return labels, query.IssueLabels.PageInfo, nil
 	}
-	return labels, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
 // GetWorkflowStates returns workflow states, optionally filtered by team
-func (c *LinearClient) GetWorkflowStates(ctx context.Context, teamID *string) ([]WorkflowState, error) {
-	var query struct {
-		WorkflowStates struct {
-			Nodes []struct {
-				ID       string `graphql:"id"`
-				Name     string `graphql:"name"`
-				Color    string `graphql:"color"`
-				Type     string `graphql:"type"`
-				Position int    `graphql:"position"`
-				Team     struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-					Key  string `graphql:"key"`
-				} `graphql:"team"`
-			} `graphql:"nodes"`
-		} `graphql:"workflowStates(first: 100)"`
-	}
+func (c *LinearClient) GetWorkflowStates(ctx context.Context, opts WorkflowStateListOptions) ([]WorkflowState, error) {
+	return c.IterateWorkflowStates(opts).Collect(ctx)
+}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
-		return nil, fmt.Errorf("get workflow states: %w", err)
-	}
+// IterateWorkflowStates returns an iterator over workflow states, optionally filtered by team
+func (c *LinearClient) IterateWorkflowStates(opts WorkflowStateListOptions) *Iterator[WorkflowState] {
+	fetch := func(ctx context.Context, first int, after *string) ([]WorkflowState, PageInfo, error) {
+		var query struct {
+			WorkflowStates struct {
+				Nodes []struct {
+					ID       string `graphql:"id"`
+					Name     string `graphql:"name"`
+					Color    string `graphql:"color"`
+					Type     string `graphql:"type"`
+					Position int    `graphql:"position"`
+					Team     struct {
+						ID   string `graphql:"id"`
+						Name string `graphql:"name"`
+						Key  string `graphql:"key"`
+					} `graphql:"team"`
+				} `graphql:"nodes"`
+				PageInfo PageInfo `graphql:"pageInfo"`
+			} `graphql:"workflowStates(first: $first, after: $after)"`
+		}
 
-	states := make([]WorkflowState, 0, len(query.WorkflowStates.Nodes))
-	for _, s := range query.WorkflowStates.Nodes {
-		// Filter by team if specified
-		if teamID != nil && s.Team.ID != *teamID {
-			continue
+		vars := map[string]interface{}{
+			"first": graphql.Int(first),
+			"after": (*graphql.String)(after),
 		}
 
-		states = append(states, WorkflowState{
-			ID:       s.ID,
-			Name:     s.Name,
-			Color:    s.Color,
-			Type:     s.Type,
-			Position: s.Position,
-			Team: &Team{
-				ID:   s.Team.ID,
-				Name: s.Team.Name,
-				Key:  s.Team.Key,
-			},
-		})
+		if err := c.query(ctx, &query, vars); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get workflow states: %w", err)
+		}
+
+		states := make([]WorkflowState, 0, len(query.WorkflowStates.Nodes))
+		for _, s := range query.WorkflowStates.Nodes {
+			// Filter by team if specified
+			if opts.TeamID != nil && s.Team.ID != *opts.TeamID {
+				continue
+			}
+
+			states = append(states, WorkflowState{
+				ID:       s.ID,
+				Name:     s.Name,
+				Color:    s.Color,
+				Type:     s.Type,
+				Position: s.Position,
+				Team: &Team{
+					ID:   s.Team.ID,
+					Name: s.Team.Name,
+					Key:  s.Team.Key,
+				},
+			})
+		}
+		return states, query.WorkflowStates.PageInfo, nil
 	}
-	return states, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
-// GetIssues returns issues with optional filters
-func (c *LinearClient) GetIssues(ctx context.Context, opts IssueListOptions) ([]Issue, error) {
-	first := opts.First
-	if first == 0 {
-		first = 50
-	}
+// issueFields is the selection set shared by queries and mutations that
+// return issues, decoded into issueNode
+const issueFields = `
+	id
+	identifier
+	title
+	description
+	priority
+	estimate
+	url
+	createdAt
+	updatedAt
+	dueDate
+	state { id name color type }
+	assignee { id name email }
+	team { id name key }
+	project { id name }
+	cycle { id name number }
+	parent { id identifier title }
+	labels { nodes { id name color } }
+`
+
+// issueNode is an issue as selected by issueFields
+type issueNode struct {
+	ID          string   `json:"id"`
+	Identifier  string   `json:"identifier"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Priority    int      `json:"priority"`
+	Estimate    *float64 `json:"estimate"`
+	URL         string   `json:"url"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+	DueDate     *string  `json:"dueDate"`
+	State       *struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+		Type  string `json:"type"`
+	} `json:"state"`
+	Assignee *struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"assignee"`
+	Team struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Key  string `json:"key"`
+	} `json:"team"`
+	Project *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+	Cycle *struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Number int    `json:"number"`
+	} `json:"cycle"`
+	Parent *struct {
+		ID         string `json:"id"`
+		Identifier string `json:"identifier"`
+		Title      string `json:"title"`
+	} `json:"parent"`
+	Labels struct {
+		Nodes []struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
 
-	var query struct {
-		Issues struct {
-			Nodes []struct {
-				ID          string  `graphql:"id"`
-				Identifier  string  `graphql:"identifier"`
-				Title       string  `graphql:"title"`
-				Description string  `graphql:"description"`
-				Priority    int     `graphql:"priority"`
-				Estimate    *float64 `graphql:"estimate"`
-				URL         string  `graphql:"url"`
-				CreatedAt   string  `graphql:"createdAt"`
-				UpdatedAt   string  `graphql:"updatedAt"`
-				DueDate     *string `graphql:"dueDate"`
-				State       *struct {
-					ID    string `graphql:"id"`
-					Name  string `graphql:"name"`
-					Color string `graphql:"color"`
-					Type  string `graphql:"type"`
-				} `graphql:"state"`
-				Assignee *struct {
-					ID    string `graphql:"id"`
-					Name  string `graphql:"name"`
-					Email string `graphql:"email"`
-				} `graphql:"assignee"`
-				Team struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-					Key  string `graphql:"key"`
-				} `graphql:"team"`
-				Project *struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-				} `graphql:"project"`
-				Labels struct {
-					Nodes []struct {
-						ID    string `graphql:"id"`
-						Name  string `graphql:"name"`
-						Color string `graphql:"color"`
-					} `graphql:"nodes"`
-				} `graphql:"labels"`
-			} `graphql:"nodes"`
-		} `graphql:"issues(first: $first)"`
-	}
+// issuesResponse is the response structure for listing issues
+type issuesResponse struct {
+	Issues struct {
+		Nodes    []issueNode `json:"nodes"`
+		PageInfo PageInfo    `json:"pageInfo"`
+	} `json:"issues"`
+}
 
-	vars := map[string]interface{}{
-		"first": graphql.Int(first),
+// toIssue converts the response node into an Issue
+func (i issueNode) toIssue() Issue {
+	issue := Issue{
+		ID:          i.ID,
+		Identifier:  i.Identifier,
+		Title:       i.Title,
+		Description: i.Description,
+		Priority:    i.Priority,
+		Estimate:    i.Estimate,
+		URL:         i.URL,
+		DueDate:     i.DueDate,
+		Team: &Team{
+			ID:   i.Team.ID,
+			Name: i.Team.Name,
+			Key:  i.Team.Key,
+		},
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
-		return nil, fmt.Errorf("get issues: %w", err)
+	if i.State != nil {
+		issue.State = &WorkflowState{
+			ID:    i.State.ID,
+			Name:  i.State.Name,
+			Color: i.State.Color,
+			Type:  i.State.Type,
+		}
 	}
 
-	issues := make([]Issue, 0, len(query.Issues.Nodes))
-	for _, i := range query.Issues.Nodes {
-		// Apply filters
-		if opts.TeamID != nil && i.Team.ID != *opts.TeamID {
-			continue
-		}
-		if opts.AssigneeID != nil && (i.Assignee == nil || i.Assignee.ID != *opts.AssigneeID) {
-			continue
-		}
-		if opts.StateID != nil && (i.State == nil || i.State.ID != *opts.StateID) {
-			continue
-		}
-		if opts.ProjectID != nil && (i.Project == nil || i.Project.ID != *opts.ProjectID) {
-			continue
+	if i.Assignee != nil {
+		issue.Assignee = &User{
+			ID:    i.Assignee.ID,
+			Name:  i.Assignee.Name,
+			Email: i.Assignee.Email,
 		}
+	}
 
-		issue := Issue{
-			ID:          i.ID,
-			Identifier:  i.Identifier,
-			Title:       i.Title,
-			Description: i.Description,
-			Priority:    i.Priority,
-			Estimate:    i.Estimate,
-			URL:         i.URL,
-			DueDate:     i.DueDate,
-			Team: &Team{
-				ID:   i.Team.ID,
-				Name: i.Team.Name,
-				Key:  i.Team.Key,
-			},
+	if i.Project != nil {
+		issue.Project = &Project{
+			ID:   i.Project.ID,
+			Name: i.Project.Name,
 		}
+	}
 
-		if i.State != nil {
-			issue.State = &WorkflowState{
-				ID:    i.State.ID,
-				Name:  i.State.Name,
-				Color: i.State.Color,
-				Type:  i.State.Type,
-			}
+	if i.Cycle != nil {
+		issue.Cycle = &Cycle{
+			ID:     i.Cycle.ID,
+			Name:   i.Cycle.Name,
+			Number: i.Cycle.Number,
 		}
+	}
 
-		if i.Assignee != nil {
-			issue.Assignee = &User{
-				ID:    i.Assignee.ID,
-				Name:  i.Assignee.Name,
-				Email: i.Assignee.Email,
-			}
+	if i.Parent != nil {
+		issue.Parent = &Issue{
+			ID:         i.Parent.ID,
+			Identifier: i.Parent.Identifier,
+			Title:      i.Parent.Title,
+		}
+	}
+
+	for _, l := range i.Labels.Nodes {
+		issue.Labels = append(issue.Labels, Label{
+			ID:    l.ID,
+			Name:  l.Name,
+			Color: l.Color,
+		})
+	}
+
+	return issue
+}
+
+// issueFilter builds the IssueFilter variable for the given options so that
+// filtering happens on the server rather than after fetching
+func issueFilter(opts IssueListOptions) map[string]interface{} {
+	filter := map[string]interface{}{}
+	if opts.TeamID != nil {
+		filter["team"] = idFilter(*opts.TeamID)
+	}
+	if opts.AssigneeID != nil {
+		filter["assignee"] = idFilter(*opts.AssigneeID)
+	}
+	if opts.StateID != nil {
+		filter["state"] = idFilter(*opts.StateID)
+	}
+	if opts.StateName != nil {
+		filter["state"] = map[string]interface{}{
+			"name": map[string]interface{}{
+				"eqIgnoreCase": *opts.StateName,
+			},
 		}
+	}
+	if opts.ProjectID != nil {
+		filter["project"] = idFilter(*opts.ProjectID)
+	}
+	if len(opts.Filter) > 0 {
+		filter["and"] = []interface{}{opts.Filter}
+	}
+	return filter
+}
 
-		if i.Project != nil {
-			issue.Project = &Project{
-				ID:   i.Project.ID,
-				Name: i.Project.Name,
+// idFilter matches an entity by ID
+func idFilter(id string) map[string]interface{} {
+	return map[string]interface{}{
+		"id": map[string]interface{}{
+			"eq": id,
+		},
+	}
+}
+
+// GetIssues returns issues with optional filters
+func (c *LinearClient) GetIssues(ctx context.Context, opts IssueListOptions) ([]Issue, error) {
+	return c.IterateIssues(opts).Collect(ctx)
+}
+
+// IterateIssues returns an iterator over issues with optional filters
+func (c *LinearClient) IterateIssues(opts IssueListOptions) *Iterator[Issue] {
+	rawQuery := `
+		query ListIssues($first: Int!, $after: String, $filter: IssueFilter!) {
+			issues(
+				filter: $filter
+				first: $first
+				after: $after
+			) {
+				nodes {` + issueFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
 			}
 		}
+	`
 
-		for _, l := range i.Labels.Nodes {
-			issue.Labels = append(issue.Labels, Label{
-				ID:    l.ID,
-				Name:  l.Name,
-				Color: l.Color,
-			})
+	filter := issueFilter(opts)
+
+	fetch := func(ctx context.Context, first int, after *string) ([]Issue, PageInfo, error) {
+		var result issuesResponse
+		err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": filter,
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get issues: %w", err)
 		}
 
-		issues = append(issues, issue)
+		issues := make([]Issue, 0, len(result.Issues.Nodes))
+		for _, i := range result.Issues.Nodes {
+			issues = append(issues, i.toIssue())
+		}
+		return issues, result.Issues.PageInfo, nil
 	}
-	return issues, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
 // GetIssue returns a single issue by ID or identifier
@@ -589,6 +1154,11 @@ func (c *LinearClient) GetIssue(ctx context.Context, id string) (*Issue, error)
 				Name   string `graphql:"name"`
 				Number int    `graphql:"number"`
 			} `graphql:"cycle"`
+			Parent *struct {
+				ID         string `graphql:"id"`
+				Identifier string `graphql:"identifier"`
+				Title      string `graphql:"title"`
+			} `graphql:"parent"`
 			Labels struct {
 				Nodes []struct {
 					ID    string `graphql:"id"`
@@ -605,6 +1175,23 @@ func (c *LinearClient) GetIssue(ctx context.Context, id string) (*Issue, error)
 					SourceType string `graphql:"sourceType"`
 				} `graphql:"nodes"`
 			} `graphql:"attachments"`
+			Children struct {
+				Nodes []issueSummary `graphql:"nodes"`
+			} `graphql:"children(first: 100)"`
+			Relations struct {
+				Nodes []struct {
+					ID           string       `graphql:"id"`
+					Type         string       `graphql:"type"`
+					RelatedIssue issueSummary `graphql:"relatedIssue"`
+				} `graphql:"nodes"`
+			} `graphql:"relations(first: 100)"`
+			InverseRelations struct {
+				Nodes []struct {
+					ID    string       `graphql:"id"`
+					Type  string       `graphql:"type"`
+					Issue issueSummary `graphql:"issue"`
+				} `graphql:"nodes"`
+			} `graphql:"inverseRelations(first: 100)"`
 		} `graphql:"issue(id: $id)"`
 	}
 
@@ -612,7 +1199,7 @@ func (c *LinearClient) GetIssue(ctx context.Context, id string) (*Issue, error)
 		"id": graphql.String(id),
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
+	if err := c.query(ctx, &query, vars); err != nil {
 		return nil, fmt.Errorf("get issue: %w", err)
 	}
 
@@ -673,6 +1260,14 @@ func (c *LinearClient) GetIssue(ctx context.Context, id string) (*Issue, error)
 		}
 	}
 
+	if i.Parent != nil {
+		issue.Parent = &Issue{
+			ID:         i.Parent.ID,
+			Identifier: i.Parent.Identifier,
+			Title:      i.Parent.Title,
+		}
+	}
+
 	for _, l := range i.Labels.Nodes {
 		issue.Labels = append(issue.Labels, Label{
 			ID:    l.ID,
@@ -691,9 +1286,296 @@ func (c *LinearClient) GetIssue(ctx context.Context, id string) (*Issue, error)
 		})
 	}
 
+	for _, child := range i.Children.Nodes {
+		issue.Children = append(issue.Children, child.toIssue())
+	}
+
+	for _, r := range i.Relations.Nodes {
+		related := r.RelatedIssue.toIssue()
+		issue.Relations = append(issue.Relations, IssueRelation{
+			ID:    r.ID,
+			Type:  r.Type,
+			Issue: &related,
+		})
+	}
+
+	// Inverse relations are stored on the other issue, so the type is
+	// flipped to read from this issue's point of view
+	for _, r := range i.InverseRelations.Nodes {
+		related := r.Issue.toIssue()
+		issue.Relations = append(issue.Relations, IssueRelation{
+			ID:    r.ID,
+			Type:  inverseRelationType(r.Type),
+			Issue: &related,
+		})
+	}
+
 	return issue, nil
 }
 
+// issueSummary is the selection of a linked issue in struct queries
+type issueSummary struct {
+	ID         string `graphql:"id"`
+	Identifier string `graphql:"identifier"`
+	Title      string `graphql:"title"`
+	State      *struct {
+		Name string `graphql:"name"`
+		Type string `graphql:"type"`
+	} `graphql:"state"`
+}
+
+// toIssue converts the summary into an Issue
+func (s issueSummary) toIssue() Issue {
+	issue := Issue{
+		ID:         s.ID,
+		Identifier: s.Identifier,
+		Title:      s.Title,
+	}
+	if s.State != nil {
+		issue.State = &WorkflowState{Name: s.State.Name, Type: s.State.Type}
+	}
+	return issue
+}
+
+// inverseRelationType returns the relation type as seen from the related issue
+func inverseRelationType(t string) string {
+	switch t {
+	case RelationBlocks:
+		return RelationBlockedBy
+	case RelationDuplicate:
+		return RelationDuplicatedBy
+	default:
+		return t
+	}
+}
+
+// childrenResponse is the response structure for listing sub-issues
+type childrenResponse struct {
+	Issue struct {
+		Children struct {
+			Nodes []struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+				Title      string `json:"title"`
+				State      *struct {
+					Name string `json:"name"`
+					Type string `json:"type"`
+				} `json:"state"`
+				Assignee *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"assignee"`
+			} `json:"nodes"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"children"`
+	} `json:"issue"`
+}
+
+// GetSubIssues returns the direct sub-issues of an issue
+func (c *LinearClient) GetSubIssues(ctx context.Context, id string) ([]Issue, error) {
+	rawQuery := `
+		query ListSubIssues($id: String!, $first: Int!, $after: String) {
+			issue(id: $id) {
+				children(first: $first, after: $after) {
+					nodes {
+						id
+						identifier
+						title
+						state { name type }
+						assignee { id name }
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	fetch := func(ctx context.Context, first int, after *string) ([]Issue, PageInfo, error) {
+		var result childrenResponse
+		err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+			"id":    id,
+			"first": first,
+			"after": after,
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get sub-issues: %w", err)
+		}
+
+		children := make([]Issue, 0, len(result.Issue.Children.Nodes))
+		for _, n := range result.Issue.Children.Nodes {
+			child := Issue{
+				ID:         n.ID,
+				Identifier: n.Identifier,
+				Title:      n.Title,
+			}
+			if n.State != nil {
+				child.State = &WorkflowState{Name: n.State.Name, Type: n.State.Type}
+			}
+			if n.Assignee != nil {
+				child.Assignee = &User{ID: n.Assignee.ID, Name: n.Assignee.Name}
+			}
+			children = append(children, child)
+		}
+		return children, result.Issue.Children.PageInfo, nil
+	}
+
+	return newIterator(fetch, 0).Collect(ctx)
+}
+
+// issueRelationCreateResponse is the response structure for creating a relation
+type issueRelationCreateResponse struct {
+	IssueRelationCreate struct {
+		Success       bool `json:"success"`
+		IssueRelation *struct {
+			ID           string `json:"id"`
+			Type         string `json:"type"`
+			RelatedIssue struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+				Title      string `json:"title"`
+			} `json:"relatedIssue"`
+		} `json:"issueRelation"`
+	} `json:"issueRelationCreate"`
+}
+
+// CreateIssueRelation links two issues
+func (c *LinearClient) CreateIssueRelation(ctx context.Context, input IssueRelationCreateInput) (*IssueRelation, error) {
+	rawQuery := `
+		mutation CreateIssueRelation($input: IssueRelationCreateInput!) {
+			issueRelationCreate(input: $input) {
+				success
+				issueRelation {
+					id
+					type
+					relatedIssue { id identifier title }
+				}
+			}
+		}
+	`
+
+	var result issueRelationCreateResponse
+	err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+		"input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create issue relation: %w", err)
+	}
+	r := result.IssueRelationCreate.IssueRelation
+	if !result.IssueRelationCreate.Success || r == nil {
+		return nil, errors.New("create issue relation: request was not successful")
+	}
+
+	return &IssueRelation{
+		ID:   r.ID,
+		Type: r.Type,
+		Issue: &Issue{
+			ID:         r.RelatedIssue.ID,
+			Identifier: r.RelatedIssue.Identifier,
+			Title:      r.RelatedIssue.Title,
+		},
+	}, nil
+}
+
+// DeleteIssueRelation removes a link between two issues
+func (c *LinearClient) DeleteIssueRelation(ctx context.Context, id string) error {
+	rawQuery := `
+		mutation DeleteIssueRelation($id: String!) {
+			issueRelationDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	var result struct {
+		IssueRelationDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueRelationDelete"`
+	}
+	err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		return fmt.Errorf("delete issue relation: %w", err)
+	}
+	if !result.IssueRelationDelete.Success {
+		return errors.New("delete issue relation: request was not successful")
+	}
+	return nil
+}
+
+// issueCreateResponse is the response structure for creating an issue
+type issueCreateResponse struct {
+	IssueCreate struct {
+		Success bool       `json:"success"`
+		Issue   *issueNode `json:"issue"`
+	} `json:"issueCreate"`
+}
+
+// CreateIssue creates an issue and returns it
+func (c *LinearClient) CreateIssue(ctx context.Context, input IssueCreateInput) (*Issue, error) {
+	rawQuery := `
+		mutation CreateIssue($input: IssueCreateInput!) {
+			issueCreate(input: $input) {
+				success
+				issue {` + issueFields + `}
+			}
+		}
+	`
+
+	var result issueCreateResponse
+	err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+		"input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	if !result.IssueCreate.Success || result.IssueCreate.Issue == nil {
+		return nil, errors.New("create issue: request was not successful")
+	}
+
+	issue := result.IssueCreate.Issue.toIssue()
+	return &issue, nil
+}
+
+// issueUpdateResponse is the response structure for updating an issue
+type issueUpdateResponse struct {
+	IssueUpdate struct {
+		Success bool       `json:"success"`
+		Issue   *issueNode `json:"issue"`
+	} `json:"issueUpdate"`
+}
+
+// UpdateIssue updates an issue by ID or identifier and returns it
+func (c *LinearClient) UpdateIssue(ctx context.Context, id string, input IssueUpdateInput) (*Issue, error) {
+	rawQuery := `
+		mutation UpdateIssue($id: String!, $input: IssueUpdateInput!) {
+			issueUpdate(id: $id, input: $input) {
+				success
+				issue {` + issueFields + `}
+			}
+		}
+	`
+
+	// Setting the same fields twice leaves the issue as the first time did
+	var result issueUpdateResponse
+	err := c.exec(WithIdempotent(ctx), rawQuery, &result, map[string]interface{}{
+		"id":    id,
+		"input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update issue: %w", err)
+	}
+	if !result.IssueUpdate.Success || result.IssueUpdate.Issue == nil {
+		return nil, errors.New("update issue: request was not successful")
+	}
+
+	issue := result.IssueUpdate.Issue.toIssue()
+	return &issue, nil
+}
+
 // searchIssuesResponse is the response structure for issue search
 type searchIssuesResponse struct {
 	Issues struct {
@@ -716,23 +1598,25 @@ type searchIssuesResponse struct {
 				Key string `json:"key"`
 			} `json:"team"`
 		} `json:"nodes"`
+		PageInfo PageInfo `json:"pageInfo"`
 	} `json:"issues"`
 }
 
 // SearchIssues searches for issues matching the query in title
 func (c *LinearClient) SearchIssues(ctx context.Context, query string, opts IssueListOptions) ([]Issue, error) {
-	first := opts.First
-	if first == 0 {
-		first = 50
-	}
+	return c.IterateSearchIssues(query, opts).Collect(ctx)
+}
 
+// IterateSearchIssues returns an iterator over issues matching the query in title
+func (c *LinearClient) IterateSearchIssues(query string, opts IssueListOptions) *Iterator[Issue] {
 	// Use proper GraphQL variables to prevent injection
 	// The filter is passed as a variable, not concatenated into the query
 	rawQuery := `
-		query SearchIssues($first: Int!, $filter: IssueFilter!) {
+		query SearchIssues($first: Int!, $after: String, $filter: IssueFilter!) {
 			issues(
 				filter: $filter
 				first: $first
+				after: $after
 			) {
 				nodes {
 					id
@@ -744,152 +1628,438 @@ func (c *LinearClient) SearchIssues(ctx context.Context, query string, opts Issu
 					assignee { id name }
 					team { id key }
 				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
 			}
 		}
 	`
 
-	// Build filter as a properly typed variable
-	filter := map[string]interface{}{
-		"title": map[string]interface{}{
-			"containsIgnoreCase": query,
-		},
+	// Build filter as a properly typed variable, narrowing any list filters
+	// by the search term
+	filter := issueFilter(opts)
+	filter["title"] = map[string]interface{}{
+		"containsIgnoreCase": query,
 	}
 
-	var result searchIssuesResponse
-	err := c.gql.Exec(ctx, rawQuery, &result, map[string]interface{}{
-		"first":  first,
-		"filter": filter,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("search issues: %w", err)
-	}
-
-	issues := make([]Issue, 0, len(result.Issues.Nodes))
-	for _, i := range result.Issues.Nodes {
-		issue := Issue{
-			ID:         i.ID,
-			Identifier: i.Identifier,
-			Title:      i.Title,
-			Priority:   i.Priority,
-			URL:        i.URL,
-			Team: &Team{
-				ID:  i.Team.ID,
-				Key: i.Team.Key,
-			},
+	fetch := func(ctx context.Context, first int, after *string) ([]Issue, PageInfo, error) {
+		var result searchIssuesResponse
+		err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": filter,
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("search issues: %w", err)
 		}
 
-		if i.State != nil {
-			issue.State = &WorkflowState{
-				ID:   i.State.ID,
-				Name: i.State.Name,
+		issues := make([]Issue, 0, len(result.Issues.Nodes))
+		for _, i := range result.Issues.Nodes {
+			issue := Issue{
+				ID:         i.ID,
+				Identifier: i.Identifier,
+				Title:      i.Title,
+				Priority:   i.Priority,
+				URL:        i.URL,
+				Team: &Team{
+					ID:  i.Team.ID,
+					Key: i.Team.Key,
+				},
 			}
-		}
 
-		if i.Assignee != nil {
-			issue.Assignee = &User{
-				ID:   i.Assignee.ID,
-				Name: i.Assignee.Name,
+			if i.State != nil {
+				issue.State = &WorkflowState{
+					ID:   i.State.ID,
+					Name: i.State.Name,
+				}
 			}
+
+			if i.Assignee != nil {
+				issue.Assignee = &User{
+					ID:   i.Assignee.ID,
+					Name: i.Assignee.Name,
+				}
+			}
+
+			issues = append(issues, issue)
 		}
+		return issues, result.Issues.PageInfo, nil
+	}
 
-		issues = append(issues, issue)
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
+}
+
+// commentFields is the selection set for comments, decoded into commentNode
+const commentFields = `
+	id
+	body
+	url
+	createdAt
+	updatedAt
+	editedAt
+	user { id name displayName email }
+	parent { id }
+	issue { id }
+`
+
+// commentNode is a comment as selected by commentFields
+type commentNode struct {
+	ID        string     `json:"id"`
+	Body      string     `json:"body"`
+	URL       string     `json:"url"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	EditedAt  *time.Time `json:"editedAt"`
+	User      *struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+		Email       string `json:"email"`
+	} `json:"user"`
+	Parent *struct {
+		ID string `json:"id"`
+	} `json:"parent"`
+	Issue *struct {
+		ID string `json:"id"`
+	} `json:"issue"`
+}
+
+// toComment converts the response node into a Comment
+func (n commentNode) toComment() Comment {
+	comment := Comment{
+		ID:        n.ID,
+		Body:      n.Body,
+		URL:       n.URL,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+		EditedAt:  n.EditedAt,
+	}
+	if n.User != nil {
+		comment.User = &User{
+			ID:          n.User.ID,
+			Name:        n.User.Name,
+			DisplayName: n.User.DisplayName,
+			Email:       n.User.Email,
+		}
+	}
+	if n.Parent != nil {
+		comment.ParentID = &n.Parent.ID
 	}
-	return issues, nil
+	if n.Issue != nil {
+		comment.IssueID = n.Issue.ID
+	}
+	return comment
 }
 
-// GetProjects returns projects with optional filters
-func (c *LinearClient) GetProjects(ctx context.Context, opts ProjectListOptions) ([]Project, error) {
-	first := opts.First
-	if first == 0 {
-		first = 50
+// commentsResponse is the response structure for listing an issue's comments
+type commentsResponse struct {
+	Issue struct {
+		Comments struct {
+			Nodes    []commentNode `json:"nodes"`
+			PageInfo PageInfo      `json:"pageInfo"`
+		} `json:"comments"`
+	} `json:"issue"`
+}
+
+// GetComments returns the comments on an issue, oldest first
+func (c *LinearClient) GetComments(ctx context.Context, issueID string, opts ListOptions) ([]Comment, error) {
+	// Keep what was fetched before a cancellation, as Collect does
+	comments, err := c.IterateComments(issueID, opts).Collect(ctx)
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, err
+}
+
+// IterateComments returns an iterator over the comments on an issue, most
+// recent first
+func (c *LinearClient) IterateComments(issueID string, opts ListOptions) *Iterator[Comment] {
+	rawQuery := `
+		query ListComments($id: String!, $first: Int!, $after: String) {
+			issue(id: $id) {
+				comments(first: $first, after: $after, orderBy: createdAt) {
+					nodes {` + commentFields + `}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	fetch := func(ctx context.Context, first int, after *string) ([]Comment, PageInfo, error) {
+		var result commentsResponse
+		err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+			"id":    issueID,
+			"first": first,
+			"after": after,
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get comments: %w", err)
+		}
+
+		comments := make([]Comment, 0, len(result.Issue.Comments.Nodes))
+		for _, n := range result.Issue.Comments.Nodes {
+			comments = append(comments, n.toComment())
+		}
+		return comments, result.Issue.Comments.PageInfo, nil
 	}
 
-	var query struct {
-		Projects struct {
-			Nodes []struct {
-				ID          string  `graphql:"id"`
-				Name        string  `graphql:"name"`
-				Description string  `graphql:"description"`
-				State       string  `graphql:"state"`
-				Progress    float64 `graphql:"progress"`
-				TargetDate  *string `graphql:"targetDate"`
-				StartDate   *string `graphql:"startDate"`
-				URL         string  `graphql:"url"`
-				CreatedAt   string  `graphql:"createdAt"`
-				UpdatedAt   string  `graphql:"updatedAt"`
-				Lead        *struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-				} `graphql:"lead"`
-				Teams struct {
-					Nodes []struct {
-						ID   string `graphql:"id"`
-						Name string `graphql:"name"`
-						Key  string `graphql:"key"`
-					} `graphql:"nodes"`
-				} `graphql:"teams"`
-			} `graphql:"nodes"`
-		} `graphql:"projects(first: $first)"`
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
+}
+
+// GetComment returns a single comment by ID
+func (c *LinearClient) GetComment(ctx context.Context, id string) (*Comment, error) {
+	rawQuery := `
+		query GetComment($id: String!) {
+			comment(id: $id) {` + commentFields + `}
+		}
+	`
+
+	var result struct {
+		Comment commentNode `json:"comment"`
+	}
+	err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get comment: %w", err)
 	}
 
-	vars := map[string]interface{}{
-		"first": graphql.Int(first),
+	comment := result.Comment.toComment()
+	return &comment, nil
+}
+
+// commentCreateResponse is the response structure for creating a comment
+type commentCreateResponse struct {
+	CommentCreate struct {
+		Success bool         `json:"success"`
+		Comment *commentNode `json:"comment"`
+	} `json:"commentCreate"`
+}
+
+// CreateComment adds a comment to an issue and returns it
+func (c *LinearClient) CreateComment(ctx context.Context, input CommentCreateInput) (*Comment, error) {
+	rawQuery := `
+		mutation CreateComment($input: CommentCreateInput!) {
+			commentCreate(input: $input) {
+				success
+				comment {` + commentFields + `}
+			}
+		}
+	`
+
+	var result commentCreateResponse
+	err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+		"input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create comment: %w", err)
+	}
+	if !result.CommentCreate.Success || result.CommentCreate.Comment == nil {
+		return nil, errors.New("create comment: request was not successful")
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
-		return nil, fmt.Errorf("get projects: %w", err)
+	comment := result.CommentCreate.Comment.toComment()
+	return &comment, nil
+}
+
+// commentUpdateResponse is the response structure for updating a comment
+type commentUpdateResponse struct {
+	CommentUpdate struct {
+		Success bool         `json:"success"`
+		Comment *commentNode `json:"comment"`
+	} `json:"commentUpdate"`
+}
+
+// UpdateComment replaces the body of a comment and returns it
+func (c *LinearClient) UpdateComment(ctx context.Context, id string, body string) (*Comment, error) {
+	rawQuery := `
+		mutation UpdateComment($id: String!, $input: CommentUpdateInput!) {
+			commentUpdate(id: $id, input: $input) {
+				success
+				comment {` + commentFields + `}
+			}
+		}
+	`
+
+	var result commentUpdateResponse
+	err := c.exec(WithIdempotent(ctx), rawQuery, &result, map[string]interface{}{
+		"id":    id,
+		"input": map[string]interface{}{"body": body},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update comment: %w", err)
+	}
+	if !result.CommentUpdate.Success || result.CommentUpdate.Comment == nil {
+		return nil, errors.New("update comment: request was not successful")
 	}
 
-	projects := make([]Project, 0, len(query.Projects.Nodes))
-	for _, p := range query.Projects.Nodes {
-		// Apply filters
-		if opts.State != nil && p.State != *opts.State {
-			continue
+	comment := result.CommentUpdate.Comment.toComment()
+	return &comment, nil
+}
+
+// DeleteComment deletes a comment
+func (c *LinearClient) DeleteComment(ctx context.Context, id string) error {
+	rawQuery := `
+		mutation DeleteComment($id: String!) {
+			commentDelete(id: $id) {
+				success
+			}
 		}
+	`
 
-		project := Project{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			State:       p.State,
-			Progress:    p.Progress,
-			TargetDate:  p.TargetDate,
-			StartDate:   p.StartDate,
-			URL:         p.URL,
+	var result struct {
+		CommentDelete struct {
+			Success bool `json:"success"`
+		} `json:"commentDelete"`
+	}
+	err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	if !result.CommentDelete.Success {
+		return errors.New("delete comment: request was not successful")
+	}
+	return nil
+}
+
+// projectsResponse is the response structure for listing projects
+type projectsResponse struct {
+	Projects struct {
+		Nodes []struct {
+			ID          string  `json:"id"`
+			Name        string  `json:"name"`
+			SlugID      string  `json:"slugId"`
+			Description string  `json:"description"`
+			State       string  `json:"state"`
+			Progress    float64 `json:"progress"`
+			TargetDate  *string `json:"targetDate"`
+			StartDate   *string `json:"startDate"`
+			URL         string  `json:"url"`
+			CreatedAt   string  `json:"createdAt"`
+			UpdatedAt   string  `json:"updatedAt"`
+			Lead        *struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"lead"`
+			Teams struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+					Key  string `json:"key"`
+				} `json:"nodes"`
+			} `json:"teams"`
+		} `json:"nodes"`
+		PageInfo PageInfo `json:"pageInfo"`
+	} `json:"projects"`
+}
+
+// projectFilter builds the ProjectFilter variable for the given options
+func projectFilter(opts ProjectListOptions) map[string]interface{} {
+	filter := map[string]interface{}{}
+	if opts.State != nil {
+		filter["state"] = map[string]interface{}{
+			"eq": *opts.State,
+		}
+	}
+	if opts.TeamID != nil {
+		filter["accessibleTeams"] = map[string]interface{}{
+			"some": idFilter(*opts.TeamID),
 		}
+	}
+	return filter
+}
 
-		if p.Lead != nil {
-			project.Lead = &User{
-				ID:   p.Lead.ID,
-				Name: p.Lead.Name,
+// GetProjects returns projects with optional filters
+func (c *LinearClient) GetProjects(ctx context.Context, opts ProjectListOptions) ([]Project, error) {
+	return c.IterateProjects(opts).Collect(ctx)
+}
+
+// IterateProjects returns an iterator over projects with optional filters
+func (c *LinearClient) IterateProjects(opts ProjectListOptions) *Iterator[Project] {
+	rawQuery := `
+		query ListProjects($first: Int!, $after: String, $filter: ProjectFilter!) {
+			projects(
+				filter: $filter
+				first: $first
+				after: $after
+			) {
+				nodes {
+					id
+					name
+					slugId
+					description
+					state
+					progress
+					targetDate
+					startDate
+					url
+					createdAt
+					updatedAt
+					lead { id name }
+					teams { nodes { id name key } }
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
 			}
 		}
+	`
 
-		for _, t := range p.Teams.Nodes {
-			project.Teams = append(project.Teams, Team{
-				ID:   t.ID,
-				Name: t.Name,
-				Key:  t.Key,
-			})
+	filter := projectFilter(opts)
+
+	fetch := func(ctx context.Context, first int, after *string) ([]Project, PageInfo, error) {
+		var result projectsResponse
+		err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": filter,
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get projects: %w", err)
 		}
 
-		// Filter by team if specified
-		if opts.TeamID != nil {
-			found := false
-			for _, t := range project.Teams {
-				if t.ID == *opts.TeamID {
-					found = true
-					break
+		projects := make([]Project, 0, len(result.Projects.Nodes))
+		for _, p := range result.Projects.Nodes {
+			project := Project{
+				ID:          p.ID,
+				Name:        p.Name,
+				SlugID:      p.SlugID,
+				Description: p.Description,
+				State:       p.State,
+				Progress:    p.Progress,
+				TargetDate:  p.TargetDate,
+				StartDate:   p.StartDate,
+				URL:         p.URL,
+			}
+
+			if p.Lead != nil {
+				project.Lead = &User{
+					ID:   p.Lead.ID,
+					Name: p.Lead.Name,
 				}
 			}
-			if !found {
-				continue
+
+			for _, t := range p.Teams.Nodes {
+				project.Teams = append(project.Teams, Team{
+					ID:   t.ID,
+					Name: t.Name,
+					Key:  t.Key,
+				})
 			}
-		}
 
-		projects = append(projects, project)
+			projects = append(projects, project)
+		}
+		return projects, result.Projects.PageInfo, nil
 	}
-	return projects, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
 // GetProject returns a single project by ID
@@ -898,6 +2068,7 @@ func (c *LinearClient) GetProject(ctx context.Context, id string) (*Project, err
 		Project struct {
 			ID          string  `graphql:"id"`
 			Name        string  `graphql:"name"`
+			SlugID      string  `graphql:"slugId"`
 			Description string  `graphql:"description"`
 			State       string  `graphql:"state"`
 			Progress    float64 `graphql:"progress"`
@@ -924,7 +2095,7 @@ func (c *LinearClient) GetProject(ctx context.Context, id string) (*Project, err
 		"id": graphql.String(id),
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
+	if err := c.query(ctx, &query, vars); err != nil {
 		return nil, fmt.Errorf("get project: %w", err)
 	}
 
@@ -932,6 +2103,7 @@ func (c *LinearClient) GetProject(ctx context.Context, id string) (*Project, err
 	project := &Project{
 		ID:          p.ID,
 		Name:        p.Name,
+		SlugID:      p.SlugID,
 		Description: p.Description,
 		State:       p.State,
 		Progress:    p.Progress,
@@ -958,46 +2130,61 @@ func (c *LinearClient) GetProject(ctx context.Context, id string) (*Project, err
 	return project, nil
 }
 
-// GetInitiatives returns all initiatives
-func (c *LinearClient) GetInitiatives(ctx context.Context) ([]Initiative, error) {
-	var query struct {
-		Initiatives struct {
-			Nodes []struct {
-				ID          string  `graphql:"id"`
-				Name        string  `graphql:"name"`
-				Description string  `graphql:"description"`
-				TargetDate  *string `graphql:"targetDate"`
-				CreatedAt   string  `graphql:"createdAt"`
-				UpdatedAt   string  `graphql:"updatedAt"`
-				Owner       *struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-				} `graphql:"owner"`
-			} `graphql:"nodes"`
-		} `graphql:"initiatives(first: 50)"`
-	}
+// GetInitiatives returns initiatives in the organisation
+func (c *LinearClient) GetInitiatives(ctx context.Context, opts ListOptions) ([]Initiative, error) {
+	return c.IterateInitiatives(opts).Collect(ctx)
+}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
-		return nil, fmt.Errorf("get initiatives: %w", err)
-	}
+// IterateInitiatives returns an iterator over initiatives in the organisation
+func (c *LinearClient) IterateInitiatives(opts ListOptions) *Iterator[Initiative] {
+	fetch := func(ctx context.Context, first int, after *string) ([]Initiative, PageInfo, error) {
+		var query struct {
+			Initiatives struct {
+				Nodes []struct {
+					ID          string  `graphql:"id"`
+					Name        string  `graphql:"name"`
+					Description string  `graphql:"description"`
+					TargetDate  *string `graphql:"targetDate"`
+					CreatedAt   string  `graphql:"createdAt"`
+					UpdatedAt   string  `graphql:"updatedAt"`
+					Owner       *struct {
+						ID   string `graphql:"id"`
+						Name string `graphql:"name"`
+					} `graphql:"owner"`
+				} `graphql:"nodes"`
+				PageInfo PageInfo `graphql:"pageInfo"`
+			} `graphql:"initiatives(first: $first, after: $after)"`
+		}
 
-	initiatives := make([]Initiative, len(query.Initiatives.Nodes))
-	for i, init := range query.Initiatives.Nodes {
-		initiatives[i] = Initiative{
-			ID:          init.ID,
-			Name:        init.Name,
-			Description: init.Description,
-			TargetDate:  init.TargetDate,
+		vars := map[string]interface{}{
+			"first": graphql.Int(first),
+			"after": (*graphql.String)(after),
 		}
 
-		if init.Owner != nil {
-			initiatives[i].Owner = &User{
-				ID:   init.Owner.ID,
-				Name: init.Owner.Name,
+		if err := c.query(ctx, &query, vars); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get initiatives: %w", err)
+		}
+
+		initiatives := make([]Initiative, len(query.Initiatives.Nodes))
+		for i, init := range query.Initiatives.Nodes {
+			initiatives[i] = Initiative{
+				ID:          init.ID,
+				Name:        init.Name,
+				Description: init.Description,
+				TargetDate:  init.TargetDate,
+			}
+
+			if init.Owner != nil {
+				initiatives[i].Owner = &User{
+					ID:   init.Owner.ID,
+					Name: init.Owner.Name,
+				}
 			}
 		}
+		return initiatives, query.Initiatives.PageInfo, nil
 	}
-	return initiatives, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
 // GetInitiative returns a single initiative by ID
@@ -1028,7 +2215,7 @@ func (c *LinearClient) GetInitiative(ctx context.Context, id string) (*Initiativ
 		"id": graphql.String(id),
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
+	if err := c.query(ctx, &query, vars); err != nil {
 		return nil, fmt.Errorf("get initiative: %w", err)
 	}
 
@@ -1058,52 +2245,94 @@ func (c *LinearClient) GetInitiative(ctx context.Context, id string) (*Initiativ
 	return initiative, nil
 }
 
+// cyclesResponse is the response structure for listing cycles
+type cyclesResponse struct {
+	Cycles struct {
+		Nodes []struct {
+			ID          string  `json:"id"`
+			Name        string  `json:"name"`
+			Number      int     `json:"number"`
+			StartsAt    string  `json:"startsAt"`
+			EndsAt      string  `json:"endsAt"`
+			Progress    float64 `json:"progress"`
+			Description string  `json:"description"`
+			Team        struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+				Key  string `json:"key"`
+			} `json:"team"`
+		} `json:"nodes"`
+		PageInfo PageInfo `json:"pageInfo"`
+	} `json:"cycles"`
+}
+
 // GetCycles returns cycles, optionally filtered by team
-func (c *LinearClient) GetCycles(ctx context.Context, teamID *string) ([]Cycle, error) {
-	var query struct {
-		Cycles struct {
-			Nodes []struct {
-				ID          string  `graphql:"id"`
-				Name        string  `graphql:"name"`
-				Number      int     `graphql:"number"`
-				StartsAt    string  `graphql:"startsAt"`
-				EndsAt      string  `graphql:"endsAt"`
-				Progress    float64 `graphql:"progress"`
-				Description string  `graphql:"description"`
-				Team        struct {
-					ID   string `graphql:"id"`
-					Name string `graphql:"name"`
-					Key  string `graphql:"key"`
-				} `graphql:"team"`
-			} `graphql:"nodes"`
-		} `graphql:"cycles(first: 50)"`
-	}
+func (c *LinearClient) GetCycles(ctx context.Context, opts CycleListOptions) ([]Cycle, error) {
+	return c.IterateCycles(opts).Collect(ctx)
+}
 
-	if err := c.gql.Query(ctx, &query, nil); err != nil {
-		return nil, fmt.Errorf("get cycles: %w", err)
+// IterateCycles returns an iterator over cycles, optionally filtered by team
+func (c *LinearClient) IterateCycles(opts CycleListOptions) *Iterator[Cycle] {
+	rawQuery := `
+		query ListCycles($first: Int!, $after: String, $filter: CycleFilter!) {
+			cycles(
+				filter: $filter
+				first: $first
+				after: $after
+			) {
+				nodes {
+					id
+					name
+					number
+					startsAt
+					endsAt
+					progress
+					description
+					team { id name key }
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	filter := map[string]interface{}{}
+	if opts.TeamID != nil {
+		filter["team"] = idFilter(*opts.TeamID)
 	}
 
-	cycles := make([]Cycle, 0, len(query.Cycles.Nodes))
-	for _, cy := range query.Cycles.Nodes {
-		// Filter by team if specified
-		if teamID != nil && cy.Team.ID != *teamID {
-			continue
+	fetch := func(ctx context.Context, first int, after *string) ([]Cycle, PageInfo, error) {
+		var result cyclesResponse
+		err := c.exec(ctx, rawQuery, &result, map[string]interface{}{
+			"first":  first,
+			"after":  after,
+			"filter": filter,
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("get cycles: %w", err)
 		}
 
-		cycles = append(cycles, Cycle{
-			ID:          cy.ID,
-			Name:        cy.Name,
-			Number:      cy.Number,
-			Progress:    cy.Progress,
-			Description: cy.Description,
-			Team: &Team{
-				ID:   cy.Team.ID,
-				Name: cy.Team.Name,
-				Key:  cy.Team.Key,
-			},
-		})
+		cycles := make([]Cycle, 0, len(result.Cycles.Nodes))
+		for _, cy := range result.Cycles.Nodes {
+			cycles = append(cycles, Cycle{
+				ID:          cy.ID,
+				Name:        cy.Name,
+				Number:      cy.Number,
+				Progress:    cy.Progress,
+				Description: cy.Description,
+				Team: &Team{
+					ID:   cy.Team.ID,
+					Name: cy.Team.Name,
+					Key:  cy.Team.Key,
+				},
+			})
+		}
+		return cycles, result.Cycles.PageInfo, nil
 	}
-	return cycles, nil
+
+	return newIterator(fetch, pageLimit(opts.Limit, opts.All))
 }
 
 // GetActiveCycle returns the currently active cycle for a team
@@ -1129,7 +2358,7 @@ func (c *LinearClient) GetActiveCycle(ctx context.Context, teamID string) (*Cycl
 		"id": graphql.String(teamID),
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
+	if err := c.query(ctx, &query, vars); err != nil {
 		return nil, fmt.Errorf("get active cycle: %w", err)
 	}
 
@@ -1175,7 +2404,7 @@ func (c *LinearClient) GetCycle(ctx context.Context, id string) (*Cycle, error)
 		"id": graphql.String(id),
 	}
 
-	if err := c.gql.Query(ctx, &query, vars); err != nil {
+	if err := c.query(ctx, &query, vars); err != nil {
 		return nil, fmt.Errorf("get cycle: %w", err)
 	}
 
@@ -18,6 +18,41 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client.gql)
 }
 
+func TestNewClientWithOptions(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, "test-api-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "lnr-test/1.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, "yes", r.Header.Get("X-Base-Transport"))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-api-key", ClientOptions{
+		Endpoint:   server.URL,
+		Transport:  headerTransport{"X-Base-Transport", "yes"},
+		UserAgent:  "lnr-test/1.0",
+		MaxRetries: -1,
+	})
+
+	_, err := client.GetViewer(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts, "retries should be disabled")
+}
+
+// headerTransport sets a header before handing the request to the default
+// transport
+type headerTransport struct {
+	key, value string
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set(t.key, t.value)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
 func TestGetViewer(t *testing.T) {
 	// Create a mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -123,7 +158,7 @@ func TestGetTeams(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	teams, err := client.GetTeams(ctx)
+	teams, err := client.GetTeams(ctx, ListOptions{})
 
 	require.NoError(t, err)
 	assert.Len(t, teams, 2)
@@ -185,7 +220,7 @@ func TestSearchIssues(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	issues, err := client.SearchIssues(ctx, "login", IssueListOptions{First: 10})
+	issues, err := client.SearchIssues(ctx, "login", IssueListOptions{Limit: 10})
 
 	require.NoError(t, err)
 	assert.Len(t, issues, 2)
@@ -264,7 +299,7 @@ func TestGetUsers(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	users, err := client.GetUsers(ctx)
+	users, err := client.GetUsers(ctx, ListOptions{})
 
 	require.NoError(t, err)
 	assert.Len(t, users, 2)
@@ -274,6 +309,45 @@ func TestGetUsers(t *testing.T) {
 	assert.False(t, users[1].Admin)
 }
 
+func TestGetUsers_FollowsPagination(t *testing.T) {
+	var afterValues []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		afterValues = append(afterValues, req.Variables["after"])
+
+		users := map[string]interface{}{
+			"nodes":    []map[string]interface{}{{"id": "user-1", "name": "Alice"}},
+			"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "cursor-1"},
+		}
+		if req.Variables["after"] == "cursor-1" {
+			users = map[string]interface{}{
+				"nodes":    []map[string]interface{}{{"id": "user-2", "name": "Bob"}},
+				"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": "cursor-2"},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"users": users},
+		})
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	ctx := context.Background()
+	users, err := client.GetUsers(ctx, ListOptions{All: true})
+
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Alice", users[0].Name)
+	assert.Equal(t, "Bob", users[1].Name)
+	assert.Equal(t, []interface{}{nil, "cursor-1"}, afterValues)
+}
+
 func TestGetIssues(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := map[string]interface{}{
@@ -317,7 +391,7 @@ func TestGetIssues(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	issues, err := client.GetIssues(ctx, IssueListOptions{First: 10})
+	issues, err := client.GetIssues(ctx, IssueListOptions{Limit: 10})
 
 	require.NoError(t, err)
 	assert.Len(t, issues, 1)
@@ -328,6 +402,296 @@ func TestGetIssues(t *testing.T) {
 	assert.Equal(t, "ENG", issues[0].Team.Key)
 }
 
+func TestGetIssues_SendsServerSideFilter(t *testing.T) {
+	var filter map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		filter, _ = req.Variables["filter"].(map[string]interface{})
+
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"issues": map[string]interface{}{
+					"nodes": []map[string]interface{}{},
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	teamID := "team-1"
+	assigneeID := "user-1"
+	ctx := context.Background()
+	_, err := client.GetIssues(ctx, IssueListOptions{TeamID: &teamID, AssigneeID: &assigneeID})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"team":     map[string]interface{}{"id": map[string]interface{}{"eq": "team-1"}},
+		"assignee": map[string]interface{}{"id": map[string]interface{}{"eq": "user-1"}},
+	}, filter)
+}
+
+func TestCreateIssue(t *testing.T) {
+	var input map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		input, _ = req.Variables["input"].(map[string]interface{})
+
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"issueCreate": map[string]interface{}{
+					"success": true,
+					"issue": map[string]interface{}{
+						"id":         "issue-1",
+						"identifier": "ENG-42",
+						"title":      "Fix login",
+						"url":        "https://linear.app/acme/issue/ENG-42",
+						"team":       map[string]interface{}{"id": "team-1", "name": "Engineering", "key": "ENG"},
+						"labels":     map[string]interface{}{"nodes": []interface{}{}},
+					},
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	priority := 2
+	issue, err := client.CreateIssue(context.Background(), IssueCreateInput{
+		TeamID:   "team-1",
+		Title:    "Fix login",
+		Priority: &priority,
+		LabelIDs: []string{"label-1"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-42", issue.Identifier)
+	assert.Equal(t, "https://linear.app/acme/issue/ENG-42", issue.URL)
+	// Unset fields are omitted so Linear applies its defaults
+	assert.Equal(t, map[string]interface{}{
+		"teamId":   "team-1",
+		"title":    "Fix login",
+		"priority": float64(2),
+		"labelIds": []interface{}{"label-1"},
+	}, input)
+}
+
+func TestCreateIssue_Unsuccessful(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"issueCreate":{"success":false,"issue":null}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	_, err := client.CreateIssue(context.Background(), IssueCreateInput{TeamID: "team-1", Title: "x"})
+
+	assert.Error(t, err)
+}
+
+func TestUpdateIssue(t *testing.T) {
+	var variables map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		variables = req.Variables
+
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"issueUpdate": map[string]interface{}{
+					"success": true,
+					"issue": map[string]interface{}{
+						"id":         "issue-1",
+						"identifier": "ENG-42",
+						"title":      "Fix login",
+						"team":       map[string]interface{}{"id": "team-1", "name": "Engineering", "key": "ENG"},
+						"parent":     map[string]interface{}{"id": "issue-0", "identifier": "ENG-10", "title": "Auth"},
+						"labels":     map[string]interface{}{"nodes": []interface{}{}},
+					},
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	title := "Fix login"
+	issue, err := client.UpdateIssue(context.Background(), "ENG-42", IssueUpdateInput{
+		Title:           &title,
+		AddedLabelIDs:   []string{"label-1"},
+		RemovedLabelIDs: []string{"label-2"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-10", issue.Parent.Identifier)
+	assert.Equal(t, "ENG-42", variables["id"])
+	assert.Equal(t, map[string]interface{}{
+		"title":           "Fix login",
+		"addedLabelIds":   []interface{}{"label-1"},
+		"removedLabelIds": []interface{}{"label-2"},
+	}, variables["input"])
+}
+
+func TestGetComments_OldestFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"issue":{"comments":{
+			"nodes":[
+				{"id":"c2","body":"Reply","url":"","createdAt":"2026-01-02T10:00:00Z","updatedAt":"2026-01-02T10:00:00Z","editedAt":null,
+				 "user":{"id":"u2","name":"Bob","displayName":"bob","email":"bob@example.com"},"parent":{"id":"c1"}},
+				{"id":"c1","body":"First","url":"","createdAt":"2026-01-01T10:00:00Z","updatedAt":"2026-01-01T10:00:00Z","editedAt":null,
+				 "user":{"id":"u1","name":"Alice","displayName":"alice","email":"alice@example.com"},"parent":null}
+			],
+			"pageInfo":{"hasNextPage":false,"endCursor":""}}}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	comments, err := client.GetComments(context.Background(), "ENG-1", ListOptions{All: true})
+
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "c1", comments[0].ID)
+	assert.Nil(t, comments[0].ParentID)
+	assert.Equal(t, "c1", *comments[1].ParentID)
+	assert.Equal(t, "Bob", comments[1].User.Name)
+}
+
+func TestCreateComment(t *testing.T) {
+	var input map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		input, _ = req.Variables["input"].(map[string]interface{})
+
+		_, _ = w.Write([]byte(`{"data":{"commentCreate":{"success":true,"comment":
+			{"id":"c3","body":"Thanks","url":"","createdAt":"2026-01-03T10:00:00Z","updatedAt":"2026-01-03T10:00:00Z","editedAt":null,"user":null,"parent":{"id":"c1"}}}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	parentID := "c1"
+	comment, err := client.CreateComment(context.Background(), CommentCreateInput{IssueID: "issue-1", Body: "Thanks", ParentID: &parentID})
+
+	require.NoError(t, err)
+	assert.Equal(t, "c3", comment.ID)
+	assert.Equal(t, map[string]interface{}{"issueId": "issue-1", "body": "Thanks", "parentId": "c1"}, input)
+}
+
+func TestDeleteComment_Unsuccessful(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"commentDelete":{"success":false}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	assert.Error(t, client.DeleteComment(context.Background(), "c1"))
+}
+
+func TestGetIssue_ChildrenAndRelations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"issue":{
+			"id":"issue-1","identifier":"ENG-1","title":"Epic",
+			"team":{"id":"team-1","name":"Engineering","key":"ENG"},
+			"parent":null,
+			"labels":{"nodes":[]},
+			"attachments":{"nodes":[]},
+			"children":{"nodes":[{"id":"issue-2","identifier":"ENG-2","title":"Child","state":{"name":"Todo","type":"unstarted"}}]},
+			"relations":{"nodes":[{"id":"rel-1","type":"blocks","relatedIssue":{"id":"issue-3","identifier":"ENG-3","title":"Blocked","state":null}}]},
+			"inverseRelations":{"nodes":[
+				{"id":"rel-2","type":"blocks","issue":{"id":"issue-4","identifier":"ENG-4","title":"Blocker","state":null}},
+				{"id":"rel-3","type":"duplicate","issue":{"id":"issue-5","identifier":"ENG-5","title":"Dupe","state":null}}
+			]}
+		}}}`))
+	}))
+	defer server.Close()
+
+	client := &LinearClient{
+		gql: createTestClient(server.URL, "test-api-key"),
+	}
+
+	issue, err := client.GetIssue(context.Background(), "ENG-1")
+
+	require.NoError(t, err)
+	require.Len(t, issue.Children, 1)
+	assert.Equal(t, "ENG-2", issue.Children[0].Identifier)
+	assert.Equal(t, "Todo", issue.Children[0].State.Name)
+
+	require.Len(t, issue.Relations, 3)
+	assert.Equal(t, RelationBlocks, issue.Relations[0].Type)
+	assert.Equal(t, "ENG-3", issue.Relations[0].Issue.Identifier)
+	assert.Equal(t, RelationBlockedBy, issue.Relations[1].Type)
+	assert.Equal(t, "ENG-4", issue.Relations[1].Issue.Identifier)
+	assert.Equal(t, RelationDuplicatedBy, issue.Relations[2].Type)
+}
+
+func TestIssueFilter_WithQueryFilter(t *testing.T) {
+	teamID := "team-1"
+	query := map[string]interface{}{"priority": map[string]interface{}{"lte": 2}}
+
+	filter := issueFilter(IssueListOptions{TeamID: &teamID, Filter: query})
+
+	assert.Equal(t, map[string]interface{}{
+		"team": map[string]interface{}{"id": map[string]interface{}{"eq": "team-1"}},
+		"and":  []interface{}{query},
+	}, filter)
+}
+
+func TestIssueFilter_StateName(t *testing.T) {
+	state := "In Progress"
+
+	filter := issueFilter(IssueListOptions{StateName: &state})
+
+	assert.Equal(t, map[string]interface{}{
+		"state": map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": "In Progress"}},
+	}, filter)
+}
+
+func TestProjectFilter(t *testing.T) {
+	teamID := "team-1"
+	state := "started"
+
+	assert.Empty(t, projectFilter(ProjectListOptions{}))
+	assert.Equal(t, map[string]interface{}{
+		"state": map[string]interface{}{"eq": "started"},
+		"accessibleTeams": map[string]interface{}{
+			"some": map[string]interface{}{"id": map[string]interface{}{"eq": "team-1"}},
+		},
+	}, projectFilter(ProjectListOptions{TeamID: &teamID, State: &state}))
+}
+
 func TestGetProjects(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := map[string]interface{}{
@@ -362,7 +726,7 @@ func TestGetProjects(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	projects, err := client.GetProjects(ctx, ProjectListOptions{First: 10})
+	projects, err := client.GetProjects(ctx, ProjectListOptions{Limit: 10})
 
 	require.NoError(t, err)
 	assert.Len(t, projects, 1)
@@ -406,7 +770,7 @@ func TestGetCycles(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	cycles, err := client.GetCycles(ctx, nil)
+	cycles, err := client.GetCycles(ctx, CycleListOptions{})
 
 	require.NoError(t, err)
 	assert.Len(t, cycles, 1)
@@ -499,3 +863,14 @@ func createTestClient(url, apiKey string) *graphqlClient {
 
 // graphqlClient is an alias for the graphql client type
 type graphqlClient = graphql.Client
+
+func TestIssueUpdateInput_MarshalJSON(t *testing.T) {
+	title := "Renamed"
+	data, err := json.Marshal(IssueUpdateInput{Title: &title})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"Renamed"}`, string(data))
+
+	data, err = json.Marshal(IssueUpdateInput{Title: &title, Clear: []string{"assigneeId", "dueDate"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"Renamed","assigneeId":null,"dueDate":null}`, string(data))
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces secret header values in debug logs
+const redacted = "REDACTED"
+
+// secretHeaders are never logged
+var secretHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// traceHeaders are the response headers worth logging: the quota and
+// anything that explains a retry
+var traceHeaders = []string{
+	headerRequestsLimit,
+	headerRequestsRemaining,
+	headerRequestsReset,
+	headerComplexityLimit,
+	headerComplexityRemaining,
+	headerComplexityReset,
+	headerComplexity,
+	"Retry-After",
+}
+
+// operationName matches a named query, mutation or subscription
+var operationName = regexp.MustCompile(`^\s*(?:query|mutation|subscription)\s+(\w+)`)
+
+// rootField matches the first field selected by an operation
+var rootField = regexp.MustCompile(`\{\s*(\w+)`)
+
+// whitespace matches the indentation and newlines in a query
+var whitespace = regexp.MustCompile(`\s+`)
+
+// debugTransport logs every request and response at debug level. It sits
+// below authTransport so each retry is logged with the headers actually
+// sent, minus secrets.
+type debugTransport struct {
+	transport http.RoundTripper
+	logger    *slog.Logger
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var gqlReq struct {
+		Query         string          `json:"query"`
+		OperationName string          `json:"operationName"`
+		Variables     json.RawMessage `json:"variables"`
+	}
+	_ = json.Unmarshal(body, &gqlReq)
+
+	t.logger.LogAttrs(ctx, slog.LevelDebug, "graphql request",
+		slog.String("operation", describeOperation(gqlReq.Query, gqlReq.OperationName)),
+		slog.String("url", req.URL.String()),
+		slog.String("query", strings.TrimSpace(whitespace.ReplaceAllString(gqlReq.Query, " "))),
+		slog.String("variables", string(gqlReq.Variables)),
+		slog.Any("headers", headerGroup(req.Header)),
+	)
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.logger.LogAttrs(ctx, slog.LevelDebug, "graphql request failed",
+			slog.String("error", err.Error()),
+			slog.Duration("duration", elapsed),
+		)
+		return nil, err
+	}
+
+	// Buffer the body to measure it, then hand it on unchanged
+	respBody, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	attrs := []slog.Attr{
+		slog.Int("status", resp.StatusCode),
+		slog.Int("bytes", len(respBody)),
+		slog.Duration("duration", elapsed),
+	}
+	for _, key := range traceHeaders {
+		if value := resp.Header.Get(key); value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	if readErr != nil {
+		attrs = append(attrs, slog.String("error", readErr.Error()))
+	}
+	t.logger.LogAttrs(ctx, slog.LevelDebug, "graphql response", attrs...)
+
+	return resp, nil
+}
+
+// describeOperation names an operation for the log: its operation name,
+// or its type and first field when it is anonymous, e.g. "query issue"
+func describeOperation(query, name string) string {
+	if name != "" {
+		return name
+	}
+	if m := operationName.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	op := OperationType(query)
+	if op == "" {
+		return "unknown"
+	}
+	if m := rootField.FindStringSubmatch(query); m != nil {
+		return op + " " + m[1]
+	}
+	return op
+}
+
+// headerGroup returns the request headers as a log group with secrets
+// redacted
+func headerGroup(h http.Header) slog.Value {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		value := strings.Join(h[k], ", ")
+		if secretHeaders[http.CanonicalHeaderKey(k)] {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(k, value))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issueBody = `{"data":{"issue":{"title":"Hello"}}}`
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRequestsRemaining, "1499")
+		w.Header().Set(headerComplexity, "3")
+		_, _ = w.Write([]byte(issueBody))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClientWithOptions("secret-key", ClientOptions{Endpoint: server.URL, Logger: logger})
+
+	query := `
+		query GetIssue($id: String!) {
+			issue(id: $id) { title }
+		}`
+	_, err := client.Raw(context.Background(), query, map[string]interface{}{"id": "ENG-1"})
+	require.NoError(t, err)
+
+	assert.NotContains(t, logs.String(), "secret-key")
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var request map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &request))
+	assert.Equal(t, "graphql request", request["msg"])
+	assert.Equal(t, "GetIssue", request["operation"])
+	assert.Equal(t, "query GetIssue($id: String!) { issue(id: $id) { title } }", request["query"])
+	assert.JSONEq(t, `{"id":"ENG-1"}`, request["variables"].(string))
+	headers := request["headers"].(map[string]interface{})
+	assert.Equal(t, redacted, headers["Authorization"])
+	assert.Equal(t, "application/json", headers["Content-Type"])
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &response))
+	assert.Equal(t, "graphql response", response["msg"])
+	assert.Equal(t, float64(200), response["status"])
+	assert.Equal(t, float64(len(issueBody)), response["bytes"])
+	assert.Equal(t, "1499", response[headerRequestsRemaining])
+	assert.Equal(t, "3", response[headerComplexity])
+	assert.Contains(t, response, "duration")
+}
+
+func TestDebugTransport_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClientWithOptions("secret-key", ClientOptions{Endpoint: server.URL, Logger: logger, MaxRetries: -1})
+
+	_, err := client.GetViewer(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, logs.String(), `msg="graphql request failed"`)
+	assert.Contains(t, logs.String(), `operation="query viewer"`)
+}
+
+func TestDescribeOperation(t *testing.T) {
+	assert.Equal(t, "Named", describeOperation("{ viewer { id } }", "Named"))
+	assert.Equal(t, "CreateIssue", describeOperation("\n\tmutation CreateIssue($input: IssueCreateInput!) {}", ""))
+	assert.Equal(t, "query users", describeOperation("query ($first:Int!){users(first: $first){nodes{id}}}", ""))
+	assert.Equal(t, "query viewer", describeOperation("{ viewer { id } }", ""))
+	assert.Equal(t, "unknown", describeOperation("", ""))
+}
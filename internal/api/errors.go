@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// Kinds of API error. Use errors.Is to check an error returned by a
+// client method against these.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrUnauthenticated = errors.New("not authenticated")
+	ErrForbidden       = errors.New("forbidden")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrValidation      = errors.New("invalid input")
+)
+
+// APIError is an error reported by the Linear API
+type APIError struct {
+	// Kind is one of the Err* values above, or nil when unclassified
+	Kind    error
+	Message string
+	// Code is the error code from the GraphQL error extensions
+	Code string
+	// StatusCode is the HTTP status, when the request itself failed
+	StatusCode int
+	// Fields lists the invalid input fields of a validation error
+	Fields []FieldError
+}
+
+// FieldError describes one invalid input field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	details := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		details[i] = f.Field + ": " + f.Message
+	}
+	return e.Message + " (" + strings.Join(details, "; ") + ")"
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// query runs a typed query, converting failures to APIErrors
+func (c *LinearClient) query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	return toAPIError(c.gql.Query(ctx, q, variables))
+}
+
+// exec runs a raw query or mutation, converting failures to APIErrors
+func (c *LinearClient) exec(ctx context.Context, query string, v interface{}, variables map[string]interface{}) error {
+	return toAPIError(c.gql.Exec(ctx, query, v, variables))
+}
+
+// toAPIError converts errors from the GraphQL client into APIErrors.
+// Errors that did not come from the API, such as network failures, are
+// returned unchanged.
+func toAPIError(err error) error {
+	var gqlErrs graphql.Errors
+	if !errors.As(err, &gqlErrs) || len(gqlErrs) == 0 {
+		return err
+	}
+
+	// The request failed before GraphQL: use the status, and the GraphQL
+	// errors in the body when there are any
+	var netErr graphql.NetworkError
+	if errors.As(gqlErrs[0].Unwrap(), &netErr) {
+		var body struct {
+			Errors []graphql.Error `json:"errors"`
+		}
+		if json.Unmarshal([]byte(netErr.Body()), &body) == nil && len(body.Errors) > 0 {
+			apiErr := fromGraphQLErrors(body.Errors)
+			apiErr.StatusCode = netErr.StatusCode()
+			if apiErr.Kind == nil {
+				apiErr.Kind = statusKind(netErr.StatusCode())
+			}
+			return apiErr
+		}
+		if kind := statusKind(netErr.StatusCode()); kind != nil {
+			return &APIError{Kind: kind, Message: netErr.Error(), StatusCode: netErr.StatusCode()}
+		}
+		return err
+	}
+
+	// Client-side failures such as network errors or undecodable responses
+	// carry a lowercase code from the GraphQL client
+	if code, _ := gqlErrs[0].Extensions["code"].(string); code != "" && code == strings.ToLower(code) {
+		// Cancellations, timeouts and replay misses read better without the
+		// client's wrapping, e.g. `Post "...": context canceled`
+		inner := gqlErrs[0].Unwrap()
+		if errors.Is(inner, context.Canceled) || errors.Is(inner, context.DeadlineExceeded) || errors.Is(inner, ErrNotRecorded) {
+			return inner
+		}
+		// So do errors the transport has classified, such as a failed
+		// token refresh
+		var apiErr *APIError
+		if errors.As(inner, &apiErr) {
+			return apiErr
+		}
+		return err
+	}
+
+	return fromGraphQLErrors(gqlErrs)
+}
+
+// fromGraphQLErrors classifies errors from a GraphQL response by their
+// first entry and joins their messages
+func fromGraphQLErrors(errs []graphql.Error) *APIError {
+	first := errs[0]
+	code, _ := first.Extensions["code"].(string)
+
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+
+	return &APIError{
+		Kind:    codeKind(code, first.Message),
+		Message: strings.Join(messages, "; "),
+		Code:    code,
+		Fields:  validationFields(first.Extensions),
+	}
+}
+
+// codeKind maps a GraphQL error code to a kind of error. Linear reports
+// missing entities as invalid input, so the message decides those.
+func codeKind(code, message string) error {
+	switch strings.ToUpper(code) {
+	case "AUTHENTICATION_ERROR", "UNAUTHENTICATED":
+		return ErrUnauthenticated
+	case "FORBIDDEN":
+		return ErrForbidden
+	case "RATELIMITED", "RATE_LIMITED":
+		return ErrRateLimited
+	case "NOT_FOUND", "ENTITY_NOT_FOUND":
+		return ErrNotFound
+	case "INVALID_INPUT", "BAD_USER_INPUT", "GRAPHQL_VALIDATION_FAILED":
+		if strings.HasPrefix(message, "Entity not found") {
+			return ErrNotFound
+		}
+		return ErrValidation
+	}
+	return nil
+}
+
+// statusKind maps an HTTP status to a kind of error
+func statusKind(status int) error {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrUnauthenticated
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// validationFields reads the per-field details of an argument validation
+// error, sent as a list of properties and the constraints they broke
+func validationFields(extensions map[string]interface{}) []FieldError {
+	list, _ := extensions["validationErrors"].([]interface{})
+	var fields []FieldError
+	for _, item := range list {
+		v, _ := item.(map[string]interface{})
+		property, _ := v["property"].(string)
+		if property == "" {
+			continue
+		}
+		constraints, _ := v["constraints"].(map[string]interface{})
+		messages := make([]string, 0, len(constraints))
+		for _, c := range constraints {
+			if s, ok := c.(string); ok {
+				messages = append(messages, s)
+			}
+		}
+		sort.Strings(messages)
+		message := strings.Join(messages, ", ")
+		if message == "" {
+			message = fmt.Sprintf("invalid value for %s", property)
+		}
+		fields = append(fields, FieldError{Field: property, Message: message})
+	}
+	return fields
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantKind   error
+		wantCode   string
+		wantFields []FieldError
+		wantMsg    string
+	}{
+		{
+			name:     "authentication error with 400",
+			status:   http.StatusBadRequest,
+			body:     `{"errors":[{"message":"Authentication required, not authenticated","extensions":{"type":"authentication error","code":"AUTHENTICATION_ERROR"}}]}`,
+			wantKind: ErrUnauthenticated,
+			wantCode: "AUTHENTICATION_ERROR",
+			wantMsg:  "Authentication required, not authenticated",
+		},
+		{
+			name:     "unauthorized status without body",
+			status:   http.StatusUnauthorized,
+			body:     ``,
+			wantKind: ErrUnauthenticated,
+			wantMsg:  "401 Unauthorized",
+		},
+		{
+			name:     "entity not found",
+			status:   http.StatusOK,
+			body:     `{"data":null,"errors":[{"message":"Entity not found: Issue","extensions":{"code":"INVALID_INPUT"}}]}`,
+			wantKind: ErrNotFound,
+			wantCode: "INVALID_INPUT",
+			wantMsg:  "Entity not found: Issue",
+		},
+		{
+			name:     "forbidden",
+			status:   http.StatusOK,
+			body:     `{"data":null,"errors":[{"message":"You don't have access","extensions":{"code":"FORBIDDEN"}}]}`,
+			wantKind: ErrForbidden,
+			wantCode: "FORBIDDEN",
+			wantMsg:  "You don't have access",
+		},
+		{
+			name:     "rate limited",
+			status:   http.StatusBadRequest,
+			body:     `{"errors":[{"message":"Rate limit exceeded","extensions":{"code":"RATELIMITED"}}]}`,
+			wantKind: ErrRateLimited,
+			wantCode: "RATELIMITED",
+			wantMsg:  "Rate limit exceeded",
+		},
+		{
+			name:   "validation with fields",
+			status: http.StatusOK,
+			body: `{"data":null,"errors":[{"message":"Argument Validation Error","extensions":{"code":"INVALID_INPUT","validationErrors":[
+				{"property":"title","constraints":{"isString":"title must be a string","maxLength":"title is too long"}},
+				{"property":"priority","constraints":{}}]}}]}`,
+			wantKind: ErrValidation,
+			wantCode: "INVALID_INPUT",
+			wantFields: []FieldError{
+				{Field: "title", Message: "title is too long, title must be a string"},
+				{Field: "priority", Message: "invalid value for priority"},
+			},
+			wantMsg: "Argument Validation Error (title: title is too long, title must be a string; priority: invalid value for priority)",
+		},
+		{
+			name:    "unclassified",
+			status:  http.StatusOK,
+			body:    `{"data":null,"errors":[{"message":"Something odd"},{"message":"And again"}]}`,
+			wantMsg: "Something odd; And again",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClientWithOptions("test-key", ClientOptions{Endpoint: server.URL, MaxRetries: -1})
+			_, err := client.GetIssue(context.Background(), "ENG-1")
+			require.Error(t, err)
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			if tt.wantKind != nil {
+				assert.ErrorIs(t, err, tt.wantKind)
+			} else {
+				assert.Nil(t, apiErr.Kind)
+			}
+			assert.Equal(t, tt.wantCode, apiErr.Code)
+			assert.Equal(t, tt.wantFields, apiErr.Fields)
+			assert.Equal(t, tt.wantMsg, apiErr.Error())
+		})
+	}
+}
+
+func TestAPIErrors_NetworkFailureUnchanged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClientWithOptions("test-key", ClientOptions{Endpoint: server.URL, MaxRetries: -1})
+	_, err := client.GetViewer(context.Background())
+	require.Error(t, err)
+
+	var apiErr *APIError
+	assert.False(t, errors.As(err, &apiErr))
+}
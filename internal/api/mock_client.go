@@ -1,26 +1,41 @@
 package api
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // MockClient is a mock implementation of the Client interface for testing
 type MockClient struct {
-	GetViewerFunc         func(ctx context.Context) (*User, error)
-	GetOrganisationFunc   func(ctx context.Context) (*Organisation, error)
-	GetUsersFunc          func(ctx context.Context) ([]User, error)
-	GetTeamsFunc          func(ctx context.Context) ([]Team, error)
-	GetTeamFunc           func(ctx context.Context, id string) (*Team, error)
-	GetLabelsFunc         func(ctx context.Context, teamID *string) ([]Label, error)
-	GetWorkflowStatesFunc func(ctx context.Context, teamID *string) ([]WorkflowState, error)
-	GetIssuesFunc         func(ctx context.Context, opts IssueListOptions) ([]Issue, error)
-	GetIssueFunc          func(ctx context.Context, id string) (*Issue, error)
-	SearchIssuesFunc      func(ctx context.Context, query string, opts IssueListOptions) ([]Issue, error)
-	GetProjectsFunc       func(ctx context.Context, opts ProjectListOptions) ([]Project, error)
-	GetProjectFunc        func(ctx context.Context, id string) (*Project, error)
-	GetInitiativesFunc    func(ctx context.Context) ([]Initiative, error)
-	GetInitiativeFunc     func(ctx context.Context, id string) (*Initiative, error)
-	GetCyclesFunc         func(ctx context.Context, teamID *string) ([]Cycle, error)
-	GetActiveCycleFunc    func(ctx context.Context, teamID string) (*Cycle, error)
-	GetCycleFunc          func(ctx context.Context, id string) (*Cycle, error)
+	GetViewerFunc           func(ctx context.Context) (*User, error)
+	GetOrganisationFunc     func(ctx context.Context) (*Organisation, error)
+	GetUsersFunc            func(ctx context.Context, opts ListOptions) ([]User, error)
+	GetTeamsFunc            func(ctx context.Context, opts ListOptions) ([]Team, error)
+	GetTeamFunc             func(ctx context.Context, id string) (*Team, error)
+	GetLabelsFunc           func(ctx context.Context, opts LabelListOptions) ([]Label, error)
+	GetWorkflowStatesFunc   func(ctx context.Context, opts WorkflowStateListOptions) ([]WorkflowState, error)
+	GetIssuesFunc           func(ctx context.Context, opts IssueListOptions) ([]Issue, error)
+	GetIssueFunc            func(ctx context.Context, id string) (*Issue, error)
+	SearchIssuesFunc        func(ctx context.Context, query string, opts IssueListOptions) ([]Issue, error)
+	CreateIssueFunc         func(ctx context.Context, input IssueCreateInput) (*Issue, error)
+	UpdateIssueFunc         func(ctx context.Context, id string, input IssueUpdateInput) (*Issue, error)
+	GetSubIssuesFunc        func(ctx context.Context, id string) ([]Issue, error)
+	CreateIssueRelationFunc func(ctx context.Context, input IssueRelationCreateInput) (*IssueRelation, error)
+	DeleteIssueRelationFunc func(ctx context.Context, id string) error
+	GetCommentsFunc         func(ctx context.Context, issueID string, opts ListOptions) ([]Comment, error)
+	GetCommentFunc          func(ctx context.Context, id string) (*Comment, error)
+	CreateCommentFunc       func(ctx context.Context, input CommentCreateInput) (*Comment, error)
+	UpdateCommentFunc       func(ctx context.Context, id string, body string) (*Comment, error)
+	DeleteCommentFunc       func(ctx context.Context, id string) error
+	GetProjectsFunc         func(ctx context.Context, opts ProjectListOptions) ([]Project, error)
+	GetProjectFunc          func(ctx context.Context, id string) (*Project, error)
+	GetInitiativesFunc      func(ctx context.Context, opts ListOptions) ([]Initiative, error)
+	GetInitiativeFunc       func(ctx context.Context, id string) (*Initiative, error)
+	GetCyclesFunc           func(ctx context.Context, opts CycleListOptions) ([]Cycle, error)
+	GetActiveCycleFunc      func(ctx context.Context, teamID string) (*Cycle, error)
+	GetCycleFunc            func(ctx context.Context, id string) (*Cycle, error)
+	RawFunc                 func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error)
+	RateLimitFunc           func() *RateLimit
 }
 
 func (m *MockClient) GetViewer(ctx context.Context) (*User, error) {
@@ -37,16 +52,16 @@ func (m *MockClient) GetOrganisation(ctx context.Context) (*Organisation, error)
 	return nil, nil
 }
 
-func (m *MockClient) GetUsers(ctx context.Context) ([]User, error) {
+func (m *MockClient) GetUsers(ctx context.Context, opts ListOptions) ([]User, error) {
 	if m.GetUsersFunc != nil {
-		return m.GetUsersFunc(ctx)
+		return m.GetUsersFunc(ctx, opts)
 	}
 	return nil, nil
 }
 
-func (m *MockClient) GetTeams(ctx context.Context) ([]Team, error) {
+func (m *MockClient) GetTeams(ctx context.Context, opts ListOptions) ([]Team, error) {
 	if m.GetTeamsFunc != nil {
-		return m.GetTeamsFunc(ctx)
+		return m.GetTeamsFunc(ctx, opts)
 	}
 	return nil, nil
 }
@@ -58,16 +73,16 @@ func (m *MockClient) GetTeam(ctx context.Context, id string) (*Team, error) {
 	return nil, nil
 }
 
-func (m *MockClient) GetLabels(ctx context.Context, teamID *string) ([]Label, error) {
+func (m *MockClient) GetLabels(ctx context.Context, opts LabelListOptions) ([]Label, error) {
 	if m.GetLabelsFunc != nil {
-		return m.GetLabelsFunc(ctx, teamID)
+		return m.GetLabelsFunc(ctx, opts)
 	}
 	return nil, nil
 }
 
-func (m *MockClient) GetWorkflowStates(ctx context.Context, teamID *string) ([]WorkflowState, error) {
+func (m *MockClient) GetWorkflowStates(ctx context.Context, opts WorkflowStateListOptions) ([]WorkflowState, error) {
 	if m.GetWorkflowStatesFunc != nil {
-		return m.GetWorkflowStatesFunc(ctx, teamID)
+		return m.GetWorkflowStatesFunc(ctx, opts)
 	}
 	return nil, nil
 }
@@ -93,6 +108,76 @@ func (m *MockClient) SearchIssues(ctx context.Context, query string, opts IssueL
 	return nil, nil
 }
 
+func (m *MockClient) CreateIssue(ctx context.Context, input IssueCreateInput) (*Issue, error) {
+	if m.CreateIssueFunc != nil {
+		return m.CreateIssueFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) UpdateIssue(ctx context.Context, id string, input IssueUpdateInput) (*Issue, error) {
+	if m.UpdateIssueFunc != nil {
+		return m.UpdateIssueFunc(ctx, id, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetSubIssues(ctx context.Context, id string) ([]Issue, error) {
+	if m.GetSubIssuesFunc != nil {
+		return m.GetSubIssuesFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateIssueRelation(ctx context.Context, input IssueRelationCreateInput) (*IssueRelation, error) {
+	if m.CreateIssueRelationFunc != nil {
+		return m.CreateIssueRelationFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteIssueRelation(ctx context.Context, id string) error {
+	if m.DeleteIssueRelationFunc != nil {
+		return m.DeleteIssueRelationFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockClient) GetComments(ctx context.Context, issueID string, opts ListOptions) ([]Comment, error) {
+	if m.GetCommentsFunc != nil {
+		return m.GetCommentsFunc(ctx, issueID, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) GetComment(ctx context.Context, id string) (*Comment, error) {
+	if m.GetCommentFunc != nil {
+		return m.GetCommentFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateComment(ctx context.Context, input CommentCreateInput) (*Comment, error) {
+	if m.CreateCommentFunc != nil {
+		return m.CreateCommentFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) UpdateComment(ctx context.Context, id string, body string) (*Comment, error) {
+	if m.UpdateCommentFunc != nil {
+		return m.UpdateCommentFunc(ctx, id, body)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteComment(ctx context.Context, id string) error {
+	if m.DeleteCommentFunc != nil {
+		return m.DeleteCommentFunc(ctx, id)
+	}
+	return nil
+}
+
 func (m *MockClient) GetProjects(ctx context.Context, opts ProjectListOptions) ([]Project, error) {
 	if m.GetProjectsFunc != nil {
 		return m.GetProjectsFunc(ctx, opts)
@@ -107,9 +192,9 @@ func (m *MockClient) GetProject(ctx context.Context, id string) (*Project, error
 	return nil, nil
 }
 
-func (m *MockClient) GetInitiatives(ctx context.Context) ([]Initiative, error) {
+func (m *MockClient) GetInitiatives(ctx context.Context, opts ListOptions) ([]Initiative, error) {
 	if m.GetInitiativesFunc != nil {
-		return m.GetInitiativesFunc(ctx)
+		return m.GetInitiativesFunc(ctx, opts)
 	}
 	return nil, nil
 }
@@ -121,9 +206,9 @@ func (m *MockClient) GetInitiative(ctx context.Context, id string) (*Initiative,
 	return nil, nil
 }
 
-func (m *MockClient) GetCycles(ctx context.Context, teamID *string) ([]Cycle, error) {
+func (m *MockClient) GetCycles(ctx context.Context, opts CycleListOptions) ([]Cycle, error) {
 	if m.GetCyclesFunc != nil {
-		return m.GetCyclesFunc(ctx, teamID)
+		return m.GetCyclesFunc(ctx, opts)
 	}
 	return nil, nil
 }
@@ -141,3 +226,17 @@ func (m *MockClient) GetCycle(ctx context.Context, id string) (*Cycle, error) {
 	}
 	return nil, nil
 }
+
+func (m *MockClient) Raw(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	if m.RawFunc != nil {
+		return m.RawFunc(ctx, query, variables)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) RateLimit() *RateLimit {
+	if m.RateLimitFunc != nil {
+		return m.RateLimitFunc()
+	}
+	return nil
+}
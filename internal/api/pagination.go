@@ -0,0 +1,117 @@
+package api
+
+import "context"
+
+const (
+	// maxPageSize is the largest page requested from a Linear connection in one call
+	maxPageSize = 100
+)
+
+// pageFunc fetches a single page of up to first results after the given cursor
+type pageFunc[T any] func(ctx context.Context, first int, after *string) ([]T, PageInfo, error)
+
+// Iterator steps through a paginated Linear connection, fetching pages on demand.
+//
+// Typical use:
+//
+//	it := client.IterateIssues(opts)
+//	for it.Next(ctx) {
+//		issue := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	fetch   pageFunc[T]
+	limit   int
+	page    []T
+	current T
+	cursor  *string
+	count   int
+	started bool
+	hasNext bool
+	err     error
+}
+
+// newIterator creates an iterator over fetch. A limit of zero means no limit.
+func newIterator[T any](fetch pageFunc[T], limit int) *Iterator[T] {
+	return &Iterator[T]{
+		fetch: fetch,
+		limit: limit,
+	}
+}
+
+// Next advances to the next result, fetching another page when the current
+// one is exhausted. It returns false when there are no more results, the
+// limit has been reached or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || (it.limit > 0 && it.count >= it.limit) {
+		return false
+	}
+
+	// Pages can come back empty when results are filtered after fetching,
+	// so keep going until we find a result or run out of pages.
+	for len(it.page) == 0 {
+		if it.started && !it.hasNext {
+			return false
+		}
+
+		first := maxPageSize
+		if it.limit > 0 && it.limit-it.count < first {
+			first = it.limit - it.count
+		}
+
+		nodes, info, err := it.fetch(ctx, first, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.page = nodes
+		it.hasNext = info.HasNextPage && info.EndCursor != ""
+		cursor := info.EndCursor
+		it.cursor = &cursor
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	it.count++
+	return true
+}
+
+// Value returns the current result
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the first error encountered while fetching pages
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Collect drains the iterator into a slice. When ctx is cancelled part way
+// through, the results fetched so far are returned along with the error.
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	results := make([]T, 0)
+	for it.Next(ctx) {
+		results = append(results, it.Value())
+	}
+	if it.err != nil {
+		if ctx.Err() != nil && len(results) > 0 {
+			return results, it.err
+		}
+		return nil, it.err
+	}
+	return results, nil
+}
+
+// pageLimit returns the total number of results to fetch, where zero means
+// every page should be fetched
+func pageLimit(limit int, all bool) int {
+	if all {
+		return 0
+	}
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return limit
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePages returns a pageFunc serving the given pages in order, recording
+// the page sizes requested
+func fakePages(pages [][]int, requested *[]int) pageFunc[int] {
+	return func(ctx context.Context, first int, after *string) ([]int, PageInfo, error) {
+		*requested = append(*requested, first)
+		index := 0
+		if after != nil {
+			_, _ = fmt.Sscanf(*after, "page-%d", &index)
+		}
+		info := PageInfo{}
+		if index+1 < len(pages) {
+			info.HasNextPage = true
+			info.EndCursor = fmt.Sprintf("page-%d", index+1)
+		}
+		return pages[index], info, nil
+	}
+}
+
+func TestIterator_FollowsCursors(t *testing.T) {
+	var requested []int
+	it := newIterator(fakePages([][]int{{1, 2}, {3, 4}, {5}}, &requested), 0)
+
+	results, err := it.Collect(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, results)
+	assert.Len(t, requested, 3)
+}
+
+func TestIterator_StopsAtLimit(t *testing.T) {
+	var requested []int
+	it := newIterator(fakePages([][]int{{1, 2}, {3, 4}, {5}}, &requested), 3)
+
+	results, err := it.Collect(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, results)
+	// Second page only needs to make up the remainder
+	assert.Equal(t, []int{3, 1}, requested)
+}
+
+func TestIterator_SkipsEmptyPages(t *testing.T) {
+	var requested []int
+	it := newIterator(fakePages([][]int{{}, {}, {7}}, &requested), 0)
+
+	results, err := it.Collect(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{7}, results)
+}
+
+func TestIterator_Error(t *testing.T) {
+	fetchErr := errors.New("boom")
+	it := newIterator(func(ctx context.Context, first int, after *string) ([]int, PageInfo, error) {
+		return nil, PageInfo{}, fetchErr
+	}, 0)
+
+	assert.False(t, it.Next(context.Background()))
+	assert.ErrorIs(t, it.Err(), fetchErr)
+
+	results, err := it.Collect(context.Background())
+	assert.Nil(t, results)
+	assert.ErrorIs(t, err, fetchErr)
+}
+
+func TestIterator_CollectKeepsResultsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var requested []int
+	pages := fakePages([][]int{{1, 2}, {3, 4}, {5}}, &requested)
+	it := newIterator(func(ctx context.Context, first int, after *string) ([]int, PageInfo, error) {
+		if len(requested) == 2 {
+			cancel()
+			return nil, PageInfo{}, ctx.Err()
+		}
+		return pages(ctx, first, after)
+	}, 0)
+
+	results, err := it.Collect(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{1, 2, 3, 4}, results)
+}
+
+func TestPageLimit(t *testing.T) {
+	assert.Equal(t, DefaultLimit, pageLimit(0, false))
+	assert.Equal(t, 10, pageLimit(10, false))
+	assert.Equal(t, 0, pageLimit(10, true))
+}
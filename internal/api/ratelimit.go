@@ -0,0 +1,189 @@
+package api
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Linear's rate limit response headers
+const (
+	headerRequestsLimit       = "X-RateLimit-Requests-Limit"
+	headerRequestsRemaining   = "X-RateLimit-Requests-Remaining"
+	headerRequestsReset       = "X-RateLimit-Requests-Reset"
+	headerComplexityLimit     = "X-RateLimit-Complexity-Limit"
+	headerComplexityRemaining = "X-RateLimit-Complexity-Remaining"
+	headerComplexityReset     = "X-RateLimit-Complexity-Reset"
+	headerComplexity          = "X-Complexity"
+)
+
+const (
+	// throttleThreshold is the fraction of a quota below which requests are
+	// spaced out over the rest of the window
+	throttleThreshold = 0.1
+
+	// maxThrottle caps the pause before a single request, so an exhausted
+	// quota surfaces as a rate limit error rather than a silent hang
+	maxThrottle = 30 * time.Second
+)
+
+// RateLimit is the API quota Linear reported on the most recent response.
+// Linear limits both the number of requests and their total complexity.
+type RateLimit struct {
+	RequestsLimit       int       `json:"requestsLimit"`
+	RequestsRemaining   int       `json:"requestsRemaining"`
+	RequestsReset       time.Time `json:"requestsReset"`
+	ComplexityLimit     int       `json:"complexityLimit"`
+	ComplexityRemaining int       `json:"complexityRemaining"`
+	ComplexityReset     time.Time `json:"complexityReset"`
+	// Complexity is the cost of the most recent request
+	Complexity int `json:"complexity"`
+}
+
+// parseRateLimit reads the rate limit headers from a response, returning
+// nil when there are none
+func parseRateLimit(h http.Header) *RateLimit {
+	if h.Get(headerRequestsRemaining) == "" && h.Get(headerComplexityRemaining) == "" {
+		return nil
+	}
+	return &RateLimit{
+		RequestsLimit:       headerInt(h, headerRequestsLimit),
+		RequestsRemaining:   headerInt(h, headerRequestsRemaining),
+		RequestsReset:       headerTime(h, headerRequestsReset),
+		ComplexityLimit:     headerInt(h, headerComplexityLimit),
+		ComplexityRemaining: headerInt(h, headerComplexityRemaining),
+		ComplexityReset:     headerTime(h, headerComplexityReset),
+		Complexity:          headerInt(h, headerComplexity),
+	}
+}
+
+func headerInt(h http.Header, key string) int {
+	n, _ := strconv.Atoi(h.Get(key))
+	return n
+}
+
+// headerTime parses a reset time, which Linear sends as a Unix timestamp
+// in milliseconds
+func headerTime(h http.Header, key string) time.Time {
+	n, err := strconv.ParseInt(h.Get(key), 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	// Accept seconds too, for proxies that rewrite the header
+	if n < 1e12 {
+		return time.Unix(n, 0)
+	}
+	return time.UnixMilli(n)
+}
+
+// parseRetryAfter parses a Retry-After value, either delay seconds or an
+// HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// jitter adds up to half of d again, so concurrent requests that were
+// limited together do not all retry at the same moment
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// rateLimiter tracks the quota reported by Linear and slows requests down
+// before it runs out. It is shared by every request a client makes, so
+// concurrent bulk operations pace themselves together.
+type rateLimiter struct {
+	mu   sync.Mutex
+	last *RateLimit
+	now  func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now}
+}
+
+// observe records the quota from a response's headers
+func (l *rateLimiter) observe(h http.Header) {
+	rl := parseRateLimit(h)
+	if rl == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.last = rl
+}
+
+// snapshot returns the most recently observed quota, or nil
+func (l *rateLimiter) snapshot() *RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.last == nil {
+		return nil
+	}
+	rl := *l.last
+	return &rl
+}
+
+// delay returns how long to wait before the next request. Once less than
+// throttleThreshold of either quota is left, the remaining requests are
+// spread evenly over the time until it resets.
+func (l *rateLimiter) delay() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.last == nil {
+		return 0
+	}
+	now := l.now()
+
+	wait := spacing(l.last.RequestsLimit, l.last.RequestsRemaining, l.last.RequestsReset, now)
+
+	// Complexity is spent in points; estimate how many more requests like
+	// the last one fit
+	cost := l.last.Complexity
+	if cost < 1 {
+		cost = 1
+	}
+	limit, remaining := l.last.ComplexityLimit/cost, l.last.ComplexityRemaining/cost
+	if d := spacing(limit, remaining, l.last.ComplexityReset, now); d > wait {
+		wait = d
+	}
+
+	if wait > maxThrottle {
+		wait = maxThrottle
+	}
+	return wait
+}
+
+// spacing is the pause that spreads remaining requests until reset
+func spacing(limit, remaining int, reset, now time.Time) time.Duration {
+	if limit <= 0 || reset.IsZero() || float64(remaining) >= float64(limit)*throttleThreshold {
+		return 0
+	}
+	untilReset := reset.Sub(now)
+	if untilReset <= 0 {
+		return 0
+	}
+	if remaining <= 0 {
+		return untilReset
+	}
+	return untilReset / time.Duration(remaining+1)
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	reset := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	h := http.Header{}
+	h.Set(headerRequestsLimit, "1500")
+	h.Set(headerRequestsRemaining, "1499")
+	h.Set(headerRequestsReset, strconv.FormatInt(reset.UnixMilli(), 10))
+	h.Set(headerComplexityLimit, "250000")
+	h.Set(headerComplexityRemaining, "249000")
+	h.Set(headerComplexityReset, strconv.FormatInt(reset.Unix(), 10))
+	h.Set(headerComplexity, "1000")
+
+	rl := parseRateLimit(h)
+	require.NotNil(t, rl)
+	assert.Equal(t, 1500, rl.RequestsLimit)
+	assert.Equal(t, 1499, rl.RequestsRemaining)
+	assert.True(t, reset.Equal(rl.RequestsReset))
+	assert.Equal(t, 250000, rl.ComplexityLimit)
+	assert.Equal(t, 249000, rl.ComplexityRemaining)
+	assert.True(t, reset.Equal(rl.ComplexityReset))
+	assert.Equal(t, 1000, rl.Complexity)
+
+	assert.Nil(t, parseRateLimit(http.Header{}))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"Wed, 01 May 2024 12:00:10 GMT", 10 * time.Second, true},
+		{"Wed, 01 May 2024 11:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := jitter(2 * time.Second)
+		assert.GreaterOrEqual(t, d, 2*time.Second)
+		assert.LessOrEqual(t, d, 3*time.Second)
+	}
+	assert.Equal(t, time.Duration(0), jitter(0))
+}
+
+func TestRateLimiter_Delay(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(10 * time.Second)
+
+	tests := []struct {
+		name string
+		rl   *RateLimit
+		want time.Duration
+	}{
+		{"nothing observed", nil, 0},
+		{"plenty left", &RateLimit{RequestsLimit: 1500, RequestsRemaining: 1000, RequestsReset: reset}, 0},
+		{"nearly exhausted", &RateLimit{RequestsLimit: 1500, RequestsRemaining: 9, RequestsReset: reset}, time.Second},
+		{"exhausted", &RateLimit{RequestsLimit: 1500, RequestsRemaining: 0, RequestsReset: reset}, 10 * time.Second},
+		{"reset passed", &RateLimit{RequestsLimit: 1500, RequestsRemaining: 0, RequestsReset: now.Add(-time.Second)}, 0},
+		{"capped", &RateLimit{RequestsLimit: 1500, RequestsRemaining: 0, RequestsReset: now.Add(time.Hour)}, maxThrottle},
+		{
+			// 4 more requests costing 1000 points fit in the remaining complexity
+			"complexity nearly exhausted",
+			&RateLimit{
+				RequestsLimit: 1500, RequestsRemaining: 1000, RequestsReset: reset,
+				ComplexityLimit: 250000, ComplexityRemaining: 4000, ComplexityReset: reset, Complexity: 1000,
+			},
+			2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter()
+			l.now = func() time.Time { return now }
+			l.last = tt.rl
+			assert.Equal(t, tt.want, l.delay())
+		})
+	}
+}
+
+func TestRetryTransport_RetryAfterAndQuota(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set(headerRequestsLimit, "1500")
+		w.Header().Set(headerRequestsRemaining, strconv.Itoa(1500-attempts))
+		if attempts == 1 {
+			w.Header().Set("Retry-After", time.Now().UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"viewer": map[string]interface{}{"id": "user-1", "name": "Test"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	var backoffs []time.Duration
+	limiter := newRateLimiter()
+	httpClient := &http.Client{
+		Transport: &retryTransport{
+			maxRetries: 3,
+			limiter:    limiter,
+			jitter: func(d time.Duration) time.Duration {
+				backoffs = append(backoffs, d)
+				return 0
+			},
+			transport: &authTransport{apiKey: "test-key", transport: http.DefaultTransport},
+		},
+	}
+	client := &LinearClient{gql: graphql.NewClient(server.URL, httpClient), limiter: limiter}
+
+	assert.Nil(t, client.RateLimit())
+	_, err := client.GetViewer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	require.Len(t, backoffs, 1)
+	assert.LessOrEqual(t, backoffs[0], time.Second)
+
+	rl := client.RateLimit()
+	require.NotNil(t, rl)
+	assert.Equal(t, 1498, rl.RequestsRemaining)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// idempotentKey marks a context whose mutations are safe to retry
+type idempotentKey struct{}
+
+// WithIdempotent marks requests made with ctx as safe to repeat. Queries
+// are always retried after transient failures; mutations only when marked,
+// since a mutation that reached Linear before the connection dropped would
+// otherwise be applied twice.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(ctx context.Context) bool {
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// isQuery reports whether a GraphQL request body holds a query rather than
+// a mutation or subscription. Bodies it cannot read are treated as
+// mutations, so they are never repeated by mistake.
+func isQuery(body []byte) bool {
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false
+	}
+	return OperationType(req.Query) == "query"
+}
+
+// OperationType returns the type of the first operation in a GraphQL
+// document: "query", "mutation" or "subscription", or "" when it cannot
+// tell. The shorthand { ... } form is a query.
+func OperationType(document string) string {
+	doc := document
+	for {
+		doc = strings.TrimSpace(doc)
+		if !strings.HasPrefix(doc, "#") {
+			break
+		}
+		// Skip comment lines
+		end := strings.IndexByte(doc, '\n')
+		if end < 0 {
+			return ""
+		}
+		doc = doc[end:]
+	}
+
+	if strings.HasPrefix(doc, "{") {
+		return "query"
+	}
+	for _, op := range []string{"query", "mutation", "subscription"} {
+		if strings.HasPrefix(doc, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// isTransientError reports whether a transport error is worth retrying:
+// dropped connections and network timeouts
+func isTransientError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTransientStatus reports whether a response status means the request
+// may succeed if repeated
+func isTransientStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyTransport fails the first failures requests with err, or with
+// status when err is nil, then answers with body
+type flakyTransport struct {
+	failures int
+	err      error
+	status   int
+	body     string
+	attempts int
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	if t.attempts <= t.failures {
+		if t.err != nil {
+			return nil, t.err
+		}
+		return &http.Response{StatusCode: t.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(t.body)), Request: req}, nil
+}
+
+func newFlakyClient(flaky *flakyTransport, maxRetries int) *LinearClient {
+	httpClient := &http.Client{
+		Transport: &retryTransport{
+			maxRetries:  maxRetries,
+			baseBackoff: time.Millisecond,
+			jitter:      func(d time.Duration) time.Duration { return d },
+			transport:   flaky,
+		},
+	}
+	return &LinearClient{gql: graphql.NewClient("http://linear.test/graphql", httpClient)}
+}
+
+const viewerBody = `{"data":{"viewer":{"id":"user-1","name":"Test"}}}`
+
+const updateBody = `{"data":{"issueUpdate":{"success":true,"issue":{"id":"issue-1","identifier":"ENG-1","title":"T"}}}}`
+
+const createBody = `{"data":{"issueCreate":{"success":true,"issue":{"id":"issue-1","identifier":"ENG-1","title":"T"}}}}`
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryTransport_TransientFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		flaky        *flakyTransport
+		call         func(c *LinearClient) error
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "query retried after connection reset",
+			flaky:        &flakyTransport{failures: 2, err: fmt.Errorf("read: %w", syscall.ECONNRESET), body: viewerBody},
+			call:         getViewer,
+			wantAttempts: 3,
+		},
+		{
+			name:         "query retried after timeout",
+			flaky:        &flakyTransport{failures: 1, err: timeoutError{}, body: viewerBody},
+			call:         getViewer,
+			wantAttempts: 2,
+		},
+		{
+			name:         "query retried after 503",
+			flaky:        &flakyTransport{failures: 1, status: http.StatusServiceUnavailable, body: viewerBody},
+			call:         getViewer,
+			wantAttempts: 2,
+		},
+		{
+			name:         "query gives up after max retries",
+			flaky:        &flakyTransport{failures: 5, status: http.StatusBadGateway, body: viewerBody},
+			call:         getViewer,
+			wantErr:      true,
+			wantAttempts: 4,
+		},
+		{
+			name:         "permanent errors are not retried",
+			flaky:        &flakyTransport{failures: 1, err: errors.New("certificate signed by unknown authority"), body: viewerBody},
+			call:         getViewer,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "server errors are not retried",
+			flaky:        &flakyTransport{failures: 1, status: http.StatusInternalServerError, body: viewerBody},
+			call:         getViewer,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "mutation not retried after connection reset",
+			flaky:        &flakyTransport{failures: 1, err: syscall.ECONNRESET, body: createBody},
+			call:         createIssue,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "mutation not retried after 504",
+			flaky:        &flakyTransport{failures: 1, status: http.StatusGatewayTimeout, body: createBody},
+			call:         createIssue,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "mutation retried after rate limit",
+			flaky:        &flakyTransport{failures: 1, status: http.StatusTooManyRequests, body: createBody},
+			call:         createIssue,
+			wantAttempts: 2,
+		},
+		{
+			name:         "idempotent mutation retried after connection reset",
+			flaky:        &flakyTransport{failures: 1, err: syscall.ECONNRESET, body: updateBody},
+			call:         updateIssue,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(newFlakyClient(tt.flaky, 3))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, tt.flaky.attempts)
+		})
+	}
+}
+
+func TestRetryTransport_RetriesDisabled(t *testing.T) {
+	flaky := &flakyTransport{failures: 1, err: syscall.ECONNRESET, body: viewerBody}
+	err := getViewer(newFlakyClient(flaky, 0))
+	assert.Error(t, err)
+	assert.Equal(t, 1, flaky.attempts)
+}
+
+func TestRetryTransport_StopsWhenCancelled(t *testing.T) {
+	flaky := &flakyTransport{failures: 5, status: http.StatusServiceUnavailable, body: viewerBody}
+	httpClient := &http.Client{
+		Transport: &retryTransport{maxRetries: 3, baseBackoff: time.Hour, transport: flaky},
+	}
+	client := &LinearClient{gql: graphql.NewClient("http://linear.test/graphql", httpClient)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.GetViewer(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, flaky.attempts)
+}
+
+// rateLimitedTransport answers every request with a 429 and retryAfter
+type rateLimitedTransport struct {
+	retryAfter string
+	attempts   int
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	header := http.Header{"Retry-After": {t.retryAfter}}
+	return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+}
+
+func TestNewClient_LongRetryAfterIsRateLimited(t *testing.T) {
+	limited := &rateLimitedTransport{retryAfter: "60"}
+	client := NewClientWithOptions("test-key", ClientOptions{Endpoint: "http://linear.test/graphql", Transport: limited})
+
+	start := time.Now()
+	_, err := client.GetViewer(context.Background())
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, limited.attempts)
+	assert.Less(t, time.Since(start), DefaultTimeout)
+}
+
+func TestNewClient_RetryAfterPastDeadlineIsRateLimited(t *testing.T) {
+	limited := &rateLimitedTransport{retryAfter: "5"}
+	client := NewClientWithOptions("test-key", ClientOptions{Endpoint: "http://linear.test/graphql", Transport: limited})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := client.GetViewer(ctx)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 1, limited.attempts)
+}
+
+func TestNewClient_TimeoutIsPerAttempt(t *testing.T) {
+	// The backoff alone outlasts the timeout, which must not cut the retry
+	// short
+	flaky := &flakyTransport{failures: 1, status: http.StatusServiceUnavailable, body: viewerBody}
+	client := NewClientWithOptions("test-key", ClientOptions{
+		Endpoint:     "http://linear.test/graphql",
+		Transport:    flaky,
+		Timeout:      50 * time.Millisecond,
+		RetryBackoff: 100 * time.Millisecond,
+	})
+
+	_, err := client.GetViewer(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, flaky.attempts)
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	transport := &retryTransport{baseBackoff: 100 * time.Millisecond, jitter: func(d time.Duration) time.Duration { return d }}
+
+	assert.Equal(t, 100*time.Millisecond, transport.backoff(0, nil))
+	assert.Equal(t, 400*time.Millisecond, transport.backoff(2, nil))
+	assert.Equal(t, maxThrottle, transport.backoff(20, nil))
+
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {"2"}}}
+	assert.Equal(t, 2*time.Second, transport.backoff(0, resp))
+}
+
+func TestIsQuery(t *testing.T) {
+	body := func(query string) []byte {
+		data, _ := json.Marshal(map[string]string{"query": query})
+		return data
+	}
+
+	assert.True(t, isQuery(body("query ($first:Int!){users(first: $first){nodes{id}}}")))
+	assert.True(t, isQuery(body("{viewer{id}}")))
+	assert.True(t, isQuery(body("\n\t\tquery GetIssue($id: String!) { issue(id: $id) { id } }")))
+	assert.False(t, isQuery(body("\n\t\tmutation CreateIssue($input: IssueCreateInput!) { issueCreate(input: $input) { success } }")))
+	assert.False(t, isQuery([]byte("not json")))
+	assert.False(t, isQuery(nil))
+}
+
+func getViewer(c *LinearClient) error {
+	_, err := c.GetViewer(context.Background())
+	return err
+}
+
+func createIssue(c *LinearClient) error {
+	_, err := c.CreateIssue(context.Background(), IssueCreateInput{TeamID: "team-1", Title: "T"})
+	return err
+}
+
+func updateIssue(c *LinearClient) error {
+	title := "T"
+	_, err := c.UpdateIssue(context.Background(), "ENG-1", IssueUpdateInput{Title: &title})
+	return err
+}
+
+func TestOperationType(t *testing.T) {
+	tests := map[string]string{
+		"{ viewer { id } }":                       "query",
+		"query Viewer { viewer { id } }":          "query",
+		"\n  mutation ($id: String!) { x }":       "mutation",
+		"# Archive it\nmutation { issueArchive }": "mutation",
+		"subscription { issues }":                 "subscription",
+		"# only a comment":                        "",
+		"":                                        "",
+	}
+	for document, want := range tests {
+		assert.Equal(t, want, OperationType(document), document)
+	}
+}
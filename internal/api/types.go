@@ -52,30 +52,65 @@ type Attachment struct {
 
 // Issue represents a Linear issue
 type Issue struct {
-	ID          string         `json:"id"`
-	Identifier  string         `json:"identifier"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Priority    int            `json:"priority"`
-	Estimate    *float64       `json:"estimate"`
-	State       *WorkflowState `json:"state"`
-	Assignee    *User          `json:"assignee"`
-	Creator     *User          `json:"creator"`
-	Team        *Team          `json:"team"`
-	Project     *Project       `json:"project"`
-	Cycle       *Cycle         `json:"cycle"`
-	Labels      []Label        `json:"labels"`
-	Attachments []Attachment   `json:"attachments,omitempty"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
-	DueDate     *string        `json:"dueDate"`
-	URL         string         `json:"url"`
+	ID          string          `json:"id"`
+	Identifier  string          `json:"identifier"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Priority    int             `json:"priority"`
+	Estimate    *float64        `json:"estimate"`
+	State       *WorkflowState  `json:"state"`
+	Assignee    *User           `json:"assignee"`
+	Creator     *User           `json:"creator"`
+	Team        *Team           `json:"team"`
+	Project     *Project        `json:"project"`
+	Cycle       *Cycle          `json:"cycle"`
+	Parent      *Issue          `json:"parent,omitempty"`
+	Children    []Issue         `json:"children,omitempty"`
+	Relations   []IssueRelation `json:"relations,omitempty"`
+	Labels      []Label         `json:"labels"`
+	Attachments []Attachment    `json:"attachments,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	DueDate     *string         `json:"dueDate"`
+	URL         string          `json:"url"`
+}
+
+// Issue relation types. Linear stores blocks and duplicate relations on one
+// issue only; blocked_by and duplicated_by are their inverses as seen from
+// the other issue.
+const (
+	RelationBlocks       = "blocks"
+	RelationBlockedBy    = "blocked_by"
+	RelationRelated      = "related"
+	RelationDuplicate    = "duplicate"
+	RelationDuplicatedBy = "duplicated_by"
+)
+
+// IssueRelation links an issue to another issue
+type IssueRelation struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Issue *Issue `json:"issue"`
+}
+
+// Comment represents a comment on an issue. Replies have a ParentID.
+type Comment struct {
+	ID        string     `json:"id"`
+	Body      string     `json:"body"`
+	User      *User      `json:"user"`
+	IssueID   string     `json:"issueId,omitempty"`
+	ParentID  *string    `json:"parentId,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	URL       string     `json:"url"`
 }
 
 // Project represents a Linear project
 type Project struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	SlugID      string    `json:"slugId,omitempty"`
 	Description string    `json:"description"`
 	State       string    `json:"state"`
 	Progress    float64   `json:"progress"`
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stustirling/lnr/internal/api"
+)
+
+// Kinds of cached entities
+const (
+	KindTeams  = "teams"
+	KindUsers  = "users"
+	KindLabels = "labels"
+	KindStates = "states"
+)
+
+// DefaultTTLs is how long each kind of entity is served from the cache.
+// Teams and users rarely change; labels are added more often.
+var DefaultTTLs = map[string]time.Duration{
+	KindTeams:  24 * time.Hour,
+	KindUsers:  12 * time.Hour,
+	KindLabels: time.Hour,
+	KindStates: 6 * time.Hour,
+}
+
+// Client is an api.Client that serves teams, users, labels and workflow
+// states from a Store, falling back to the wrapped client on a miss. Every
+// other call goes straight through, and only raw mutations, which may change
+// a cached entity, clear the store. Entities created elsewhere, such as in
+// the web UI, are picked up when a lookup made with a Refresh context
+// replaces the stale entry. Cache write failures are ignored: the cache only
+// ever saves a request.
+type Client struct {
+	api.Client
+
+	store *Store
+	ttls  map[string]time.Duration
+}
+
+// NewClient wraps client with store, using DefaultTTLs
+func NewClient(client api.Client, store *Store) *Client {
+	return &Client{Client: client, store: store, ttls: DefaultTTLs}
+}
+
+type refreshKey struct{}
+
+// Refresh returns a copy of ctx whose lookups skip the cached entries and
+// store what they fetch instead, for when a cached list is missing
+// something
+func (c *Client) Refresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+// GetUsers implements api.Client
+func (c *Client) GetUsers(ctx context.Context, opts api.ListOptions) ([]api.User, error) {
+	var users []api.User
+	err := c.cached(ctx, KindUsers, fmt.Sprintf("users:%d:%t", opts.Limit, opts.All), &users, func() (err error) {
+		users, err = c.Client.GetUsers(ctx, opts)
+		return err
+	})
+	return users, err
+}
+
+// GetTeams implements api.Client
+func (c *Client) GetTeams(ctx context.Context, opts api.ListOptions) ([]api.Team, error) {
+	var teams []api.Team
+	err := c.cached(ctx, KindTeams, fmt.Sprintf("teams:%d:%t", opts.Limit, opts.All), &teams, func() (err error) {
+		teams, err = c.Client.GetTeams(ctx, opts)
+		return err
+	})
+	return teams, err
+}
+
+// GetTeam implements api.Client
+func (c *Client) GetTeam(ctx context.Context, id string) (*api.Team, error) {
+	var team *api.Team
+	err := c.cached(ctx, KindTeams, "team:"+id, &team, func() (err error) {
+		team, err = c.Client.GetTeam(ctx, id)
+		return err
+	})
+	return team, err
+}
+
+// GetLabels implements api.Client
+func (c *Client) GetLabels(ctx context.Context, opts api.LabelListOptions) ([]api.Label, error) {
+	var labels []api.Label
+	key := fmt.Sprintf("labels:%s:%d:%t", deref(opts.TeamID), opts.Limit, opts.All)
+	err := c.cached(ctx, KindLabels, key, &labels, func() (err error) {
+		labels, err = c.Client.GetLabels(ctx, opts)
+		return err
+	})
+	return labels, err
+}
+
+// GetWorkflowStates implements api.Client
+func (c *Client) GetWorkflowStates(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+	var states []api.WorkflowState
+	key := fmt.Sprintf("states:%s:%d:%t", deref(opts.TeamID), opts.Limit, opts.All)
+	err := c.cached(ctx, KindStates, key, &states, func() (err error) {
+		states, err = c.Client.GetWorkflowStates(ctx, opts)
+		return err
+	})
+	return states, err
+}
+
+// Issue, relation and comment mutations go straight through without
+// touching the cache: none of them can change a team, user, label or
+// workflow state.
+
+// Raw implements api.Client. Raw mutations can change anything, including
+// the cached entities, so a successful one clears the cache.
+func (c *Client) Raw(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	data, err := c.Client.Raw(ctx, query, variables)
+	if api.OperationType(query) != "query" {
+		c.invalidate(err)
+	}
+	return data, err
+}
+
+// cached decodes the entry for key into v, or calls fetch to fill v and
+// stores the result. A Refresh context always fetches.
+func (c *Client) cached(ctx context.Context, kind, key string, v interface{}, fetch func() error) error {
+	if refresh, _ := ctx.Value(refreshKey{}).(bool); !refresh && c.store.Get(kind, key, c.ttls[kind], v) {
+		return nil
+	}
+	if err := fetch(); err != nil {
+		return err
+	}
+	_ = c.store.Set(kind, key, v)
+	return nil
+}
+
+// invalidate clears the cache after a successful raw mutation
+func (c *Client) invalidate(err error) {
+	if err == nil {
+		_ = c.store.Clear()
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+)
+
+func newTestClient(t *testing.T, mock *api.MockClient) (*Client, *Store) {
+	t.Helper()
+	store := NewStore(t.TempDir())
+	return NewClient(mock, store), store
+}
+
+func TestClient_GetTeams_CachesResults(t *testing.T) {
+	calls := 0
+	mock := &api.MockClient{
+		GetTeamsFunc: func(ctx context.Context, opts api.ListOptions) ([]api.Team, error) {
+			calls++
+			return []api.Team{{ID: "team-1", Key: "ENG"}}, nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		teams, err := client.GetTeams(ctx, api.ListOptions{All: true})
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, "ENG", teams[0].Key)
+	}
+	assert.Equal(t, 1, calls)
+
+	// Different options are cached separately
+	_, err := client.GetTeams(ctx, api.ListOptions{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_GetLabels_KeyedByTeam(t *testing.T) {
+	var teams []string
+	mock := &api.MockClient{
+		GetLabelsFunc: func(ctx context.Context, opts api.LabelListOptions) ([]api.Label, error) {
+			teams = append(teams, deref(opts.TeamID))
+			return []api.Label{{Name: "bug"}}, nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+	ctx := context.Background()
+	eng, des := "team-eng", "team-des"
+
+	for _, teamID := range []*string{&eng, &des, &eng, nil, nil} {
+		_, err := client.GetLabels(ctx, api.LabelListOptions{TeamID: teamID, All: true})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"team-eng", "team-des", ""}, teams)
+}
+
+func TestClient_Expires(t *testing.T) {
+	calls := 0
+	mock := &api.MockClient{
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			calls++
+			return []api.WorkflowState{{Name: "Todo"}}, nil
+		},
+	}
+	client, store := newTestClient(t, mock)
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	_, err := client.GetWorkflowStates(ctx, api.WorkflowStateListOptions{All: true})
+	require.NoError(t, err)
+
+	now = now.Add(DefaultTTLs[KindStates] - time.Minute)
+	_, err = client.GetWorkflowStates(ctx, api.WorkflowStateListOptions{All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(2 * time.Minute)
+	_, err = client.GetWorkflowStates(ctx, api.WorkflowStateListOptions{All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_DoesNotCacheErrors(t *testing.T) {
+	calls := 0
+	mock := &api.MockClient{
+		GetUsersFunc: func(ctx context.Context, opts api.ListOptions) ([]api.User, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("network down")
+			}
+			return []api.User{{Name: "Ada"}}, nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+	ctx := context.Background()
+
+	_, err := client.GetUsers(ctx, api.ListOptions{All: true})
+	assert.ErrorContains(t, err, "network down")
+
+	users, err := client.GetUsers(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", users[0].Name)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_IssueMutationsKeepCache(t *testing.T) {
+	calls := 0
+	mock := &api.MockClient{
+		GetTeamFunc: func(ctx context.Context, id string) (*api.Team, error) {
+			calls++
+			return &api.Team{ID: id, Key: "ENG"}, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, id string, input api.IssueUpdateInput) (*api.Issue, error) {
+			return &api.Issue{Identifier: id}, nil
+		},
+		CreateCommentFunc: func(ctx context.Context, input api.CommentCreateInput) (*api.Comment, error) {
+			return &api.Comment{Body: input.Body}, nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+	ctx := context.Background()
+
+	_, err := client.GetTeam(ctx, "team-1")
+	require.NoError(t, err)
+
+	// Issues and comments are never cached, and changing them cannot change
+	// a team, so the cached team is still served
+	_, err = client.UpdateIssue(ctx, "ENG-1", api.IssueUpdateInput{})
+	require.NoError(t, err)
+	_, err = client.CreateComment(ctx, api.CommentCreateInput{IssueID: "ENG-1", Body: "Done"})
+	require.NoError(t, err)
+	team, err := client.GetTeam(ctx, "team-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ENG", team.Key)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_PassesThroughOtherCalls(t *testing.T) {
+	calls := 0
+	mock := &api.MockClient{
+		GetViewerFunc: func(ctx context.Context) (*api.User, error) {
+			calls++
+			return &api.User{Name: "Ada"}, nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetViewer(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_RawMutationsInvalidate(t *testing.T) {
+	calls := 0
+	mock := &api.MockClient{
+		GetTeamsFunc: func(ctx context.Context, opts api.ListOptions) ([]api.Team, error) {
+			calls++
+			return []api.Team{{Key: "ENG"}}, nil
+		},
+		RawFunc: func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+			if strings.Contains(query, "invalid") {
+				return nil, errors.New("invalid input")
+			}
+			return json.RawMessage(`{}`), nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+	ctx := context.Background()
+
+	_, err := client.GetTeams(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+
+	_, err = client.Raw(ctx, "{ viewer { id } }", nil)
+	require.NoError(t, err)
+	_, err = client.GetTeams(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// A failed mutation changes nothing, so the cache is kept
+	_, err = client.Raw(ctx, `mutation { teamUpdate(id: "invalid", input: {}) { success } }`, nil)
+	require.Error(t, err)
+	_, err = client.GetTeams(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	_, err = client.Raw(ctx, `mutation { teamUpdate(id: "x", input: {}) { success } }`, nil)
+	require.NoError(t, err)
+	_, err = client.GetTeams(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_RefreshBypassesCache(t *testing.T) {
+	users := []api.User{{ID: "user-alice", Name: "Alice"}}
+	calls := 0
+	mock := &api.MockClient{
+		GetUsersFunc: func(ctx context.Context, opts api.ListOptions) ([]api.User, error) {
+			calls++
+			return users, nil
+		},
+	}
+	client, _ := newTestClient(t, mock)
+	ctx := context.Background()
+
+	_, err := client.GetUsers(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+
+	// A user added since is only seen by a refresh, which replaces the entry
+	users = append(users, api.User{ID: "user-bob", Name: "Bob"})
+	got, err := client.GetUsers(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	got, err = client.GetUsers(client.Refresh(ctx), api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = client.GetUsers(ctx, api.ListOptions{All: true})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, 2, calls)
+}
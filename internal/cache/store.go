@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// lockFile serialises writers across concurrent lnr invocations
+	lockFile = ".lock"
+	// lockTimeout is how long a writer waits for another to finish
+	lockTimeout = 5 * time.Second
+	// staleLock is the age after which a lock left by a crashed process is
+	// broken
+	staleLock = 30 * time.Second
+)
+
+// ErrLocked is returned when the cache lock cannot be acquired in time
+var ErrLocked = errors.New("cache is locked by another lnr process")
+
+// Store keeps JSON entries in a directory, one file per key. Writes go
+// through a temporary file and a rename so readers never see a partial
+// entry, and are serialised with a lock file so concurrent invocations do
+// not race on clearing.
+type Store struct {
+	dir string
+	now func() time.Time
+}
+
+// entry is the on-disk form of a cached value
+type entry struct {
+	Kind     string          `json:"kind"`
+	StoredAt time.Time       `json:"storedAt"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// Stat summarises the entries of one kind
+type Stat struct {
+	Kind    string    `json:"kind"`
+	Entries int       `json:"entries"`
+	Expired int       `json:"expired"`
+	Bytes   int64     `json:"bytes"`
+	Oldest  time.Time `json:"oldest"`
+}
+
+// DefaultDir returns the directory lnr caches into, under the user cache
+// directory
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("find cache directory: %w", err)
+	}
+	return filepath.Join(dir, "lnr"), nil
+}
+
+// WorkspaceDir returns the directory for one API endpoint and key, so
+// switching keys or endpoints never serves another workspace's data
+func WorkspaceDir(root, endpoint, apiKey string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + apiKey))
+	return filepath.Join(root, hex.EncodeToString(sum[:8]))
+}
+
+// NewStore creates a store in dir. The directory is created on first
+// write.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, now: time.Now}
+}
+
+// Dir returns the directory the store writes to
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Get decodes the entry for key into v. It reports false when the entry is
+// missing, unreadable or older than ttl.
+func (s *Store) Get(kind, key string, ttl time.Duration, v interface{}) bool {
+	data, err := os.ReadFile(s.path(kind, key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	if s.now().Sub(e.StoredAt) > ttl {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Set stores v under key
+func (s *Store) Set(kind, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+	data, err = json.Marshal(entry{Kind: kind, StoredAt: s.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+
+	return s.withLock(func() error {
+		tmp, err := os.CreateTemp(s.dir, "entry-*.tmp")
+		if err != nil {
+			return fmt.Errorf("write cache entry: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		if _, err := tmp.Write(data); err != nil {
+			tmp.Close()
+			return fmt.Errorf("write cache entry: %w", err)
+		}
+		if err := tmp.Close(); err != nil {
+			return fmt.Errorf("write cache entry: %w", err)
+		}
+		if err := os.Rename(tmp.Name(), s.path(kind, key)); err != nil {
+			return fmt.Errorf("write cache entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// Clear removes every entry
+func (s *Store) Clear() error {
+	if _, err := os.Stat(s.dir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return s.withLock(func() error {
+		files, err := s.entries()
+		if err != nil {
+			return err
+		}
+		for _, name := range files {
+			if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("clear cache: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Stats summarises the stored entries by kind, using ttls to count expired
+// entries
+func (s *Store) Stats(ttls map[string]time.Duration) ([]Stat, error) {
+	files, err := s.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	byKind := map[string]*Stat{}
+	for _, name := range files {
+		path := filepath.Join(s.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		st, ok := byKind[e.Kind]
+		if !ok {
+			st = &Stat{Kind: e.Kind, Oldest: e.StoredAt}
+			byKind[e.Kind] = st
+		}
+		st.Entries++
+		st.Bytes += int64(len(data))
+		if e.StoredAt.Before(st.Oldest) {
+			st.Oldest = e.StoredAt
+		}
+		if ttl, ok := ttls[e.Kind]; ok && s.now().Sub(e.StoredAt) > ttl {
+			st.Expired++
+		}
+	}
+
+	stats := make([]Stat, 0, len(byKind))
+	for _, st := range byKind {
+		stats = append(stats, *st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Kind < stats[j].Kind })
+	return stats, nil
+}
+
+// path returns the file for key. Keys are hashed so any string is safe.
+func (s *Store) path(kind, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, kind+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// entries lists the entry files in the store, recursing into workspace
+// directories when the store is the cache root
+func (s *Store) entries() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".json") {
+			rel, err := filepath.Rel(s.dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read cache: %w", err)
+	}
+	return files, nil
+}
+
+// withLock runs fn while holding the store's lock file
+func (s *Store) withLock(fn func() error) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+
+	path := filepath.Join(s.dir, lockFile)
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("lock cache: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return ErrLocked
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer os.Remove(path)
+
+	return fn()
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SetGet(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested"))
+
+	var got []string
+	assert.False(t, store.Get(KindTeams, "key", time.Hour, &got))
+
+	require.NoError(t, store.Set(KindTeams, "key", []string{"ENG", "DES"}))
+	assert.True(t, store.Get(KindTeams, "key", time.Hour, &got))
+	assert.Equal(t, []string{"ENG", "DES"}, got)
+
+	assert.False(t, store.Get(KindTeams, "other", time.Hour, &got))
+}
+
+func TestStore_IgnoresCorruptEntries(t *testing.T) {
+	store := NewStore(t.TempDir())
+	require.NoError(t, os.WriteFile(store.path(KindTeams, "key"), []byte("{not json"), 0o600))
+
+	var got []string
+	assert.False(t, store.Get(KindTeams, "key", time.Hour, &got))
+}
+
+func TestStore_ClearAndStats(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	workspace := NewStore(WorkspaceDir(root, "", "key-1"))
+	workspace.now = func() time.Time { return now }
+
+	require.NoError(t, workspace.Set(KindTeams, "a", []string{"ENG"}))
+	require.NoError(t, workspace.Set(KindLabels, "a", []string{"bug"}))
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, workspace.Set(KindLabels, "b", []string{"feature"}))
+
+	// The root store sees every workspace
+	all := NewStore(root)
+	all.now = workspace.now
+	stats, err := all.Stats(DefaultTTLs)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, KindLabels, stats[0].Kind)
+	assert.Equal(t, 2, stats[0].Entries)
+	assert.Equal(t, 1, stats[0].Expired)
+	assert.Equal(t, KindTeams, stats[1].Kind)
+	assert.Equal(t, 0, stats[1].Expired)
+
+	require.NoError(t, all.Clear())
+	stats, err = all.Stats(DefaultTTLs)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestStore_ClearMissingDir(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, store.Clear())
+
+	stats, err := store.Stats(DefaultTTLs)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestWorkspaceDir_SeparatesKeys(t *testing.T) {
+	a := WorkspaceDir("/cache", "", "key-1")
+	b := WorkspaceDir("/cache", "", "key-2")
+	c := WorkspaceDir("/cache", "http://localhost/graphql", "key-1")
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Equal(t, a, WorkspaceDir("/cache", "", "key-1"))
+}
+
+func TestStore_ConcurrentWriters(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- store.Set(KindUsers, "key", []int{i})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var got []int
+	assert.True(t, store.Get(KindUsers, "key", time.Hour, &got))
+	assert.Len(t, got, 1)
+	assert.NoFileExists(t, filepath.Join(store.Dir(), lockFile))
+}
+
+func TestStore_BreaksStaleLock(t *testing.T) {
+	store := NewStore(t.TempDir())
+	lock := filepath.Join(store.Dir(), lockFile)
+	require.NoError(t, os.WriteFile(lock, nil, 0o600))
+	old := time.Now().Add(-2 * staleLock)
+	require.NoError(t, os.Chtimes(lock, old, old))
+
+	assert.NoError(t, store.Set(KindTeams, "key", []string{"ENG"}))
+}
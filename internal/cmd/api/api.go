@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdAPI creates the api parent command
+func NewCmdAPI() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Make authenticated Linear API requests",
+		Long: `Send requests straight to the Linear API, for anything lnr has no
+command for yet. Requests use your API key and are retried like any other.`,
+	}
+
+	cmd.AddCommand(NewCmdGraphQL())
+
+	return cmd
+}
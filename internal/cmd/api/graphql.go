@@ -0,0 +1,301 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// maxPages stops --paginate from looping forever on a misbehaving cursor
+const maxPages = 1000
+
+// cursorVariable matches the variable --paginate sets to the end cursor
+var cursorVariable = regexp.MustCompile(`\$(after|endCursor)\b`)
+
+// graphqlOptions holds the request and how to print the response
+type graphqlOptions struct {
+	Query     string
+	Variables map[string]interface{}
+	Paginate  bool
+	Raw       bool
+	Out       io.Writer
+}
+
+// NewCmdGraphQL creates the api graphql command
+func NewCmdGraphQL() *cobra.Command {
+	var fields, typedFields []string
+	var input string
+	var opts graphqlOptions
+
+	cmd := &cobra.Command{
+		Use:   "graphql",
+		Short: "Send a GraphQL query or mutation",
+		Long: `Send a GraphQL query or mutation to Linear and print the data it returns
+as JSON.
+
+Pass the document as the query field and anything else as variables:
+
+  -f key=value   adds a string variable
+  -F key=value   adds a variable typed from its JSON form: numbers, true,
+                 false, null, arrays and objects; anything else is a string
+
+A value of @file reads it from a file, and @- from stdin. Variables can also
+be read from a JSON object with --input; flags override it.
+
+With --paginate, every page of the query's connection is fetched and their
+nodes combined. The query must take an $after (or $endCursor) variable and
+select pageInfo { hasNextPage endCursor } on the connection.`,
+		Example: `  lnr api graphql -f query='{ viewer { id name } }'
+  lnr api graphql -f query=@team.graphql -F teamId=ENG
+  lnr api graphql --paginate -f query='
+    query($after: String) {
+      issueLabels(first: 100, after: $after) {
+        nodes { id name }
+        pageInfo { hasNextPage endCursor }
+      }
+    }'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			variables, err := readVariables(input)
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if err := setField(variables, f, false); err != nil {
+					return err
+				}
+			}
+			for _, f := range typedFields {
+				if err := setField(variables, f, true); err != nil {
+					return err
+				}
+			}
+
+			query, _ := variables["query"].(string)
+			delete(variables, "query")
+			opts.Query = query
+			opts.Variables = variables
+			opts.Out = os.Stdout
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runGraphQL(cmd.Context(), jsonOutput, opts)
+		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
+	}
+
+	cmd.Flags().StringArrayVarP(&fields, "raw-field", "f", nil, "Add a string variable, or the query, as key=value")
+	cmd.Flags().StringArrayVarP(&typedFields, "field", "F", nil, "Add a typed variable as key=value")
+	cmd.Flags().StringVar(&input, "input", "", "Read variables from a JSON file (use - for stdin)")
+	cmd.Flags().BoolVar(&opts.Paginate, "paginate", false, "Fetch every page and combine the results")
+	cmd.Flags().BoolVar(&opts.Raw, "raw", false, "Print compact JSON instead of indenting it")
+
+	return cmd
+}
+
+func runGraphQL(ctx context.Context, jsonOutput bool, opts graphqlOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runGraphQLWithFactory(factory, opts)
+}
+
+func runGraphQLWithFactory(factory *cmdutil.Factory, opts graphqlOptions) error {
+	if strings.TrimSpace(opts.Query) == "" {
+		return &cmdutil.FlagError{Err: errors.New("a query is required (use -f query='{ ... }' or -f query=@file.graphql)")}
+	}
+	ctx := factory.Context
+
+	var data json.RawMessage
+	var queryErr error
+	if opts.Paginate {
+		data, queryErr = paginate(ctx, factory, opts.Query, opts.Variables)
+	} else {
+		data, queryErr = factory.Client.Raw(ctx, opts.Query, opts.Variables)
+	}
+	if queryErr != nil && data == nil {
+		return fmt.Errorf("failed to run query: %w", queryErr)
+	}
+
+	var err error
+	var buf bytes.Buffer
+	if opts.Raw {
+		err = json.Compact(&buf, data)
+	} else {
+		err = json.Indent(&buf, data, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to format response: %w", err)
+	}
+	buf.WriteByte('\n')
+	if _, err := buf.WriteTo(opts.Out); err != nil {
+		return err
+	}
+	return queryErr
+}
+
+// paginate runs query once per page, following the end cursor of its
+// connection, and returns the first page with every page's nodes. When ctx
+// is cancelled after the first page, the pages fetched so far are returned
+// along with the error.
+func paginate(ctx context.Context, factory *cmdutil.Factory, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	match := cursorVariable.FindStringSubmatch(query)
+	if match == nil {
+		return nil, &cmdutil.FlagError{Err: errors.New("--paginate needs a query with an $after or $endCursor variable")}
+	}
+	cursor := match[1]
+
+	vars := make(map[string]interface{}, len(variables)+1)
+	for k, v := range variables {
+		vars[k] = v
+	}
+
+	var first map[string]interface{}
+	var combined map[string]interface{}
+	for page := 0; page < maxPages; page++ {
+		raw, err := factory.Client.Raw(ctx, query, vars)
+		if err != nil && first != nil && ctx.Err() != nil {
+			data, marshalErr := json.Marshal(first)
+			if marshalErr != nil {
+				return nil, err
+			}
+			return data, cmdutil.Incomplete(err, page, "pages")
+		}
+		if err != nil {
+			return nil, err
+		}
+		var data map[string]interface{}
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		conn, err := findConnection(data)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first, combined = data, conn
+		} else {
+			for _, key := range []string{"nodes", "edges"} {
+				if items, ok := conn[key].([]interface{}); ok {
+					existing, _ := combined[key].([]interface{})
+					combined[key] = append(existing, items...)
+				}
+			}
+			combined["pageInfo"] = conn["pageInfo"]
+		}
+
+		pageInfo := conn["pageInfo"].(map[string]interface{})
+		hasNext, _ := pageInfo["hasNextPage"].(bool)
+		endCursor, _ := pageInfo["endCursor"].(string)
+		if !hasNext || endCursor == "" {
+			break
+		}
+		vars[cursor] = endCursor
+	}
+
+	return json.Marshal(first)
+}
+
+// findConnection returns the one object in data with a pageInfo field.
+// Lists are not searched, so connections nested inside nodes are ignored.
+func findConnection(data map[string]interface{}) (map[string]interface{}, error) {
+	var found []map[string]interface{}
+	var walk func(obj map[string]interface{})
+	walk = func(obj map[string]interface{}) {
+		if _, ok := obj["pageInfo"].(map[string]interface{}); ok {
+			found = append(found, obj)
+			return
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if child, ok := obj[k].(map[string]interface{}); ok {
+				walk(child)
+			}
+		}
+	}
+	walk(data)
+
+	switch len(found) {
+	case 0:
+		return nil, errors.New("--paginate needs the query to select pageInfo { hasNextPage endCursor } on a connection")
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("--paginate supports one paginated connection per query, found %d", len(found))
+	}
+}
+
+// readVariables reads a JSON object of variables from path, or from stdin
+// when path is -
+func readVariables(path string) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	if path == "" {
+		return variables, nil
+	}
+	data, err := readValue("@" + path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(data), &variables); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: variables must be a JSON object: %w", path, err)
+	}
+	return variables, nil
+}
+
+// setField parses a key=value flag into variables. Typed values are
+// decoded as JSON when they parse as JSON.
+func setField(variables map[string]interface{}, field string, typed bool) error {
+	key, value, ok := strings.Cut(field, "=")
+	if !ok || key == "" {
+		return &cmdutil.FlagError{Err: fmt.Errorf("invalid field %q: use key=value", field)}
+	}
+	value, err := readValue(value)
+	if err != nil {
+		return err
+	}
+
+	if typed {
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			variables[key] = v
+			return nil
+		}
+	}
+	variables[key] = value
+	return nil
+}
+
+// readValue returns value, or the contents of the file it names when it
+// starts with @ (@- for stdin)
+func readValue(value string) (string, error) {
+	path, ok := strings.CutPrefix(value, "@")
+	if !ok {
+		return value, nil
+	}
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	linear "github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/api/apitest"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func newTestFactory(t *testing.T) *cmdutil.Factory {
+	t.Helper()
+	srv := apitest.NewServer(apitest.NewDemoWorkspace())
+	t.Cleanup(srv.Close)
+	client := linear.NewClientWithOptions("test-key", linear.ClientOptions{Endpoint: srv.URL})
+	return cmdutil.NewFactoryWithClient(client, false)
+}
+
+func TestRunGraphQL(t *testing.T) {
+	factory := newTestFactory(t)
+
+	var buf bytes.Buffer
+	err := runGraphQLWithFactory(factory, graphqlOptions{
+		Query:     `query($id: String!) { team(id: $id) { key name } }`,
+		Variables: map[string]interface{}{"id": "ENG"},
+		Out:       &buf,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "team": {
+    "key": "ENG",
+    "name": "Engineering"
+  }
+}
+`, buf.String())
+
+	buf.Reset()
+	err = runGraphQLWithFactory(factory, graphqlOptions{Query: `{ viewer { name } }`, Raw: true, Out: &buf})
+	require.NoError(t, err)
+	assert.Equal(t, `{"viewer":{"name":"Ada Lovelace"}}`+"\n", buf.String())
+}
+
+func TestRunGraphQL_Errors(t *testing.T) {
+	factory := newTestFactory(t)
+
+	err := runGraphQLWithFactory(factory, graphqlOptions{Out: &bytes.Buffer{}})
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+
+	err = runGraphQLWithFactory(factory, graphqlOptions{
+		Query: `{ issue(id: "ENG-999") { id } }`,
+		Out:   &bytes.Buffer{},
+	})
+	assert.ErrorIs(t, err, linear.ErrNotFound)
+}
+
+func TestRunGraphQL_Paginate(t *testing.T) {
+	factory := newTestFactory(t)
+
+	var buf bytes.Buffer
+	err := runGraphQLWithFactory(factory, graphqlOptions{
+		Query: `query($after: String) {
+			users(first: 1, after: $after) {
+				nodes { name }
+				pageInfo { hasNextPage endCursor }
+			}
+		}`,
+		Paginate: true,
+		Out:      &buf,
+	})
+	require.NoError(t, err)
+
+	var result struct {
+		Users struct {
+			Nodes []struct {
+				Name string `json:"name"`
+			} `json:"nodes"`
+			PageInfo struct {
+				HasNextPage bool `json:"hasNextPage"`
+			} `json:"pageInfo"`
+		} `json:"users"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result.Users.Nodes, 3)
+	assert.Equal(t, "Ada Lovelace", result.Users.Nodes[0].Name)
+	assert.Equal(t, "Alan Turing", result.Users.Nodes[2].Name)
+	assert.False(t, result.Users.PageInfo.HasNextPage)
+}
+
+func TestRunGraphQL_PaginateInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	client := &linear.MockClient{
+		RawFunc: func(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+			calls++
+			if calls > 1 {
+				cancel()
+				return nil, ctx.Err()
+			}
+			return json.RawMessage(`{"users":{"nodes":[{"name":"Ada"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}`), nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(client, false)
+	factory.Context = ctx
+
+	var buf bytes.Buffer
+	err := runGraphQLWithFactory(factory, graphqlOptions{
+		Query:    `query($after: String) { users(after: $after) { nodes { name } pageInfo { hasNextPage endCursor } } }`,
+		Paginate: true,
+		Raw:      true,
+		Out:      &buf,
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "stopped after 1 pages")
+	assert.JSONEq(t, `{"users":{"nodes":[{"name":"Ada"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}`, buf.String())
+}
+
+func TestRunGraphQL_PaginateNeedsCursorAndPageInfo(t *testing.T) {
+	factory := newTestFactory(t)
+
+	err := runGraphQLWithFactory(factory, graphqlOptions{
+		Query:    `{ users(first: 1) { nodes { name } pageInfo { hasNextPage endCursor } } }`,
+		Paginate: true,
+		Out:      &bytes.Buffer{},
+	})
+	assert.ErrorContains(t, err, "$after")
+
+	err = runGraphQLWithFactory(factory, graphqlOptions{
+		Query:    `query($after: String) { users(first: 1, after: $after) { nodes { name } } }`,
+		Paginate: true,
+		Out:      &bytes.Buffer{},
+	})
+	assert.ErrorContains(t, err, "pageInfo")
+
+	err = runGraphQLWithFactory(factory, graphqlOptions{
+		Query: `query($after: String) {
+			users(first: 1, after: $after) { pageInfo { hasNextPage endCursor } }
+			teams(first: 1, after: $after) { pageInfo { hasNextPage endCursor } }
+		}`,
+		Paginate: true,
+		Out:      &bytes.Buffer{},
+	})
+	assert.ErrorContains(t, err, "one paginated connection")
+}
+
+func TestSetField(t *testing.T) {
+	dir := t.TempDir()
+	queryFile := filepath.Join(dir, "query.graphql")
+	require.NoError(t, os.WriteFile(queryFile, []byte("{ viewer { id } }"), 0o600))
+
+	variables := map[string]interface{}{}
+	require.NoError(t, setField(variables, "query=@"+queryFile, false))
+	require.NoError(t, setField(variables, "teamId=ENG", true))
+	require.NoError(t, setField(variables, "first=10", true))
+	require.NoError(t, setField(variables, "count=10", false))
+	require.NoError(t, setField(variables, "archived=false", true))
+	require.NoError(t, setField(variables, `input={"title":"Hi"}`, true))
+	require.NoError(t, setField(variables, "empty=", false))
+
+	assert.Equal(t, map[string]interface{}{
+		"query":    "{ viewer { id } }",
+		"teamId":   "ENG",
+		"first":    float64(10),
+		"count":    "10",
+		"archived": false,
+		"input":    map[string]interface{}{"title": "Hi"},
+		"empty":    "",
+	}, variables)
+
+	assert.Error(t, setField(variables, "novalue", false))
+	assert.Error(t, setField(variables, "missing=@"+filepath.Join(dir, "nope"), false))
+}
+
+func TestReadVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vars.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"teamId": "ENG", "first": 5}`), 0o600))
+
+	variables, err := readVariables(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"teamId": "ENG", "first": float64(5)}, variables)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[1, 2]`), 0o600))
+	_, err = readVariables(path)
+	assert.ErrorContains(t, err, "JSON object")
+}
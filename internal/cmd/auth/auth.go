@@ -9,10 +9,16 @@ func NewCmdAuth() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Authentication commands",
-		Long:  "Commands for managing Linear authentication.",
+		Long: `Commands for managing Linear authentication.
+
+lnr uses LINEAR_API_KEY when it is set, and otherwise the API key of the
+active profile saved by lnr auth login.`,
 	}
 
+	cmd.AddCommand(NewCmdLogin())
+	cmd.AddCommand(NewCmdLogout())
 	cmd.AddCommand(NewCmdStatus())
+	cmd.AddCommand(NewCmdSwitch())
 
 	return cmd
 }
@@ -0,0 +1,315 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/oauth"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+	"golang.org/x/term"
+)
+
+// defaultProfile is the profile auth login saves to without --profile
+const defaultProfile = "default"
+
+// loginResult is the JSON form of auth login
+type loginResult struct {
+	Profile      string            `json:"profile"`
+	User         *api.User         `json:"user"`
+	Organisation *api.Organisation `json:"organisation"`
+}
+
+// NewCmdLogin creates the auth login command
+func NewCmdLogin() *cobra.Command {
+	var apiURL, helper string
+	var useOAuth bool
+	app := oauth.App{}
+	var port int
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Save an API key or OAuth login as a profile",
+		Long: `Check a Linear API key and save it as a profile, which then becomes the
+active one. Use --profile to name it; it is called "default" otherwise.
+
+The key is read from standard input, so it can also be piped in. Create one
+in Linear under Settings > Account > Security & Access > Personal API keys.
+
+To keep the key out of the config file, give --credential-helper a command
+that prints it, such as a password manager's CLI. The command is saved
+instead of the key and runs whenever lnr needs the key.
+
+With --oauth, lnr logs in through an OAuth app instead: it opens the
+authorization page in a browser and waits for the redirect on
+http://127.0.0.1:PORT/callback, which must be one of the app's redirect
+URIs. The access and refresh tokens are saved in the profile and the access
+token is refreshed when it expires.
+
+The key is checked against, and the profile saved with, the --api-url
+endpoint, or else LINEAR_API_URL, the endpoint the profile already has or
+the api_url setting.
+
+Each profile keeps its own credentials, endpoint and settings, so belonging
+to more than one workspace only needs lnr auth switch. LINEAR_API_KEY, when
+set, still takes precedence over the profile.`,
+		Example: `  lnr auth login
+  lnr auth login --profile work < work-key.txt
+  lnr auth login --credential-helper "op read op://Private/Linear/credential"
+  lnr auth login --oauth --client-id 1a2b3c --port 8765`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if useOAuth && app.ClientID == "" {
+				return &cmdutil.FlagError{Err: errors.New("--client-id is required with --oauth")}
+			}
+			file, err := readUserConfig()
+			if err != nil {
+				return err
+			}
+			profile := config.ProfileOverride(cmd.Context())
+			if profile == "" {
+				profile = defaultProfile
+			}
+			logger, err := cmdutil.DebugLogger(cmd.Context(), os.Stderr)
+			if err != nil {
+				return err
+			}
+			opts := loginOptions{profile: profile, apiURL: loginEndpoint(file, profile, apiURL), helper: helper}
+			formatter := output.NewFormatter(jsonOutput)
+			if useOAuth {
+				opts.oauth, opts.port = &app, port
+				return runOAuthLogin(cmd.Context(), formatter, file, opts, openAuthorizeURL, oauthClient(logger))
+			}
+			in := io.Reader(os.Stdin)
+			if helper == "" && cmdutil.IsTerminal(os.Stdin) {
+				if in, err = promptAPIKey(os.Stdin, os.Stderr); err != nil {
+					return err
+				}
+			}
+			newClient, err := loginClient(cmd.Context(), logger)
+			if err != nil {
+				return err
+			}
+			return runLogin(cmd.Context(), formatter, file, opts, in, newClient)
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", "", "GraphQL endpoint, when it is not Linear's (default LINEAR_API_URL or the profile's)")
+	cmd.Flags().StringVar(&helper, "credential-helper", "", "Command that prints the API key, saved instead of the key")
+	cmd.Flags().BoolVar(&useOAuth, "oauth", false, "Log in through an OAuth app in the browser")
+	cmd.Flags().StringVar(&app.ClientID, "client-id", "", "OAuth app client ID")
+	cmd.Flags().StringVar(&app.ClientSecret, "client-secret", "", "OAuth app client secret, if the app has one")
+	cmd.Flags().StringVar(&app.AuthorizeURL, "authorize-url", oauth.LinearAuthorizeURL, "OAuth authorization endpoint")
+	cmd.Flags().StringVar(&app.TokenURL, "token-url", oauth.LinearTokenURL, "OAuth token endpoint")
+	cmd.Flags().StringVar(&app.Scopes, "scopes", oauth.DefaultScopes, "OAuth scopes to ask for")
+	cmd.Flags().IntVar(&port, "port", 0, "Port for the OAuth redirect to 127.0.0.1 (default any free port)")
+	cmd.MarkFlagsMutuallyExclusive("oauth", "credential-helper")
+
+	return cmd
+}
+
+// loginEndpoint returns the endpoint a login is checked against and saved
+// with: --api-url, or else LINEAR_API_URL, so a key for a test server such
+// as lnr dev fake-server is not sent to Linear, or else the profile's
+// current endpoint or the top-level api_url setting
+func loginEndpoint(file *config.File, profile, apiURL string) string {
+	if apiURL != "" {
+		return apiURL
+	}
+	if apiURL = os.Getenv(config.EnvAPIURL); apiURL != "" {
+		return apiURL
+	}
+	if apiURL, ok := file.ProfileGet(profile, config.ProfileAPIURL); ok {
+		return apiURL
+	}
+	apiURL, _ = file.Get(config.KeyAPIURL)
+	return apiURL
+}
+
+// loginClient returns the function that creates the client a key is
+// checked with. --record, --replay and --debug apply to the check as they
+// do to every other command.
+func loginClient(ctx context.Context, logger *slog.Logger) (func(apiKey, apiURL string) api.Client, error) {
+	record, replay := config.CassettesFor(config.OverridesFrom(ctx))
+	var replayer http.RoundTripper
+	if replay != "" {
+		var err error
+		if replayer, err = api.NewReplayTransport(replay); err != nil {
+			return nil, err
+		}
+	}
+	return func(apiKey, apiURL string) api.Client {
+		opts := api.ClientOptions{Endpoint: apiURL, Transport: replayer, Logger: logger}
+		if replayer == nil && record != "" {
+			opts.Transport = api.NewRecordTransport(record, nil, apiKey)
+		}
+		return api.NewClientWithOptions(apiKey, opts)
+	}, nil
+}
+
+// oauthClient returns the function that creates the client an OAuth login
+// is checked with
+func oauthClient(logger *slog.Logger) func(tokens api.TokenSource, apiURL string) api.Client {
+	return func(tokens api.TokenSource, apiURL string) api.Client {
+		return api.NewClientWithOptions("", api.ClientOptions{Endpoint: apiURL, TokenSource: tokens, Logger: logger})
+	}
+}
+
+// openAuthorizeURL shows the authorization URL and tries to open it. A
+// browser that fails to start is not an error, as the URL can be copied.
+func openAuthorizeURL(url string) error {
+	fmt.Fprintf(os.Stderr, "Opening %s\nin your browser. Waiting for you to authorize lnr...\n", url)
+	if err := cmdutil.OpenBrowser(url); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not open a browser (%v); open the URL above to continue.\n", err)
+	}
+	return nil
+}
+
+// loginOptions says what auth login saves
+type loginOptions struct {
+	profile string
+	apiURL  string
+	// helper, when set, is run for the key instead of reading it
+	helper string
+	// oauth is the app an OAuth login authorizes, and port where its
+	// redirect is received
+	oauth *oauth.App
+	port  int
+}
+
+func runLogin(ctx context.Context, formatter *output.Formatter, file *config.File, opts loginOptions, in io.Reader, newClient func(apiKey, apiURL string) api.Client) error {
+	apiKey, err := readAPIKey(opts, in)
+	if err != nil {
+		return err
+	}
+
+	user, org, err := checkLogin(ctx, newClient(apiKey, opts.apiURL))
+	if err != nil {
+		return err
+	}
+
+	profile := opts.profile
+	if opts.helper != "" {
+		file.ProfileSet(profile, config.KeyCredentialHelper, opts.helper)
+		file.ProfileUnset(profile, config.ProfileAPIKey)
+	} else {
+		file.ProfileSet(profile, config.ProfileAPIKey, apiKey)
+		file.ProfileUnset(profile, config.KeyCredentialHelper)
+	}
+	config.WriteOAuth(file, profile, &config.OAuth{})
+	return saveLogin(formatter, file, opts, user, org)
+}
+
+func runOAuthLogin(ctx context.Context, formatter *output.Formatter, file *config.File, opts loginOptions, open func(url string) error, newClient func(tokens api.TokenSource, apiURL string) api.Client) error {
+	token, err := opts.oauth.Login(ctx, oauth.LoginOptions{Port: opts.port, Open: open})
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+
+	// Tokens refreshed while checking are kept in token
+	tokens := oauth.NewTokenSource(opts.oauth, *token, func(t *oauth.Token) error {
+		*token = *t
+		return nil
+	})
+	user, org, err := checkLogin(ctx, newClient(tokens, opts.apiURL))
+	if err != nil {
+		return err
+	}
+
+	profile := opts.profile
+	config.WriteOAuth(file, profile, &config.OAuth{
+		ClientID:     opts.oauth.ClientID,
+		ClientSecret: opts.oauth.ClientSecret,
+		AuthorizeURL: opts.oauth.AuthorizeURL,
+		TokenURL:     opts.oauth.TokenURL,
+		Scopes:       opts.oauth.Scopes,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	})
+	file.ProfileUnset(profile, config.ProfileAPIKey)
+	file.ProfileUnset(profile, config.KeyCredentialHelper)
+	return saveLogin(formatter, file, opts, user, org)
+}
+
+// checkLogin fetches who the new credentials belong to
+func checkLogin(ctx context.Context, client api.Client) (*api.User, *api.Organisation, error) {
+	user, err := client.GetViewer(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to log in: %w", err)
+	}
+	org, err := client.GetOrganisation(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get organisation: %w", err)
+	}
+	return user, org, nil
+}
+
+// saveLogin saves the profile's endpoint, makes it the active profile and
+// reports the login
+func saveLogin(formatter *output.Formatter, file *config.File, opts loginOptions, user *api.User, org *api.Organisation) error {
+	profile := opts.profile
+	if opts.apiURL != "" {
+		file.ProfileSet(profile, config.ProfileAPIURL, opts.apiURL)
+	} else {
+		file.ProfileUnset(profile, config.ProfileAPIURL)
+	}
+	file.Set(config.KeyProfile, profile)
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	text := fmt.Sprintf("Logged in to %s as %s (%s). Saved as profile %s.\n", org.Name, user.Name, user.Email, profile)
+	return formatter.PrintText(text, loginResult{Profile: profile, User: user, Organisation: org})
+}
+
+// readAPIKey gets the key to log in with from the credential helper or in
+func readAPIKey(opts loginOptions, in io.Reader) (string, error) {
+	if opts.helper != "" {
+		return config.RunCredentialHelper(opts.helper, opts.profile)
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read API key: %w", err)
+	}
+	apiKey := strings.TrimSpace(line)
+	if apiKey == "" {
+		return "", errors.New("no API key given")
+	}
+	return apiKey, nil
+}
+
+// promptAPIKey asks for the API key on the terminal tty without echoing
+// it, and returns a reader of what was typed
+func promptAPIKey(tty *os.File, prompt io.Writer) (io.Reader, error) {
+	fmt.Fprint(prompt, "Paste your Linear API key: ")
+	key, err := term.ReadPassword(int(tty.Fd()))
+	// The newline the user typed is not echoed either
+	fmt.Fprintln(prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API key: %w", err)
+	}
+	return bytes.NewReader(key), nil
+}
+
+// readUserConfig reads the user config file, which holds the profiles
+func readUserConfig() (*config.File, error) {
+	path, err := config.UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return config.ReadFile(path)
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/api/apitest"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/oauth"
+	"github.com/stustirling/lnr/internal/oauth/oauthtest"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func newTestFile(t *testing.T) *config.File {
+	t.Helper()
+	file, err := config.ReadFile(filepath.Join(t.TempDir(), "lnr", "config.yaml"))
+	require.NoError(t, err)
+	return file
+}
+
+// fakeLogin accepts the key "good-key" only
+func fakeLogin(t *testing.T, wantURL string) func(apiKey, apiURL string) api.Client {
+	return func(apiKey, apiURL string) api.Client {
+		assert.Equal(t, wantURL, apiURL)
+		return &api.MockClient{
+			GetViewerFunc: func(ctx context.Context) (*api.User, error) {
+				if apiKey != "good-key" {
+					return nil, &api.APIError{Kind: api.ErrUnauthenticated, Message: "Authentication required"}
+				}
+				return &api.User{Name: "Ada Lovelace", Email: "ada@example.com"}, nil
+			},
+			GetOrganisationFunc: func(ctx context.Context) (*api.Organisation, error) {
+				return &api.Organisation{Name: "Acme"}, nil
+			},
+		}
+	}
+}
+
+func TestRunLogin(t *testing.T) {
+	file := newTestFile(t)
+	var buf bytes.Buffer
+	formatter := output.NewFormatter(false)
+	formatter.SetWriter(&buf)
+
+	err := runLogin(context.Background(), formatter, file, loginOptions{profile: "work", apiURL: "http://localhost/graphql"}, strings.NewReader("good-key\n"), fakeLogin(t, "http://localhost/graphql"))
+	require.NoError(t, err)
+	assert.Equal(t, "Logged in to Acme as Ada Lovelace (ada@example.com). Saved as profile work.\n", buf.String())
+
+	saved, err := config.ReadFile(file.Path)
+	require.NoError(t, err)
+	key, _ := saved.ProfileGet("work", config.ProfileAPIKey)
+	assert.Equal(t, "good-key", key)
+	url, _ := saved.ProfileGet("work", config.ProfileAPIURL)
+	assert.Equal(t, "http://localhost/graphql", url)
+	active, _ := saved.Get(config.KeyProfile)
+	assert.Equal(t, "work", active)
+}
+
+func TestRunLogin_BadKey(t *testing.T) {
+	file := newTestFile(t)
+	formatter := output.NewFormatter(false)
+
+	err := runLogin(context.Background(), formatter, file, loginOptions{profile: "work"}, strings.NewReader("bad-key"), fakeLogin(t, ""))
+	assert.ErrorIs(t, err, api.ErrUnauthenticated)
+	assert.Empty(t, file.Profiles(), "nothing is saved")
+
+	err = runLogin(context.Background(), formatter, file, loginOptions{profile: "work"}, strings.NewReader("\n"), fakeLogin(t, ""))
+	assert.EqualError(t, err, "no API key given")
+}
+
+func TestRunSwitchAndLogout(t *testing.T) {
+	file := newTestFile(t)
+	file.ProfileSet("work", config.ProfileAPIKey, "work-key")
+	file.ProfileSet("home", config.ProfileAPIKey, "home-key")
+	file.Set(config.KeyProfile, "work")
+	t.Setenv(config.EnvProfile, "")
+	t.Setenv(config.EnvAPIKey, "")
+
+	var buf bytes.Buffer
+	formatter := output.NewFormatter(false)
+	formatter.SetWriter(&buf)
+
+	require.NoError(t, runSwitch(formatter, file, "home"))
+	assert.Equal(t, "Switched to profile home.\n", buf.String())
+	active, _ := file.Get(config.KeyProfile)
+	assert.Equal(t, "home", active)
+
+	err := runSwitch(formatter, file, "play")
+	var flagErr *cmdutil.FlagError
+	require.ErrorAs(t, err, &flagErr)
+	assert.EqualError(t, err, `unknown profile "play": choose from work, home`)
+
+	buf.Reset()
+	formatter = output.NewFormatter(true)
+	formatter.SetWriter(&buf)
+	require.NoError(t, runLogout(formatter, file, "home"))
+	var result logoutResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, logoutResult{Profile: "home", Remaining: []string{"work"}}, result)
+	_, ok := file.Get(config.KeyProfile)
+	assert.False(t, ok, "the active profile is gone")
+
+	assert.EqualError(t, runLogout(formatter, file, ""), "not logged in to a profile")
+}
+
+func TestRunLogin_CredentialHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell command as the helper")
+	}
+	file := newTestFile(t)
+	file.ProfileSet("work", config.ProfileAPIKey, "old-key")
+	formatter := output.NewFormatter(false)
+
+	opts := loginOptions{profile: "work", helper: "echo good-key"}
+	require.NoError(t, runLogin(context.Background(), formatter, file, opts, strings.NewReader(""), fakeLogin(t, "")))
+
+	helper, _ := file.ProfileGet("work", config.KeyCredentialHelper)
+	assert.Equal(t, "echo good-key", helper)
+	_, ok := file.ProfileGet("work", config.ProfileAPIKey)
+	assert.False(t, ok, "the key itself is not stored")
+}
+
+func TestLoginEndpoint(t *testing.T) {
+	file := newTestFile(t)
+	file.ProfileSet("work", config.ProfileAPIURL, "https://work.example.com/graphql")
+
+	t.Setenv(config.EnvAPIURL, "")
+	assert.Equal(t, "https://flag.example.com/graphql", loginEndpoint(file, "work", "https://flag.example.com/graphql"))
+	assert.Equal(t, "https://work.example.com/graphql", loginEndpoint(file, "work", ""))
+	assert.Equal(t, "", loginEndpoint(file, "default", ""))
+	file.Set(config.KeyAPIURL, "https://top.example.com/graphql")
+	assert.Equal(t, "https://top.example.com/graphql", loginEndpoint(file, "default", ""))
+	assert.Equal(t, "https://work.example.com/graphql", loginEndpoint(file, "work", ""))
+
+	// A key for a server named in the environment, such as lnr dev
+	// fake-server, is checked there rather than against Linear
+	t.Setenv(config.EnvAPIURL, "http://127.0.0.1:4000/graphql")
+	assert.Equal(t, "http://127.0.0.1:4000/graphql", loginEndpoint(file, "default", ""))
+	assert.Equal(t, "http://127.0.0.1:4000/graphql", loginEndpoint(file, "work", ""))
+	assert.Equal(t, "https://flag.example.com/graphql", loginEndpoint(file, "work", "https://flag.example.com/graphql"))
+}
+
+func TestRunOAuthLogin(t *testing.T) {
+	auth := oauthtest.NewServer("lnr-test")
+	defer auth.Close()
+	graphql := httptest.NewServer(auth.Protect(&apitest.Handler{Workspace: apitest.NewDemoWorkspace()}))
+	defer graphql.Close()
+
+	file := newTestFile(t)
+	file.ProfileSet("work", config.ProfileAPIKey, "old-key")
+	var buf bytes.Buffer
+	formatter := output.NewFormatter(false)
+	formatter.SetWriter(&buf)
+
+	app := &oauth.App{ClientID: "lnr-test", AuthorizeURL: auth.AuthorizeURL, TokenURL: auth.TokenURL, Scopes: oauth.DefaultScopes}
+	opts := loginOptions{profile: "work", apiURL: graphql.URL, oauth: app}
+	require.NoError(t, runOAuthLogin(context.Background(), formatter, file, opts, oauthtest.Approve, oauthClient(nil)))
+	assert.Contains(t, buf.String(), "Saved as profile work.")
+
+	saved, err := config.ReadFile(file.Path)
+	require.NoError(t, err)
+	o := config.ReadOAuth(saved, "work")
+	require.NotNil(t, o)
+	assert.Equal(t, "lnr-test", o.ClientID)
+	assert.Equal(t, auth.TokenURL, o.TokenURL)
+	assert.True(t, auth.Valid(o.AccessToken))
+	assert.NotEmpty(t, o.RefreshToken)
+	_, ok := saved.ProfileGet("work", config.ProfileAPIKey)
+	assert.False(t, ok, "the old key is replaced")
+
+	// Logging in with a key again drops the OAuth tokens
+	require.NoError(t, runLogin(context.Background(), formatter, file, loginOptions{profile: "work"}, strings.NewReader("good-key"), fakeLogin(t, "")))
+	assert.Nil(t, config.ReadOAuth(file, "work"))
+}
+
+func TestRunOAuthLogin_Denied(t *testing.T) {
+	auth := oauthtest.NewServer("lnr-test")
+	defer auth.Close()
+
+	file := newTestFile(t)
+	app := &oauth.App{ClientID: "someone-else", AuthorizeURL: auth.AuthorizeURL, TokenURL: auth.TokenURL}
+	err := runOAuthLogin(context.Background(), output.NewFormatter(false), file, loginOptions{profile: "work", oauth: app}, oauthtest.Approve, oauthClient(nil))
+	assert.ErrorContains(t, err, "failed to log in")
+	assert.Empty(t, file.Profiles(), "nothing is saved")
+}
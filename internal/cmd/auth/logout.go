@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+// logoutResult is the JSON form of auth logout
+type logoutResult struct {
+	Profile string `json:"profile"`
+	// Remaining are the profiles left to switch to
+	Remaining []string `json:"remaining"`
+}
+
+// NewCmdLogout creates the auth logout command
+func NewCmdLogout() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Remove a saved profile",
+		Long:  "Remove the active profile, or the one given with --profile, with its API key and settings.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			settings, err := config.LoadSettingsWith(config.OverridesFrom(cmd.Context()))
+			if err != nil {
+				return err
+			}
+			return runLogout(output.NewFormatter(jsonOutput), settings.User, settings.Profile())
+		},
+	}
+
+	return cmd
+}
+
+func runLogout(formatter *output.Formatter, file *config.File, profile string) error {
+	if profile == "" {
+		return errors.New("not logged in to a profile")
+	}
+	if !file.RemoveProfile(profile) {
+		return fmt.Errorf("unknown profile %q", profile)
+	}
+	if active, _ := file.Get(config.KeyProfile); active == profile {
+		file.Unset(config.KeyProfile)
+	}
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	result := logoutResult{Profile: profile, Remaining: file.Profiles()}
+	if result.Remaining == nil {
+		result.Remaining = []string{}
+	}
+	text := fmt.Sprintf("Logged out of profile %s.\n", profile)
+	if len(result.Remaining) > 0 {
+		text += fmt.Sprintf("Other profiles: %s. Use lnr auth switch to pick one.\n", strings.Join(result.Remaining, ", "))
+	}
+	if os.Getenv(config.EnvAPIKey) != "" {
+		text += config.EnvAPIKey + " is still set and will be used.\n"
+	}
+	return formatter.PrintText(text, result)
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stustirling/lnr/internal/config"
@@ -17,20 +18,23 @@ func NewCmdStatus() *cobra.Command {
 		Long:  "Verify your Linear API key and display account information.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runStatus(jsonOutput)
+			return runStatus(cmd.Context(), jsonOutput)
 		},
 	}
 
 	return cmd
 }
 
-func runStatus(jsonOutput bool) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runStatus(ctx context.Context, jsonOutput bool) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		if err == config.ErrNoAPIKey {
 			fmt.Println("Not authenticated.")
 			fmt.Println("")
-			fmt.Println("To authenticate, set your Linear API key:")
+			fmt.Println("To authenticate, save your Linear API key as a profile:")
+			fmt.Println("  lnr auth login")
+			fmt.Println("")
+			fmt.Println("or set it in the environment:")
 			fmt.Println("  export LINEAR_API_KEY=your_api_key")
 			fmt.Println("")
 			fmt.Println("You can create an API key at:")
@@ -43,7 +47,7 @@ func runStatus(jsonOutput bool) error {
 }
 
 func runStatusWithFactory(factory *cmdutil.Factory, jsonOutput bool) error {
-	ctx := context.Background()
+	ctx := factory.Context
 
 	// Get current user
 	user, err := factory.Client.GetViewer(ctx)
@@ -57,17 +61,35 @@ func runStatusWithFactory(factory *cmdutil.Factory, jsonOutput bool) error {
 		return fmt.Errorf("failed to get organisation: %w", err)
 	}
 
+	// Known once a request has been made
+	rateLimit := factory.Client.RateLimit()
+
 	if jsonOutput {
 		data := map[string]interface{}{
 			"authenticated": true,
 			"user":          user,
 			"organisation":  org,
 		}
+		if profile := factory.Config.Profile; profile != "" {
+			data["profile"] = profile
+		}
+		if source := factory.Config.CredentialSource; source != "" {
+			data["credentialSource"] = source
+		}
+		if rateLimit != nil {
+			data["rateLimit"] = rateLimit
+		}
 		return factory.Formatter.PrintJSON(data)
 	}
 
 	fmt.Println("Authenticated!")
 	fmt.Println("")
+	if profile := factory.Config.Profile; profile != "" {
+		fmt.Printf("Profile:      %s\n", profile)
+	}
+	if source := factory.Config.CredentialSource; source != "" {
+		fmt.Printf("Credentials:  %s\n", source)
+	}
 	fmt.Printf("User:         %s (%s)\n", user.Name, user.Email)
 	fmt.Printf("Organisation: %s\n", org.Name)
 	fmt.Printf("Users:        %d\n", org.UserCount)
@@ -76,5 +98,25 @@ func runStatusWithFactory(factory *cmdutil.Factory, jsonOutput bool) error {
 		fmt.Println("Role:         Admin")
 	}
 
+	if rateLimit != nil {
+		now := time.Now()
+		if rateLimit.RequestsLimit > 0 {
+			fmt.Printf("Requests:     %s\n", formatQuota(rateLimit.RequestsRemaining, rateLimit.RequestsLimit, rateLimit.RequestsReset, now))
+		}
+		if rateLimit.ComplexityLimit > 0 {
+			fmt.Printf("Complexity:   %s\n", formatQuota(rateLimit.ComplexityRemaining, rateLimit.ComplexityLimit, rateLimit.ComplexityReset, now))
+		}
+	}
+
 	return nil
 }
+
+// formatQuota describes how much of a rate limit is left, e.g.
+// "1498 of 1500 left, resets in 59m"
+func formatQuota(remaining, limit int, reset, now time.Time) string {
+	quota := fmt.Sprintf("%d of %d left", remaining, limit)
+	if reset.After(now) {
+		quota += fmt.Sprintf(", resets in %s", reset.Sub(now).Round(time.Second))
+	}
+	return quota
+}
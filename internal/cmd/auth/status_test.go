@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -120,3 +121,32 @@ func TestRunStatusWithFactory_JSONOutput(t *testing.T) {
 	assert.Contains(t, output, `"user"`)
 	assert.Contains(t, output, `"organisation"`)
 }
+
+func TestRunStatusWithFactory_RateLimit(t *testing.T) {
+	mockClient := &api.MockClient{
+		GetViewerFunc: func(ctx context.Context) (*api.User, error) {
+			return &api.User{Name: "Test User"}, nil
+		},
+		GetOrganisationFunc: func(ctx context.Context) (*api.Organisation, error) {
+			return &api.Organisation{Name: "Test Org"}, nil
+		},
+		RateLimitFunc: func() *api.RateLimit {
+			return &api.RateLimit{RequestsLimit: 1500, RequestsRemaining: 1498}
+		},
+	}
+
+	factory := cmdutil.NewFactoryWithClient(mockClient, true)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	require.NoError(t, runStatusWithFactory(factory, true))
+	assert.Contains(t, buf.String(), `"rateLimit"`)
+	assert.Contains(t, buf.String(), `"requestsRemaining": 1498`)
+}
+
+func TestFormatQuota(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "1498 of 1500 left, resets in 59m30s", formatQuota(1498, 1500, now.Add(59*time.Minute+30*time.Second), now))
+	assert.Equal(t, "0 of 1500 left", formatQuota(0, 1500, now.Add(-time.Minute), now))
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdSwitch creates the auth switch command
+func NewCmdSwitch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "switch <profile>",
+		Short: "Change the active profile",
+		Long: `Make a profile saved by lnr auth login the active one. --profile and
+LNR_PROFILE choose a profile for a single command instead.`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			file, err := readUserConfig()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return file.Profiles(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			file, err := readUserConfig()
+			if err != nil {
+				return err
+			}
+			return runSwitch(output.NewFormatter(jsonOutput), file, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runSwitch(formatter *output.Formatter, file *config.File, profile string) error {
+	if !file.HasProfile(profile) {
+		profiles := file.Profiles()
+		if len(profiles) == 0 {
+			return &cmdutil.FlagError{Err: fmt.Errorf("unknown profile %q: no profiles saved yet, run lnr auth login", profile)}
+		}
+		return &cmdutil.FlagError{Err: fmt.Errorf("unknown profile %q: choose from %s", profile, strings.Join(profiles, ", "))}
+	}
+
+	file.Set(config.KeyProfile, profile)
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	text := fmt.Sprintf("Switched to profile %s.\n", profile)
+	if env := os.Getenv(config.EnvProfile); env != "" && env != profile {
+		text += fmt.Sprintf("%s=%s still takes precedence.\n", config.EnvProfile, env)
+	}
+	return formatter.PrintText(text, map[string]string{"profile": profile})
+}
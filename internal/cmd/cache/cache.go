@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCache creates the cache parent command
+func NewCmdCache() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache",
+		Long: `Manage the on-disk cache of teams, users, labels and workflow states.
+
+lnr caches these slow-changing lookups so commands do not refetch them on
+every run. Entries expire on their own and are cleared after a mutation sent
+with lnr api, which may change them. A name missing from the cache is
+looked up again before it is reported as not found. Pass --no-cache or set LNR_NO_CACHE=1 to bypass the cache.`,
+	}
+
+	cmd.AddCommand(NewCmdClear())
+	cmd.AddCommand(NewCmdStatus())
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/cache"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+func TestRunStatus(t *testing.T) {
+	store := cache.NewStore(t.TempDir())
+	require.NoError(t, store.Set(cache.KindTeams, "teams", []string{"ENG"}))
+
+	var buf bytes.Buffer
+	formatter := output.NewFormatter(false)
+	formatter.SetWriter(&buf)
+	require.NoError(t, runStatus(formatter, store, time.Now()))
+	assert.Contains(t, buf.String(), "Cache: "+store.Dir())
+	assert.Contains(t, buf.String(), "teams")
+	assert.Contains(t, buf.String(), "24h0m0s")
+
+	buf.Reset()
+	formatter = output.NewFormatter(true)
+	formatter.SetWriter(&buf)
+	require.NoError(t, runStatus(formatter, store, time.Now()))
+	var result statusResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, 1, result.Entries[0].Entries)
+}
+
+func TestRunClear(t *testing.T) {
+	store := cache.NewStore(t.TempDir())
+	require.NoError(t, store.Set(cache.KindTeams, "teams", []string{"ENG"}))
+
+	var buf bytes.Buffer
+	formatter := output.NewFormatter(false)
+	formatter.SetWriter(&buf)
+	require.NoError(t, runClear(formatter, store))
+	assert.Equal(t, "Cache cleared.\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, runStatus(formatter, store, time.Now()))
+	assert.Contains(t, buf.String(), "No cached entries.")
+}
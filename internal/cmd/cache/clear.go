@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/cache"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+// NewCmdClear creates the cache clear command
+func NewCmdClear() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Clear the cache",
+		Long:  "Remove every cached entry, for all workspaces.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			dir, err := cache.DefaultDir()
+			if err != nil {
+				return err
+			}
+			return runClear(output.NewFormatter(jsonOutput), cache.NewStore(dir))
+		},
+	}
+
+	return cmd
+}
+
+func runClear(formatter *output.Formatter, store *cache.Store) error {
+	if err := store.Clear(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return formatter.PrintText("Cache cleared.\n", map[string]interface{}{"cleared": true})
+}
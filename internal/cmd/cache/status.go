@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/cache"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+// statusResult is the JSON form of the cache status
+type statusResult struct {
+	Dir     string       `json:"dir"`
+	Entries []cache.Stat `json:"entries"`
+}
+
+// NewCmdStatus creates the cache status command
+func NewCmdStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show what is cached",
+		Long:  "Show the cache directory and the cached entries of each kind, across all workspaces.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			dir, err := cache.DefaultDir()
+			if err != nil {
+				return err
+			}
+			return runStatus(output.NewFormatter(jsonOutput), cache.NewStore(dir), time.Now())
+		},
+	}
+
+	return cmd
+}
+
+func runStatus(formatter *output.Formatter, store *cache.Store, now time.Time) error {
+	stats, err := store.Stats(cache.DefaultTTLs)
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	if formatter.IsJSON() {
+		return formatter.PrintJSON(statusResult{Dir: store.Dir(), Entries: stats})
+	}
+
+	if err := formatter.PrintText("Cache: "+store.Dir()+"\n\n", nil); err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		return formatter.PrintText("No cached entries.\n", nil)
+	}
+
+	headers := []string{"KIND", "ENTRIES", "EXPIRED", "SIZE", "TTL", "OLDEST"}
+	rows := make([][]string, len(stats))
+	for i, st := range stats {
+		rows[i] = []string{
+			st.Kind,
+			strconv.Itoa(st.Entries),
+			strconv.Itoa(st.Expired),
+			formatBytes(st.Bytes),
+			cache.DefaultTTLs[st.Kind].String(),
+			now.Sub(st.Oldest).Truncate(time.Second).String() + " ago",
+		}
+	}
+	formatter.PrintTable(headers, rows)
+	return nil
+}
+
+// formatBytes formats n as B or KB
+func formatBytes(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
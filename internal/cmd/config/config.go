@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdConfig creates the config parent command
+func NewCmdConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage settings",
+		Long: `Manage lnr settings.
+
+Settings live in the user config file, $XDG_CONFIG_HOME/lnr/config.yaml or
+~/.config/lnr/config.yaml, and a repository can override them with a
+.lnr.yaml file in its root. Where a setting is given in more than one place,
+the first of these wins:
+
+  1. a command-line flag, such as --team or --limit
+  2. an environment variable, such as LNR_TEAM
+  3. .lnr.yaml in the working directory or one of its parents
+  4. the user config file
+  5. the built-in default
+
+Settings:
+` + keyHelp(),
+	}
+
+	cmd.AddCommand(NewCmdGet())
+	cmd.AddCommand(NewCmdSet())
+	cmd.AddCommand(NewCmdUnset())
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdEdit())
+
+	return cmd
+}
+
+// keyHelp lists the settings for help text
+func keyHelp() string {
+	width := 0
+	for _, k := range config.Keys {
+		width = max(width, len(k.Name))
+	}
+	var b strings.Builder
+	for _, k := range config.Keys {
+		fmt.Fprintf(&b, "  %-*s  %s (%s)\n", width, k.Name, k.Description, k.Env)
+	}
+	return b.String()
+}
+
+// lookupKey returns the setting called name, or a usage error
+func lookupKey(name string) (config.Key, error) {
+	k, ok := config.LookupKey(name)
+	if !ok {
+		return k, &cmdutil.FlagError{Err: fmt.Errorf("unknown setting %q; run lnr config --help to see them", name)}
+	}
+	return k, nil
+}
+
+// completeKeys completes setting names
+func completeKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, len(config.Keys))
+	for i, k := range config.Keys {
+		names[i] = k.Name + "\t" + k.Description
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// newTestSettings points lnr at an empty config home and working directory
+func newTestSettings(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv(config.EnvConfigHome, home)
+	for _, k := range config.Keys {
+		t.Setenv(k.Env, "")
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	path, err := config.UserConfigPath()
+	require.NoError(t, err)
+	return path
+}
+
+func newFormatter(jsonOutput bool) (*output.Formatter, *bytes.Buffer) {
+	var buf bytes.Buffer
+	formatter := output.NewFormatter(jsonOutput)
+	formatter.SetWriter(&buf)
+	return formatter, &buf
+}
+
+func TestRunSetGetUnset(t *testing.T) {
+	path := newTestSettings(t)
+
+	file, err := config.ReadFile(path)
+	require.NoError(t, err)
+	formatter, buf := newFormatter(false)
+	require.NoError(t, runSet(formatter, file, "", "team", "ENG"))
+	assert.Equal(t, `Set team to "ENG" in `+path+"\n", buf.String())
+
+	settings, err := config.LoadSettings()
+	require.NoError(t, err)
+	formatter, buf = newFormatter(false)
+	require.NoError(t, runGet(formatter, settings, "team"))
+	assert.Equal(t, "ENG\n", buf.String())
+
+	formatter, buf = newFormatter(true)
+	require.NoError(t, runGet(formatter, settings, "team"))
+	var value config.Value
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &value))
+	assert.Equal(t, config.Value{Key: "team", Value: "ENG", Source: path}, value)
+
+	file, err = config.ReadFile(path)
+	require.NoError(t, err)
+	formatter, buf = newFormatter(false)
+	require.NoError(t, runUnset(formatter, file, "", "team"))
+	assert.Equal(t, "Unset team in "+path+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, runUnset(formatter, file, "", "team"))
+	assert.Equal(t, "team is not set in "+path+"\n", buf.String())
+}
+
+func TestRunSet_Invalid(t *testing.T) {
+	path := newTestSettings(t)
+	file, err := config.ReadFile(path)
+	require.NoError(t, err)
+	formatter, _ := newFormatter(false)
+
+	err = runSet(formatter, file, "", "colour", "always")
+	var flagErr *cmdutil.FlagError
+	require.ErrorAs(t, err, &flagErr)
+	assert.ErrorContains(t, err, `unknown setting "colour"`)
+
+	err = runSet(formatter, file, "", "limit", "lots")
+	require.ErrorAs(t, err, &flagErr)
+	assert.ErrorContains(t, err, `invalid limit "lots"`)
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "nothing is written")
+}
+
+func TestRunList(t *testing.T) {
+	path := newTestSettings(t)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("output: json\n"), 0o600))
+	t.Setenv("LNR_PAGER", "less")
+
+	settings, err := config.LoadSettings()
+	require.NoError(t, err)
+	formatter, buf := newFormatter(true)
+	require.NoError(t, runList(formatter, settings, false))
+
+	var values map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &values))
+	assert.Equal(t, "json", values["output"])
+	assert.Equal(t, "less", values["pager"])
+	assert.Equal(t, "auto", values["color"])
+	assert.Equal(t, "", values["team"])
+}
+
+func TestRunList_ShowOrigin(t *testing.T) {
+	path := newTestSettings(t)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("team: OPS\nlimit: 10\n"), 0o600))
+
+	// The context file is found from a subdirectory of the repository
+	repo, err := os.Getwd()
+	require.NoError(t, err)
+	repoConfig := filepath.Join(repo, config.RepoConfigName)
+	require.NoError(t, os.WriteFile(repoConfig, []byte("team: ENG\nproject: Public launch\nlabels: [cli, backend]\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "cmd", "lnr"), 0o755))
+	require.NoError(t, os.Chdir(filepath.Join(repo, "cmd", "lnr")))
+	t.Setenv("LNR_PAGER", "less")
+
+	settings, err := config.LoadSettings()
+	require.NoError(t, err)
+	formatter, buf := newFormatter(true)
+	require.NoError(t, runList(formatter, settings, true))
+
+	var values []config.Value
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &values))
+	origins := make(map[string]config.Value, len(values))
+	for _, v := range values {
+		origins[v.Key] = v
+	}
+	assert.Equal(t, config.Value{Key: "team", Value: "ENG", Source: repoConfig}, origins["team"])
+	assert.Equal(t, config.Value{Key: "project", Value: "Public launch", Source: repoConfig}, origins["project"])
+	assert.Equal(t, config.Value{Key: "labels", Value: "cli,backend", Source: repoConfig}, origins["labels"])
+	assert.Equal(t, config.Value{Key: "limit", Value: "10", Source: path}, origins["limit"])
+	assert.Equal(t, config.Value{Key: "pager", Value: "less", Source: "LNR_PAGER"}, origins["pager"])
+	assert.Equal(t, config.Value{Key: "branch", Value: "{identifier}-{title}", Source: "default"}, origins["branch"])
+
+	formatter, buf = newFormatter(false)
+	require.NoError(t, runList(formatter, settings, true))
+	assert.Contains(t, buf.String(), "ORIGIN")
+	assert.Regexp(t, `team +ENG +`+regexp.QuoteMeta(repoConfig), buf.String())
+}
+
+func TestRunEdit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+	path := newTestSettings(t)
+
+	// The fake editor sets a bad limit
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho 'limit: 0' >> \"$1\"\n"), 0o755))
+	t.Setenv(config.EnvEditor, script)
+
+	err := runEdit(path)
+	assert.ErrorContains(t, err, `invalid limit "0"`)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# team:\n")
+	assert.Contains(t, string(data), "# color: auto\n")
+	assert.Contains(t, string(data), "limit: 0\n")
+}
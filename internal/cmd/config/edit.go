@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/editor"
+)
+
+// NewCmdEdit creates the config edit command
+func NewCmdEdit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the user config file",
+		Long:  "Open the user config file in your editor, creating it with every setting commented out if it does not exist.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.UserConfigPath()
+			if err != nil {
+				return err
+			}
+			return runEdit(path)
+		},
+	}
+
+	return cmd
+}
+
+func runEdit(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := writeTemplate(path); err != nil {
+			return err
+		}
+	}
+
+	if err := editor.Open(path); err != nil {
+		return err
+	}
+
+	// Check the result, so mistakes show up now rather than on the next
+	// command
+	file, err := config.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for _, k := range config.Keys {
+		if value, ok := file.Get(k.Name); ok {
+			if err := k.Validate(value); err != nil {
+				return fmt.Errorf("%s: %w; run lnr config edit to fix it", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeTemplate creates the config file with every setting commented out
+func writeTemplate(path string) error {
+	var b strings.Builder
+	b.WriteString("# lnr settings. Uncomment a line to change a setting; see lnr config --help.\n")
+	for _, k := range config.Keys {
+		line := strings.TrimSpace(fmt.Sprintf("# %s: %s", k.Name, k.Default))
+		fmt.Fprintf(&b, "\n# %s\n%s\n", k.Description, line)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+// NewCmdGet creates the config get command
+func NewCmdGet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print a setting",
+		Long:              "Print the value a setting has here, wherever it comes from.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			settings, err := config.LoadSettingsWith(config.OverridesFrom(cmd.Context()))
+			if err != nil {
+				return err
+			}
+			return runGet(output.NewFormatter(jsonOutput), settings, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runGet(formatter *output.Formatter, settings *config.Settings, name string) error {
+	if _, err := lookupKey(name); err != nil {
+		return err
+	}
+	value := settings.Get(name)
+	return formatter.PrintText(value.Value+"\n", value)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+// NewCmdList creates the config list command
+func NewCmdList() *cobra.Command {
+	var showOrigin bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List settings",
+		Long: `List every setting with the value it has here. Empty values are unset and
+have no default.
+
+--show-origin adds where each value came from: an environment variable, the
+repository's .lnr.yaml, the user config file or its active profile, or the
+default.`,
+		Example: `  lnr config list
+  lnr config list --show-origin`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			settings, err := config.LoadSettingsWith(config.OverridesFrom(cmd.Context()))
+			if err != nil {
+				return err
+			}
+			return runList(output.NewFormatter(jsonOutput), settings, showOrigin)
+		},
+	}
+
+	cmd.Flags().BoolVar(&showOrigin, "show-origin", false, "Show where each value came from")
+
+	return cmd
+}
+
+func runList(formatter *output.Formatter, settings *config.Settings, showOrigin bool) error {
+	values := settings.All()
+
+	if showOrigin {
+		rows := make([][]string, len(values))
+		for i, v := range values {
+			rows[i] = []string{v.Key, v.Value, v.Source}
+		}
+		return formatter.Print([]string{"KEY", "VALUE", "ORIGIN"}, rows, values)
+	}
+
+	jsonData := make(map[string]string, len(values))
+	rows := make([][]string, len(values))
+	for i, v := range values {
+		jsonData[v.Key] = v.Value
+		rows[i] = []string{v.Key, v.Value}
+	}
+	return formatter.Print([]string{"KEY", "VALUE"}, rows, jsonData)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdSet creates the config set command
+func NewCmdSet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting",
+		Long: `Change a setting in the user config file. With --profile or LNR_PROFILE,
+change it for that profile only.`,
+		Example: `  lnr config set team ENG
+  lnr config set pager "less -S"
+  lnr config set team OPS --profile work`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			file, err := readUserConfig()
+			if err != nil {
+				return err
+			}
+			return runSet(output.NewFormatter(jsonOutput), file, config.ProfileOverride(cmd.Context()), args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func runSet(formatter *output.Formatter, file *config.File, profile, name, value string) error {
+	k, err := lookupKey(name)
+	if err != nil {
+		return err
+	}
+	if err := k.Validate(value); err != nil {
+		return &cmdutil.FlagError{Err: err}
+	}
+
+	where := file.Path
+	if profile != "" {
+		if name == config.KeyProfile {
+			return &cmdutil.FlagError{Err: errors.New("a profile cannot choose another profile; use lnr auth switch")}
+		}
+		if !file.HasProfile(profile) {
+			return fmt.Errorf("unknown profile %q: run lnr auth login --profile %s", profile, profile)
+		}
+		file.ProfileSet(profile, name, value)
+		where = fmt.Sprintf("%s (profile %s)", file.Path, profile)
+	} else {
+		file.Set(name, value)
+	}
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("failed to save setting: %w", err)
+	}
+	return formatter.PrintText(fmt.Sprintf("Set %s to %q in %s\n", name, value, where),
+		config.Value{Key: name, Value: value, Source: where})
+}
+
+// readUserConfig reads the user config file without checking its values,
+// so a bad value can be fixed
+func readUserConfig() (*config.File, error) {
+	path, err := config.UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return config.ReadFile(path)
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/output"
+)
+
+// NewCmdUnset creates the config unset command
+func NewCmdUnset() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unset <key>",
+		Short:             "Remove a setting",
+		Long:              "Remove a setting from the user config file, or with --profile or LNR_PROFILE from that profile.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			file, err := readUserConfig()
+			if err != nil {
+				return err
+			}
+			return runUnset(output.NewFormatter(jsonOutput), file, config.ProfileOverride(cmd.Context()), args[0])
+		},
+	}
+
+	return cmd
+}
+
+// unsetResult is the JSON form of config unset
+type unsetResult struct {
+	Key     string `json:"key"`
+	File    string `json:"file"`
+	Profile string `json:"profile,omitempty"`
+	Removed bool   `json:"removed"`
+}
+
+func runUnset(formatter *output.Formatter, file *config.File, profile, name string) error {
+	result := unsetResult{Key: name, File: file.Path}
+
+	where := file.Path
+	var removed bool
+	if profile != "" {
+		result.Profile = profile
+		where = fmt.Sprintf("%s (profile %s)", file.Path, profile)
+		removed = file.ProfileUnset(profile, name)
+	} else {
+		removed = file.Unset(name)
+	}
+
+	// Keys lnr no longer knows about can still be removed
+	if !removed {
+		if _, err := lookupKey(name); err != nil {
+			return err
+		}
+		return formatter.PrintText(fmt.Sprintf("%s is not set in %s\n", name, where), result)
+	}
+	if err := file.Save(); err != nil {
+		return fmt.Errorf("failed to save setting: %w", err)
+	}
+	result.Removed = true
+	return formatter.PrintText(fmt.Sprintf("Unset %s in %s\n", name, where), result)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/config"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
@@ -14,27 +15,44 @@ import (
 // NewCmdActive creates the cycle active command
 func NewCmdActive() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "active <team-id>",
+		Use:   "active [team]",
 		Short: "Show active cycle",
-		Long:  "Show the currently active cycle for a team.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the currently active cycle for a team, given by key, name or ID.
+Without a team, the team setting is used (see lnr config).`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runActive(jsonOutput, args[0])
+			teamRef := ""
+			if len(args) > 0 {
+				teamRef = args[0]
+			}
+			return runActive(cmd.Context(), jsonOutput, teamRef)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
 	return cmd
 }
 
-func runActive(jsonOutput bool, teamID string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runActive(ctx context.Context, jsonOutput bool, teamRef string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	cycle, err := factory.Client.GetActiveCycle(ctx, teamID)
+	if teamRef == "" {
+		teamRef = factory.Config.Settings.Get(config.KeyTeam).Value
+	}
+	if teamRef == "" {
+		return &cmdutil.FlagError{Err: errors.New("no team given; pass one or set a default with lnr config set team <key>")}
+	}
+
+	team, err := factory.Resolver.Team(ctx, teamRef)
+	if err != nil {
+		return err
+	}
+
+	cycle, err := factory.Client.GetActiveCycle(ctx, team.ID)
 	if err != nil {
 		if errors.Is(err, api.ErrNoActiveCycle) {
 			fmt.Println("No active cycle for this team.")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
@@ -12,6 +13,8 @@ import (
 // NewCmdList creates the cycle list command
 func NewCmdList() *cobra.Command {
 	var teamID string
+	var limit int
+	var all bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -19,33 +22,42 @@ func NewCmdList() *cobra.Command {
 		Long:  "List all cycles in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			var teamPtr *string
+			opts := api.CycleListOptions{Limit: limit, All: all}
 			if teamID != "" {
-				teamPtr = &teamID
+				opts.TeamID = &teamID
 			}
-			return runList(jsonOutput, teamPtr)
+			return runList(cmd.Context(), jsonOutput, opts)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
-	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team ID")
+	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team key, name or ID")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of cycles to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all cycles, ignoring --limit")
 
 	return cmd
 }
 
-func runList(jsonOutput bool, teamID *string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.CycleListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	cycles, err := factory.Client.GetCycles(ctx, teamID)
+	opts.TeamID, err = factory.Resolver.TeamID(ctx, opts.TeamID)
 	if err != nil {
-		return fmt.Errorf("failed to list cycles: %w", err)
+		return err
+	}
+
+	cycles, listErr := factory.Client.GetCycles(ctx, opts)
+	if listErr != nil && len(cycles) == 0 {
+		return fmt.Errorf("failed to list cycles: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API limit is 50)
-	output.WarnIfTruncated(len(cycles), 50)
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(cycles), opts.Limit)
+	}
 
 	headers := []string{"NUMBER", "NAME", "PROGRESS", "TEAM"}
 	rows := make([][]string, len(cycles))
@@ -62,5 +74,8 @@ func runList(jsonOutput bool, teamID *string) error {
 		}
 	}
 
-	return factory.Formatter.Print(headers, rows, cycles)
+	if err := factory.Formatter.Print(headers, rows, cycles); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(cycles), "cycles")
 }
@@ -18,20 +18,20 @@ func NewCmdView() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runView(jsonOutput, args[0])
+			return runView(cmd.Context(), jsonOutput, args[0])
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
 	return cmd
 }
 
-func runView(jsonOutput bool, cycleID string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runView(ctx context.Context, jsonOutput bool, cycleID string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
 	cycle, err := factory.Client.GetCycle(ctx, cycleID)
 	if err != nil {
 		return fmt.Errorf("failed to get cycle: %w", err)
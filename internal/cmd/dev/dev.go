@@ -0,0 +1,19 @@
+package dev
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDev creates the hidden dev parent command
+func NewCmdDev() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "dev",
+		Short:  "Developer tools",
+		Long:   "Tools for working on lnr itself.",
+		Hidden: true,
+	}
+
+	cmd.AddCommand(NewCmdFakeServer())
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api/apitest"
+	"github.com/stustirling/lnr/internal/oauth/oauthtest"
+)
+
+// NewCmdFakeServer creates the dev fake-server command
+func NewCmdFakeServer() *cobra.Command {
+	var addr string
+	var opts oauthOptions
+
+	cmd := &cobra.Command{
+		Use:   "fake-server",
+		Short: "Serve a fake Linear API",
+		Long: `Serve an in-memory Linear workspace over GraphQL so the CLI can be
+run end-to-end without network access. The workspace starts with demo data
+and changes made through it are lost when the server stops.
+
+With --oauth-client-id, the server is also an OAuth app for lnr auth login
+--oauth, approving every authorization at once, and only accepts its access
+tokens. --oauth-token-ttl makes them expire quickly to try out refreshing.`,
+		Example: `  # Start the server, then point lnr at it from another shell
+  lnr dev fake-server --addr 127.0.0.1:8080
+  export LINEAR_API_URL=http://127.0.0.1:8080/graphql
+  export LINEAR_API_KEY=fake
+  lnr issue list
+
+  # Log in through the fake OAuth app instead of with a key
+  lnr dev fake-server --addr 127.0.0.1:8080 --oauth-client-id dev
+  lnr auth login --oauth --client-id dev --api-url http://127.0.0.1:8080/graphql \
+    --authorize-url http://127.0.0.1:8080/oauth/authorize --token-url http://127.0.0.1:8080/oauth/token`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Runs until Ctrl-C, or --timeout when given
+			return runFakeServer(cmd.Context(), os.Stdout, addr, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8080", "Address to listen on")
+	cmd.Flags().StringVar(&opts.clientID, "oauth-client-id", "", "Serve an OAuth app with this client ID and require its tokens")
+	cmd.Flags().DurationVar(&opts.tokenTTL, "oauth-token-ttl", time.Hour, "How long OAuth access tokens last")
+
+	return cmd
+}
+
+// oauthOptions configures the fake server's OAuth app
+type oauthOptions struct {
+	// clientID turns the OAuth app on when set
+	clientID string
+	tokenTTL time.Duration
+}
+
+func runFakeServer(ctx context.Context, out io.Writer, addr string, opts oauthOptions) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	var handler http.Handler = &apitest.Handler{Workspace: apitest.NewDemoWorkspace()}
+	if opts.clientID != "" {
+		app := oauthtest.NewHandler(opts.clientID)
+		app.AccessTTL = opts.tokenTTL
+		mux := http.NewServeMux()
+		mux.Handle(oauthtest.AuthorizePath, app)
+		mux.Handle(oauthtest.TokenPath, app)
+		mux.Handle("/", app.Protect(handler))
+		handler = mux
+	}
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	base := fmt.Sprintf("http://%s", listener.Addr())
+	endpoint := base + "/graphql"
+	fmt.Fprintf(out, "Serving a fake Linear API at %s\n\n", endpoint)
+	fmt.Fprintln(out, "To use it, run:")
+	if opts.clientID != "" {
+		fmt.Fprintf(out, "  lnr auth login --oauth --client-id %s --api-url %s \\\n", opts.clientID, endpoint)
+		fmt.Fprintf(out, "    --authorize-url %s --token-url %s\n", base+oauthtest.AuthorizePath, base+oauthtest.TokenPath)
+	} else {
+		fmt.Fprintf(out, "  export LINEAR_API_URL=%s\n", endpoint)
+		fmt.Fprintln(out, "  export LINEAR_API_KEY=fake")
+	}
+	fmt.Fprintln(out, "\nPress Ctrl+C to stop.")
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package dev
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFakeServer_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	err := runFakeServer(ctx, &out, "127.0.0.1:0", oauthOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "export LINEAR_API_URL=http://127.0.0.1:")
+	assert.Contains(t, out.String(), "/graphql")
+}
+
+func TestRunFakeServer_BadAddress(t *testing.T) {
+	err := runFakeServer(context.Background(), &bytes.Buffer{}, "not-an-address", oauthOptions{})
+	assert.ErrorContains(t, err, "failed to listen on not-an-address")
+}
+
+func TestRunFakeServer_OAuth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	err := runFakeServer(ctx, &out, "127.0.0.1:0", oauthOptions{clientID: "dev"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "lnr auth login --oauth --client-id dev")
+	assert.Contains(t, out.String(), "/oauth/token")
+	assert.NotContains(t, out.String(), "LINEAR_API_KEY")
+}
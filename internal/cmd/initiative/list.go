@@ -5,39 +5,48 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
 // NewCmdList creates the initiative list command
 func NewCmdList() *cobra.Command {
+	var limit int
+	var all bool
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List initiatives",
 		Long:  "List all initiatives in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runList(jsonOutput)
+			return runList(cmd.Context(), jsonOutput, api.ListOptions{Limit: limit, All: all})
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of initiatives to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all initiatives, ignoring --limit")
+
 	return cmd
 }
 
-func runList(jsonOutput bool) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.ListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	initiatives, err := factory.Client.GetInitiatives(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to list initiatives: %w", err)
+	initiatives, listErr := factory.Client.GetInitiatives(ctx, opts)
+	if listErr != nil && len(initiatives) == 0 {
+		return fmt.Errorf("failed to list initiatives: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API limit is 50)
-	output.WarnIfTruncated(len(initiatives), 50)
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(initiatives), opts.Limit)
+	}
 
 	headers := []string{"NAME", "OWNER", "TARGET DATE"}
 	rows := make([][]string, len(initiatives))
@@ -53,5 +62,8 @@ func runList(jsonOutput bool) error {
 		}
 	}
 
-	return factory.Formatter.Print(headers, rows, initiatives)
+	if err := factory.Formatter.Print(headers, rows, initiatives); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(initiatives), "initiatives")
 }
@@ -0,0 +1,100 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// maxTitleSlug is how much of the title a branch name keeps
+const maxTitleSlug = 50
+
+// branchResult is the JSON form of issue branch
+type branchResult struct {
+	Issue  string `json:"issue"`
+	Branch string `json:"branch"`
+}
+
+// NewCmdBranch creates the issue branch command
+func NewCmdBranch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branch <issue-id>",
+		Short: "Print a git branch name for an issue",
+		Long: `Print the name of a git branch for working on an issue, following the
+branch setting. A repository's .lnr.yaml usually sets it to match the
+repository's conventions; the default is {identifier}-{title}.
+
+The format can use {identifier} (eng-123), {number} (123), {team} (eng)
+and {title}, the title in lower case with words joined by hyphens.`,
+		Example: `  lnr issue branch ENG-123
+  git switch -c "$(lnr issue branch ENG-123)"
+  LNR_BRANCH="feature/{identifier}" lnr issue branch ENG-123`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runBranch(cmd.Context(), jsonOutput, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runBranch(ctx context.Context, jsonOutput bool, issueID string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runBranchWithFactory(factory, issueID, factory.Config.Settings.Get(config.KeyBranch).Value)
+}
+
+func runBranchWithFactory(factory *cmdutil.Factory, issueID, format string) error {
+	issue, err := factory.Client.GetIssue(factory.Context, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	branch := branchName(format, issue)
+	return factory.Formatter.PrintText(branch+"\n", branchResult{Issue: issue.Identifier, Branch: branch})
+}
+
+// branchName fills in the placeholders of a branch format, see
+// config.BranchFields
+func branchName(format string, issue *api.Issue) string {
+	identifier := strings.ToLower(issue.Identifier)
+	team, number, _ := strings.Cut(identifier, "-")
+	return strings.NewReplacer(
+		"{identifier}", identifier,
+		"{number}", number,
+		"{team}", team,
+		"{title}", slug(issue.Title, maxTitleSlug),
+	).Replace(format)
+}
+
+// slug lower-cases s and joins its words with hyphens, keeping whole words
+// up to max characters
+func slug(s string, max int) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if b.Len() > 0 && b.Len()+1+len(w) > max {
+			break
+		}
+		if b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(w)
+	}
+	// A single long word is cut short
+	if r := []rune(b.String()); len(r) > max {
+		return string(r[:max])
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package issue
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func TestBranchName(t *testing.T) {
+	issue := &api.Issue{Identifier: "ENG-123", Title: "Fix login redirect (SSO) — again!"}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"{identifier}-{title}", "eng-123-fix-login-redirect-sso-again"},
+		{"feature/{team}-{number}", "feature/eng-123"},
+		{"{identifier}", "eng-123"},
+		{"fix/{title}", "fix/fix-login-redirect-sso-again"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, branchName(tt.format, issue), tt.format)
+	}
+
+	for _, field := range config.BranchFields {
+		assert.NotContains(t, branchName("{"+field+"}", issue), "{", "{%s} is filled in", field)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "café-crème", slug("Café Crème", 50))
+	assert.Equal(t, "add-retries-to", slug("Add retries to the sync worker", 16), "whole words only")
+	assert.Equal(t, "supercalifragil", slug("Supercalifragilistic", 15))
+	assert.Equal(t, "", slug("!!!", 50))
+}
+
+func TestRunBranchWithFactory(t *testing.T) {
+	mockClient := &api.MockClient{
+		GetIssueFunc: issuesByIdentifier(&api.Issue{ID: "issue-1", Identifier: "ENG-1", Title: "Dark mode"}),
+	}
+
+	var buf bytes.Buffer
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	factory.Formatter.SetWriter(&buf)
+	require.NoError(t, runBranchWithFactory(factory, "ENG-1", "{identifier}-{title}"))
+	assert.Equal(t, "eng-1-dark-mode\n", buf.String())
+
+	buf.Reset()
+	factory = cmdutil.NewFactoryWithClient(mockClient, true)
+	factory.Formatter.SetWriter(&buf)
+	require.NoError(t, runBranchWithFactory(factory, "ENG-1", "{team}/{number}"))
+	var result branchResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, branchResult{Issue: "ENG-1", Branch: "eng/1"}, result)
+
+	err := runBranchWithFactory(factory, "ENG-999", "{identifier}")
+	assert.True(t, strings.HasPrefix(err.Error(), "failed to get issue"))
+}
@@ -0,0 +1,256 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// defaultBulkConcurrency is the number of updates sent at once. It is kept
+// low so a large batch doesn't run straight into Linear's rate limits.
+const defaultBulkConcurrency = 4
+
+// bulkEditOptions controls how a bulk edit is carried out
+type bulkEditOptions struct {
+	DryRun      bool
+	Yes         bool
+	Concurrency int
+	// Confirm asks whether to go ahead. It is nil when there is no terminal
+	// to ask on.
+	Confirm func(prompt string) (bool, error)
+}
+
+// bulkEditResult is the outcome of updating a single issue
+type bulkEditResult struct {
+	Issue   string `json:"issue"`
+	Title   string `json:"title"`
+	Updated bool   `json:"updated"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewCmdBulkEdit creates the issue bulk-edit command
+func NewCmdBulkEdit() *cobra.Command {
+	var flags listFlags
+	var changes editOptions
+	var opts bulkEditOptions
+
+	cmd := &cobra.Command{
+		Use:   "bulk-edit",
+		Short: "Edit every issue matching a filter",
+		Long: `Apply the same change to every issue matching the filters of lnr issue list.
+
+Every matching issue is edited, however many there are, unless --limit is
+given. The matching issues are listed first and nothing changes until you
+confirm, or pass --yes. Use --dry-run to see the issues without changing them.
+States, labels and cycles are looked up in each issue's own team.
+
+` + queryHelp,
+		Example: `  lnr issue bulk-edit -q 'label:q3 state:Backlog' --set-cycle next
+  lnr issue bulk-edit --team ENG --assignee alice@example.com --set-assignee @me --yes
+  lnr issue bulk-edit -q 'label:triage' --add-label bug --remove-label triage --dry-run
+  lnr issue bulk-edit -q 'label:sso' --set-parent ENG-10`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			listOpts, err := bulkListOptions(cmd, &flags)
+			if err != nil {
+				return err
+			}
+			if cmdutil.IsTerminal(os.Stdin) {
+				opts.Confirm = func(prompt string) (bool, error) {
+					return cmdutil.ConfirmContext(cmd.Context(), os.Stdin, os.Stderr, prompt)
+				}
+			}
+			return runBulkEdit(cmd.Context(), jsonOutput, listOpts, changes, opts)
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().StringVar(&changes.State, "set-state", "", "Move to a workflow state by name or ID")
+	cmd.Flags().StringVar(&changes.Assignee, "set-assignee", "", "Assign to a user by email, name, @me or ID, or none to unassign")
+	cmd.Flags().StringVar(&changes.Priority, "set-priority", "", "Set priority: urgent, high, medium, low, none or 0-4")
+	cmd.Flags().StringVar(&changes.Estimate, "set-estimate", "", "Set the estimate in points, or none to clear it")
+	cmd.Flags().StringVar(&changes.Due, "set-due", "", "Set the due date (YYYY-MM-DD), or none to clear it")
+	cmd.Flags().StringVar(&changes.Project, "set-project", "", "Move to a project by name, slug or ID, or none")
+	cmd.Flags().StringVar(&changes.Cycle, "set-cycle", "", "Move to a cycle by number, current, next, previous or ID, or none")
+	cmd.Flags().StringVar(&changes.Parent, "set-parent", "", "Make sub-issues of a parent issue (e.g., ENG-10), or none to make them top-level")
+	cmd.Flags().StringSliceVar(&changes.AddLabels, "add-label", nil, "Add a label by name or ID (repeatable)")
+	cmd.Flags().StringSliceVar(&changes.RemoveLabels, "remove-label", nil, "Remove a label by name or ID (repeatable)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the matching issues without changing them")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Update without asking for confirmation")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", defaultBulkConcurrency, "Number of issues to update at once")
+
+	return cmd
+}
+
+// bulkListOptions converts the filter flags into list options. Unlike
+// issue list, every match is fetched unless --limit is passed: a batch cut
+// short by the default limit or the limit setting would leave the rest of
+// the matches unchanged.
+func bulkListOptions(cmd *cobra.Command, flags *listFlags) (api.IssueListOptions, error) {
+	opts, err := flags.options()
+	if err != nil {
+		return opts, err
+	}
+	if !cmd.Flags().Changed("limit") {
+		opts.All = true
+	}
+	return opts, nil
+}
+
+func runBulkEdit(ctx context.Context, jsonOutput bool, listOpts api.IssueListOptions, changes editOptions, opts bulkEditOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	if err := resolveListOptions(ctx, factory, &listOpts); err != nil {
+		return err
+	}
+	return runBulkEditWithFactory(factory, listOpts, changes, opts)
+}
+
+func runBulkEditWithFactory(factory *cmdutil.Factory, listOpts api.IssueListOptions, changes editOptions, opts bulkEditOptions) error {
+	ctx := factory.Context
+
+	if opts.Concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	if !hasChanges(changes) {
+		return errors.New("nothing to update (see lnr issue bulk-edit --help for the fields that can be changed)")
+	}
+
+	issues, err := factory.Client.GetIssues(ctx, listOpts)
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+	if !listOpts.All {
+		output.WarnIfTruncated(len(issues), listOpts.Limit)
+	}
+
+	if len(issues) == 0 {
+		return factory.Formatter.PrintText("No issues match the filters.\n", []bulkEditResult{})
+	}
+
+	// Resolve the changes for every issue before updating any, so a typo in
+	// a state or label name doesn't leave the batch half done. The resolver
+	// caches its lookups, including each team's cycles, and the parent is
+	// looked up here once, so this costs a handful of requests in total.
+	if changes.Parent != "" && !isNone(changes.Parent) {
+		if changes.Parent, err = resolveParent(ctx, factory, changes.Parent); err != nil {
+			return err
+		}
+	}
+	inputs := make([]*api.IssueUpdateInput, len(issues))
+	for i := range issues {
+		input, err := buildUpdateInput(ctx, factory, &issues[i], changes)
+		if err != nil {
+			return fmt.Errorf("%s: %w", issues[i].Identifier, err)
+		}
+		inputs[i] = input
+	}
+
+	if opts.DryRun {
+		headers, rows := issueRows(issues)
+		if err := factory.Formatter.Print(headers, rows, issues); err != nil {
+			return err
+		}
+		if !factory.Formatter.IsJSON() {
+			_, _ = fmt.Fprintf(os.Stderr, "Dry run: %d issues would be updated.\n", len(issues))
+		}
+		return nil
+	}
+
+	if !opts.Yes {
+		if opts.Confirm == nil {
+			return fmt.Errorf("refusing to update %d issues without confirmation (use --yes)", len(issues))
+		}
+		// Keep stdout clean for the JSON results
+		preview := factory.Formatter
+		if preview.IsJSON() {
+			preview = output.NewFormatter(false)
+			preview.SetWriter(os.Stderr)
+		}
+		headers, rows := issueRows(issues)
+		preview.PrintTable(headers, rows)
+		ok, err := opts.Confirm(fmt.Sprintf("Update %d issues?", len(issues)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("aborted")
+		}
+	}
+
+	results := updateIssues(ctx, factory.Client, issues, inputs, opts.Concurrency)
+
+	failed := 0
+	headers := []string{"ISSUE", "TITLE", "RESULT"}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		status := "updated"
+		if r.Error != "" {
+			status = "error: " + r.Error
+			failed++
+		}
+		rows[i] = []string{r.Issue, output.Truncate(r.Title, 50), status}
+	}
+
+	if err := factory.Formatter.Print(headers, rows, results); err != nil {
+		return err
+	}
+	if !factory.Formatter.IsJSON() {
+		if err := factory.Formatter.PrintText(fmt.Sprintf("\nUpdated %d of %d issues, %d failed.\n", len(issues)-failed, len(issues), failed), nil); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d of %d issues", failed, len(issues))
+	}
+	return nil
+}
+
+// updateIssues applies inputs[i] to issues[i] with at most concurrency
+// requests in flight. Rate limited requests are retried by the client's
+// transport, so workers simply wait their turn. Results keep the order of
+// issues.
+func updateIssues(ctx context.Context, client api.Client, issues []api.Issue, inputs []*api.IssueUpdateInput, concurrency int) []bulkEditResult {
+	results := make([]bulkEditResult, len(issues))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range issues {
+		results[i] = bulkEditResult{Issue: issues[i].Identifier, Title: issues[i].Title}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if _, err := client.UpdateIssue(ctx, issues[i].ID, *inputs[i]); err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Updated = true
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// hasChanges reports whether changes sets any field
+func hasChanges(changes editOptions) bool {
+	return changes.Title != "" || changes.Description != "" || changes.State != "" ||
+		changes.Assignee != "" || changes.Priority != "" || changes.Estimate != "" ||
+		changes.Due != "" || changes.Project != "" || changes.Cycle != "" ||
+		changes.Parent != "" || len(changes.AddLabels) > 0 || len(changes.RemoveLabels) > 0
+}
@@ -0,0 +1,189 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// bulkEditMock serves three issues and records the updates made to them.
+// Updating ENG-2 fails.
+type bulkEditMock struct {
+	mu      sync.Mutex
+	updated map[string]api.IssueUpdateInput
+}
+
+func (m *bulkEditMock) client() *api.MockClient {
+	team := &api.Team{ID: "team-eng", Key: "ENG"}
+	return &api.MockClient{
+		GetIssuesFunc: func(ctx context.Context, opts api.IssueListOptions) ([]api.Issue, error) {
+			return []api.Issue{
+				{ID: "issue-1", Identifier: "ENG-1", Title: "One", Team: team},
+				{ID: "issue-2", Identifier: "ENG-2", Title: "Two", Team: team},
+				{ID: "issue-3", Identifier: "ENG-3", Title: "Three", Team: team},
+			}, nil
+		},
+		GetLabelsFunc: func(ctx context.Context, opts api.LabelListOptions) ([]api.Label, error) {
+			return []api.Label{{ID: "label-bug", Name: "bug"}}, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, id string, input api.IssueUpdateInput) (*api.Issue, error) {
+			if id == "issue-2" {
+				return nil, assert.AnError
+			}
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.updated[id] = input
+			return &api.Issue{ID: id}, nil
+		},
+	}
+}
+
+func TestRunBulkEditWithFactory(t *testing.T) {
+	changes := editOptions{AddLabels: []string{"bug"}}
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+		factory := cmdutil.NewFactoryWithClient(mock.client(), false)
+		var buf bytes.Buffer
+		factory.Formatter.SetWriter(&buf)
+
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, changes, bulkEditOptions{DryRun: true, Concurrency: 2})
+
+		require.NoError(t, err)
+		assert.Empty(t, mock.updated)
+		assert.Contains(t, buf.String(), "ENG-3")
+	})
+
+	t.Run("updates every issue and reports failures", func(t *testing.T) {
+		mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+		factory := cmdutil.NewFactoryWithClient(mock.client(), true)
+		var buf bytes.Buffer
+		factory.Formatter.SetWriter(&buf)
+
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, changes, bulkEditOptions{Yes: true, Concurrency: 2})
+
+		assert.EqualError(t, err, "failed to update 1 of 3 issues")
+		assert.Len(t, mock.updated, 2)
+		assert.Equal(t, []string{"label-bug"}, mock.updated["issue-1"].AddedLabelIDs)
+
+		var results []bulkEditResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		require.Len(t, results, 3)
+		assert.True(t, results[0].Updated)
+		assert.Equal(t, "ENG-2", results[1].Issue)
+		assert.False(t, results[1].Updated)
+		assert.NotEmpty(t, results[1].Error)
+		assert.True(t, results[2].Updated)
+	})
+
+	t.Run("prints a summary", func(t *testing.T) {
+		mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+		factory := cmdutil.NewFactoryWithClient(mock.client(), false)
+		var buf bytes.Buffer
+		factory.Formatter.SetWriter(&buf)
+
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, changes, bulkEditOptions{Yes: true, Concurrency: 1})
+
+		require.Error(t, err)
+		assert.Contains(t, buf.String(), "Updated 2 of 3 issues, 1 failed.")
+	})
+
+	t.Run("requires confirmation", func(t *testing.T) {
+		mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+		factory := cmdutil.NewFactoryWithClient(mock.client(), false)
+		factory.Formatter.SetWriter(&bytes.Buffer{})
+
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, changes, bulkEditOptions{Concurrency: 2})
+
+		assert.EqualError(t, err, "refusing to update 3 issues without confirmation (use --yes)")
+		assert.Empty(t, mock.updated)
+	})
+
+	t.Run("stops when confirmation is declined", func(t *testing.T) {
+		mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+		factory := cmdutil.NewFactoryWithClient(mock.client(), false)
+		factory.Formatter.SetWriter(&bytes.Buffer{})
+
+		var prompt string
+		opts := bulkEditOptions{Concurrency: 2, Confirm: func(p string) (bool, error) {
+			prompt = p
+			return false, nil
+		}}
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, changes, opts)
+
+		assert.EqualError(t, err, "aborted")
+		assert.Equal(t, "Update 3 issues?", prompt)
+		assert.Empty(t, mock.updated)
+	})
+
+	t.Run("rejects unknown labels before updating", func(t *testing.T) {
+		mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+		factory := cmdutil.NewFactoryWithClient(mock.client(), false)
+
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, editOptions{AddLabels: []string{"nope"}}, bulkEditOptions{Yes: true, Concurrency: 2})
+
+		assert.EqualError(t, err, `ENG-1: label "nope" not found`)
+		assert.Empty(t, mock.updated)
+	})
+
+	t.Run("requires a change", func(t *testing.T) {
+		factory := cmdutil.NewFactoryWithClient(&api.MockClient{}, false)
+
+		err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, editOptions{}, bulkEditOptions{Yes: true, Concurrency: 2})
+
+		assert.ErrorContains(t, err, "nothing to update")
+	})
+}
+
+func TestRunBulkEditWithFactory_LooksUpOnce(t *testing.T) {
+	mock := &bulkEditMock{updated: map[string]api.IssueUpdateInput{}}
+	client := mock.client()
+	var cycleCalls, parentCalls int
+	client.GetCyclesFunc = func(ctx context.Context, opts api.CycleListOptions) ([]api.Cycle, error) {
+		cycleCalls++
+		return []api.Cycle{{ID: "cycle-next", Number: 9, StartsAt: time.Now().Add(24 * time.Hour)}}, nil
+	}
+	client.GetIssueFunc = func(ctx context.Context, id string) (*api.Issue, error) {
+		parentCalls++
+		return &api.Issue{ID: "00000000-0000-4000-8000-0000000000e1", Identifier: "ENG-10"}, nil
+	}
+	factory := cmdutil.NewFactoryWithClient(client, false)
+	factory.Formatter.SetWriter(&bytes.Buffer{})
+
+	changes := editOptions{Cycle: "next", Parent: "ENG-10"}
+	err := runBulkEditWithFactory(factory, api.IssueListOptions{All: true}, changes, bulkEditOptions{Yes: true, Concurrency: 1})
+
+	assert.EqualError(t, err, "failed to update 1 of 3 issues")
+	assert.Equal(t, 1, cycleCalls)
+	assert.Equal(t, 1, parentCalls)
+	assert.Equal(t, "cycle-next", *mock.updated["issue-3"].CycleID)
+	assert.Equal(t, "00000000-0000-4000-8000-0000000000e1", *mock.updated["issue-3"].ParentID)
+}
+
+func TestBulkListOptions(t *testing.T) {
+	parse := func(args ...string) api.IssueListOptions {
+		var flags listFlags
+		cmd := &cobra.Command{Use: "bulk-edit"}
+		flags.register(cmd)
+		require.NoError(t, cmd.ParseFlags(args))
+		opts, err := bulkListOptions(cmd, &flags)
+		require.NoError(t, err)
+		return opts
+	}
+
+	opts := parse("--team", "ENG")
+	assert.True(t, opts.All, "every match is edited by default")
+
+	opts = parse("--limit", "20")
+	assert.False(t, opts.All)
+	assert.Equal(t, 20, opts.Limit)
+}
@@ -0,0 +1,116 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/editor"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdComment creates the issue comment parent command
+func NewCmdComment() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Manage issue comments",
+		Long:  "Commands for reading and writing comments on Linear issues.",
+	}
+
+	cmd.AddCommand(NewCmdCommentList())
+	cmd.AddCommand(NewCmdCommentAdd())
+	cmd.AddCommand(NewCmdCommentReply())
+	cmd.AddCommand(NewCmdCommentEdit())
+	cmd.AddCommand(NewCmdCommentDelete())
+
+	return cmd
+}
+
+// commentBody returns the comment body from --body or --body-file, or opens
+// $EDITOR on initial when neither is given and lnr is running in a terminal
+func commentBody(body, bodyFile, initial string) (string, error) {
+	switch {
+	case body != "":
+	case bodyFile != "":
+		var err error
+		body, err = readDescription(bodyFile)
+		if err != nil {
+			return "", err
+		}
+	case cmdutil.IsTerminal(os.Stdin) && cmdutil.IsTerminal(os.Stdout):
+		var err error
+		body, err = editor.Edit(initial, "lnr-comment-*.md")
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", errors.New("a comment body is required (use --body or --body-file)")
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("aborting: comment body is empty")
+	}
+	return body, nil
+}
+
+// renderComments renders comments as threads, with replies indented under
+// the comment they answer. Replies whose parent is not in comments are shown
+// at the top level.
+func renderComments(comments []api.Comment) string {
+	if len(comments) == 0 {
+		return "No comments.\n"
+	}
+
+	present := make(map[string]bool, len(comments))
+	for _, c := range comments {
+		present[c.ID] = true
+	}
+
+	replies := make(map[string][]api.Comment)
+	var threads []api.Comment
+	for _, c := range comments {
+		if c.ParentID != nil && present[*c.ParentID] {
+			replies[*c.ParentID] = append(replies[*c.ParentID], c)
+		} else {
+			threads = append(threads, c)
+		}
+	}
+
+	var b strings.Builder
+	for i, thread := range threads {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeComment(&b, thread, "")
+		for _, reply := range replies[thread.ID] {
+			b.WriteString("\n")
+			writeComment(&b, reply, "    ")
+		}
+	}
+	return b.String()
+}
+
+// writeComment writes a comment header and indented body
+func writeComment(b *strings.Builder, c api.Comment, indent string) {
+	author := "Unknown"
+	if c.User != nil {
+		author = c.User.Name
+	}
+	edited := ""
+	if c.EditedAt != nil {
+		edited = " (edited)"
+	}
+
+	marker := ""
+	if indent != "" {
+		marker = "↳ "
+	}
+	fmt.Fprintf(b, "%s%s%s · %s%s  [%s]\n", indent, marker, author, c.CreatedAt.Local().Format("2006-01-02 15:04"), edited, c.ID)
+	for _, line := range strings.Split(strings.TrimRight(c.Body, "\n"), "\n") {
+		fmt.Fprintf(b, "%s  %s\n", indent, line)
+	}
+}
@@ -0,0 +1,135 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdCommentAdd creates the issue comment add command
+func NewCmdCommentAdd() *cobra.Command {
+	var body string
+	var bodyFile string
+
+	cmd := &cobra.Command{
+		Use:   "add <issue-id>",
+		Short: "Comment on an issue",
+		Long:  "Add a comment to an issue. Without --body or --body-file, $EDITOR opens to write it.",
+		Example: `  lnr issue comment add ENG-123 --body "Reproduced on staging"
+  lnr issue comment add ENG-123 --body-file - < notes.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			text, err := commentBody(body, bodyFile, "")
+			if err != nil {
+				return err
+			}
+			return runCommentAdd(cmd.Context(), jsonOutput, args[0], text)
+		},
+	}
+
+	cmd.Flags().StringVarP(&body, "body", "b", "", "Comment text in markdown")
+	cmd.Flags().StringVar(&bodyFile, "body-file", "", "Read the comment from a file, or - for stdin")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file")
+
+	return cmd
+}
+
+// NewCmdCommentReply creates the issue comment reply command
+func NewCmdCommentReply() *cobra.Command {
+	var body string
+	var bodyFile string
+
+	cmd := &cobra.Command{
+		Use:   "reply <comment-id>",
+		Short: "Reply to a comment thread",
+		Long:  "Reply to a comment. Replies to a reply are added to the same thread.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			text, err := commentBody(body, bodyFile, "")
+			if err != nil {
+				return err
+			}
+			return runCommentReply(cmd.Context(), jsonOutput, args[0], text)
+		},
+	}
+
+	cmd.Flags().StringVarP(&body, "body", "b", "", "Reply text in markdown")
+	cmd.Flags().StringVar(&bodyFile, "body-file", "", "Read the reply from a file, or - for stdin")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file")
+
+	return cmd
+}
+
+func runCommentAdd(ctx context.Context, jsonOutput bool, issueID, body string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runCommentAddWithFactory(factory, issueID, body)
+}
+
+func runCommentAddWithFactory(factory *cmdutil.Factory, issueID, body string) error {
+	ctx := factory.Context
+	issue, err := factory.Client.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	comment, err := factory.Client.CreateComment(ctx, api.CommentCreateInput{
+		IssueID: issue.ID,
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return printComment(factory, "Commented on "+issue.Identifier, comment)
+}
+
+func runCommentReply(ctx context.Context, jsonOutput bool, commentID, body string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runCommentReplyWithFactory(factory, commentID, body)
+}
+
+func runCommentReplyWithFactory(factory *cmdutil.Factory, commentID, body string) error {
+	ctx := factory.Context
+	parent, err := factory.Client.GetComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	// Linear threads are one level deep, so replies go to the thread's root
+	parentID := parent.ID
+	if parent.ParentID != nil {
+		parentID = *parent.ParentID
+	}
+
+	comment, err := factory.Client.CreateComment(ctx, api.CommentCreateInput{
+		IssueID:  parent.IssueID,
+		Body:     body,
+		ParentID: &parentID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reply to comment: %w", err)
+	}
+
+	return printComment(factory, "Replied", comment)
+}
+
+// printComment prints a summary of a comment that was just written
+func printComment(factory *cmdutil.Factory, action string, comment *api.Comment) error {
+	fields := []output.DetailField{
+		{Label: action, Value: comment.ID},
+		{Label: "URL", Value: comment.URL},
+	}
+	return factory.Formatter.PrintDetail(fields, comment)
+}
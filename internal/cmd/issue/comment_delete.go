@@ -0,0 +1,63 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdCommentDelete creates the issue comment delete command
+func NewCmdCommentDelete() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <comment-id>",
+		Short: "Delete a comment",
+		Long:  "Delete a comment. Asks for confirmation unless --yes is given.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if !yes {
+				if !cmdutil.IsTerminal(os.Stdin) {
+					return errors.New("refusing to delete without confirmation (use --yes)")
+				}
+				ok, err := cmdutil.ConfirmContext(cmd.Context(), os.Stdin, os.Stderr, "Delete this comment?")
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errors.New("aborted")
+				}
+			}
+			return runCommentDelete(cmd.Context(), jsonOutput, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete without asking for confirmation")
+
+	return cmd
+}
+
+func runCommentDelete(ctx context.Context, jsonOutput bool, commentID string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runCommentDeleteWithFactory(factory, commentID)
+}
+
+func runCommentDeleteWithFactory(factory *cmdutil.Factory, commentID string) error {
+	ctx := factory.Context
+	if err := factory.Client.DeleteComment(ctx, commentID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return factory.Formatter.PrintText(
+		fmt.Sprintf("Deleted comment %s\n", commentID),
+		map[string]interface{}{"id": commentID, "deleted": true},
+	)
+}
@@ -0,0 +1,64 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdCommentEdit creates the issue comment edit command
+func NewCmdCommentEdit() *cobra.Command {
+	var body string
+	var bodyFile string
+
+	cmd := &cobra.Command{
+		Use:   "edit <comment-id>",
+		Short: "Edit a comment",
+		Long:  "Replace the text of a comment. Without --body or --body-file, $EDITOR opens on the current text.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runCommentEdit(cmd.Context(), jsonOutput, args[0], body, bodyFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&body, "body", "b", "", "New comment text in markdown")
+	cmd.Flags().StringVar(&bodyFile, "body-file", "", "Read the comment from a file, or - for stdin")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file")
+
+	return cmd
+}
+
+func runCommentEdit(ctx context.Context, jsonOutput bool, commentID, body, bodyFile string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runCommentEditWithFactory(factory, commentID, body, bodyFile)
+}
+
+func runCommentEditWithFactory(factory *cmdutil.Factory, commentID, body, bodyFile string) error {
+	ctx := factory.Context
+	current, err := factory.Client.GetComment(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	text, err := commentBody(body, bodyFile, current.Body)
+	if err != nil {
+		return err
+	}
+	if text == current.Body {
+		return errors.New("aborting: comment is unchanged")
+	}
+
+	comment, err := factory.Client.UpdateComment(ctx, current.ID, text)
+	if err != nil {
+		return fmt.Errorf("failed to edit comment: %w", err)
+	}
+
+	return printComment(factory, "Edited", comment)
+}
@@ -0,0 +1,59 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdCommentList creates the issue comment list command
+func NewCmdCommentList() *cobra.Command {
+	var limit int
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list <issue-id>",
+		Short: "List comments on an issue",
+		Long:  "List the comments on an issue as threads, oldest first.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runCommentList(cmd.Context(), jsonOutput, args[0], api.ListOptions{Limit: limit, All: all})
+		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of comments to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all comments, ignoring --limit")
+
+	return cmd
+}
+
+func runCommentList(ctx context.Context, jsonOutput bool, issueID string, opts api.ListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runCommentListWithFactory(factory, issueID, opts)
+}
+
+func runCommentListWithFactory(factory *cmdutil.Factory, issueID string, opts api.ListOptions) error {
+	ctx := factory.Context
+	comments, listErr := factory.Client.GetComments(ctx, issueID, opts)
+	if listErr != nil && len(comments) == 0 {
+		return fmt.Errorf("failed to list comments: %w", listErr)
+	}
+
+	if !opts.All {
+		output.WarnIfTruncated(len(comments), opts.Limit)
+	}
+
+	if err := factory.Formatter.PrintText(renderComments(comments), comments); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(comments), "comments")
+}
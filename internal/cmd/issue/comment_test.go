@@ -0,0 +1,162 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func testComments() []api.Comment {
+	root := "c1"
+	missing := "c0"
+	at := time.Date(2026, 1, 1, 10, 0, 0, 0, time.Local)
+	return []api.Comment{
+		{ID: "c1", Body: "Can't reproduce", User: &api.User{Name: "Alice"}, CreatedAt: at},
+		{ID: "c2", Body: "Try staging", User: &api.User{Name: "Bob"}, CreatedAt: at.Add(time.Hour), ParentID: &root},
+		{ID: "c3", Body: "Unrelated\nsecond line", CreatedAt: at.Add(2 * time.Hour)},
+		{ID: "c4", Body: "Orphan reply", User: &api.User{Name: "Carol"}, CreatedAt: at.Add(3 * time.Hour), ParentID: &missing},
+	}
+}
+
+func TestRenderComments(t *testing.T) {
+	got := renderComments(testComments())
+
+	assert.Equal(t, strings.Join([]string{
+		"Alice · 2026-01-01 10:00  [c1]",
+		"  Can't reproduce",
+		"",
+		"    ↳ Bob · 2026-01-01 11:00  [c2]",
+		"      Try staging",
+		"",
+		"Unknown · 2026-01-01 12:00  [c3]",
+		"  Unrelated",
+		"  second line",
+		"",
+		"Carol · 2026-01-01 13:00  [c4]",
+		"  Orphan reply",
+		"",
+	}, "\n"), got)
+}
+
+func TestRenderComments_Empty(t *testing.T) {
+	assert.Equal(t, "No comments.\n", renderComments(nil))
+}
+
+func TestRunCommentAddWithFactory(t *testing.T) {
+	var created api.CommentCreateInput
+	mockClient := &api.MockClient{
+		GetIssueFunc: func(ctx context.Context, id string) (*api.Issue, error) {
+			return &api.Issue{ID: "issue-1", Identifier: id}, nil
+		},
+		CreateCommentFunc: func(ctx context.Context, input api.CommentCreateInput) (*api.Comment, error) {
+			created = input
+			return &api.Comment{ID: "c9", Body: input.Body, URL: "https://linear.app/acme/issue/ENG-1#comment-c9"}, nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runCommentAddWithFactory(factory, "ENG-1", "Looks good")
+
+	require.NoError(t, err)
+	assert.Equal(t, api.CommentCreateInput{IssueID: "issue-1", Body: "Looks good"}, created)
+	assert.Contains(t, buf.String(), "Commented on ENG-1")
+	assert.Contains(t, buf.String(), "#comment-c9")
+}
+
+func TestRunCommentReplyWithFactory_UsesThreadRoot(t *testing.T) {
+	root := "c1"
+	var created api.CommentCreateInput
+	mockClient := &api.MockClient{
+		GetCommentFunc: func(ctx context.Context, id string) (*api.Comment, error) {
+			return &api.Comment{ID: id, IssueID: "issue-1", ParentID: &root}, nil
+		},
+		CreateCommentFunc: func(ctx context.Context, input api.CommentCreateInput) (*api.Comment, error) {
+			created = input
+			return &api.Comment{ID: "c9"}, nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	factory.Formatter.SetWriter(&bytes.Buffer{})
+
+	err := runCommentReplyWithFactory(factory, "c2", "Agreed")
+
+	require.NoError(t, err)
+	assert.Equal(t, "issue-1", created.IssueID)
+	assert.Equal(t, "c1", *created.ParentID)
+}
+
+func TestRunCommentEditWithFactory(t *testing.T) {
+	var updatedBody string
+	mockClient := &api.MockClient{
+		GetCommentFunc: func(ctx context.Context, id string) (*api.Comment, error) {
+			return &api.Comment{ID: id, Body: "Old text"}, nil
+		},
+		UpdateCommentFunc: func(ctx context.Context, id string, body string) (*api.Comment, error) {
+			updatedBody = body
+			return &api.Comment{ID: id, Body: body}, nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	factory.Formatter.SetWriter(&bytes.Buffer{})
+
+	require.NoError(t, runCommentEditWithFactory(factory, "c1", "  New text\n", ""))
+	assert.Equal(t, "New text", updatedBody)
+
+	err := runCommentEditWithFactory(factory, "c1", "Old text", "")
+	assert.EqualError(t, err, "aborting: comment is unchanged")
+}
+
+func TestRunCommentDeleteWithFactory_JSONOutput(t *testing.T) {
+	var deleted string
+	mockClient := &api.MockClient{
+		DeleteCommentFunc: func(ctx context.Context, id string) error {
+			deleted = id
+			return nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, true)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	require.NoError(t, runCommentDeleteWithFactory(factory, "c1"))
+	assert.Equal(t, "c1", deleted)
+	assert.JSONEq(t, `{"id": "c1", "deleted": true}`, buf.String())
+}
+
+func TestRunViewWithFactory_Comments(t *testing.T) {
+	mockClient := &api.MockClient{
+		GetIssueFunc: func(ctx context.Context, id string) (*api.Issue, error) {
+			return &api.Issue{ID: "issue-1", Identifier: "ENG-1", Title: "Fix login", Team: &api.Team{Name: "Engineering"}}, nil
+		},
+		GetCommentsFunc: func(ctx context.Context, issueID string, opts api.ListOptions) ([]api.Comment, error) {
+			assert.Equal(t, "issue-1", issueID)
+			return testComments(), nil
+		},
+	}
+
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	require.NoError(t, runViewWithFactory(factory, "ENG-1", true))
+	assert.Contains(t, buf.String(), "Fix login")
+	assert.Contains(t, buf.String(), "Comments (4)")
+	assert.Contains(t, buf.String(), "↳ Bob")
+
+	factory = cmdutil.NewFactoryWithClient(mockClient, true)
+	buf.Reset()
+	factory.Formatter.SetWriter(&buf)
+
+	require.NoError(t, runViewWithFactory(factory, "ENG-1", true))
+	assert.Contains(t, buf.String(), `"identifier": "ENG-1"`)
+	assert.Contains(t, buf.String(), `"comments": [`)
+}
@@ -0,0 +1,291 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/editor"
+	"github.com/stustirling/lnr/internal/filter"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+	"gopkg.in/yaml.v3"
+)
+
+// createOptions holds the issue fields as given on the command line, before
+// references are resolved to IDs
+type createOptions struct {
+	Team        string   `yaml:"team"`
+	Title       string   `yaml:"title"`
+	Assignee    string   `yaml:"assignee"`
+	Labels      []string `yaml:"labels"`
+	Priority    string   `yaml:"priority"`
+	Estimate    string   `yaml:"estimate"`
+	Project     string   `yaml:"project"`
+	Cycle       string   `yaml:"cycle"`
+	Parent      string   `yaml:"parent"`
+	Due         string   `yaml:"due"`
+	Description string   `yaml:"-"`
+}
+
+// NewCmdCreate creates the issue create command
+func NewCmdCreate() *cobra.Command {
+	var opts createOptions
+	var descriptionFile string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an issue",
+		Long: `Create an issue in a team.
+
+When --title or the description is omitted and lnr is running in a terminal,
+$EDITOR opens on a template where the fields are set in the front matter and
+the description is written below it.
+
+The team, project and labels settings, such as those in a repository's
+.lnr.yaml, fill in --team, --project and --label when they are not given.`,
+		Example: `  lnr issue create --team ENG --title "Fix login" --label bug --priority high
+  lnr issue create --team ENG --title "Flaky test" --description-file - < notes.md
+  lnr issue create --team ENG`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			if descriptionFile != "" {
+				description, err := readDescription(descriptionFile)
+				if err != nil {
+					return err
+				}
+				opts.Description = description
+			}
+
+			if (opts.Title == "" || opts.Description == "") && cmdutil.IsTerminal(os.Stdin) && cmdutil.IsTerminal(os.Stdout) {
+				edited, err := editor.Edit(renderTemplate(opts), "lnr-issue-*.md")
+				if err != nil {
+					return err
+				}
+				if err := opts.applyTemplate(edited); err != nil {
+					return err
+				}
+			}
+
+			return runCreate(cmd.Context(), jsonOutput, opts)
+		},
+		Annotations: map[string]string{cmdutil.AnnotationContext: "true"},
+	}
+
+	cmd.Flags().StringVar(&opts.Team, "team", "", "Team key, name or ID")
+	cmd.Flags().StringVar(&opts.Title, "title", "", "Issue title")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Issue description in markdown")
+	cmd.Flags().StringVar(&descriptionFile, "description-file", "", "Read the description from a file, or - for stdin")
+	cmd.Flags().StringVar(&opts.Assignee, "assignee", "", "Assignee email, name, @me or ID")
+	cmd.Flags().StringSliceVar(&opts.Labels, "label", nil, "Label name or ID (repeatable)")
+	cmd.Flags().StringVar(&opts.Priority, "priority", "", "Priority: urgent, high, medium, low, none or 0-4")
+	cmd.Flags().StringVar(&opts.Estimate, "estimate", "", "Estimate in points")
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Project name, slug or ID")
+	cmd.Flags().StringVar(&opts.Cycle, "cycle", "", "Cycle number, current, next, previous or ID")
+	cmd.Flags().StringVar(&opts.Parent, "parent", "", "Parent issue identifier (e.g., ENG-10)")
+	cmd.Flags().StringVar(&opts.Due, "due", "", "Due date (YYYY-MM-DD)")
+	cmd.MarkFlagsMutuallyExclusive("description", "description-file")
+
+	return cmd
+}
+
+// readDescription reads a description from path, or from stdin when path is -
+func readDescription(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read description: %w", err)
+	}
+	return string(data), nil
+}
+
+// renderTemplate renders opts as a markdown document with the fields in YAML
+// front matter and the description as the body
+func renderTemplate(opts createOptions) string {
+	if opts.Labels == nil {
+		opts.Labels = []string{}
+	}
+	frontMatter, _ := yaml.Marshal(opts)
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(frontMatter)
+	buf.WriteString("---\n\n")
+	buf.WriteString(opts.Description)
+	return buf.String()
+}
+
+// applyTemplate replaces opts with the fields and description from an edited
+// template
+func (o *createOptions) applyTemplate(content string) error {
+	content = strings.TrimLeft(content, "\r\n")
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return errors.New("invalid template: missing front matter")
+	}
+	frontMatter, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		// Front matter ending at the end of the file
+		frontMatter, ok = strings.CutSuffix(rest, "\n---")
+		if !ok {
+			return errors.New("invalid template: unterminated front matter")
+		}
+	}
+
+	var parsed createOptions
+	if err := yaml.Unmarshal([]byte(frontMatter), &parsed); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	parsed.Description = strings.TrimSpace(body)
+	*o = parsed
+	return nil
+}
+
+func runCreate(ctx context.Context, jsonOutput bool, opts createOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runCreateWithFactory(factory, opts)
+}
+
+func runCreateWithFactory(factory *cmdutil.Factory, opts createOptions) error {
+	ctx := factory.Context
+	input, err := buildCreateInput(ctx, factory, opts)
+	if err != nil {
+		return err
+	}
+
+	issue, err := factory.Client.CreateIssue(ctx, *input)
+	if err != nil {
+		return fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	fields := []output.DetailField{
+		{Label: "Created", Value: fmt.Sprintf("%s %s", issue.Identifier, issue.Title)},
+		{Label: "URL", Value: issue.URL},
+	}
+
+	return factory.Formatter.PrintDetail(fields, issue)
+}
+
+// buildCreateInput validates opts and resolves its references into an
+// IssueCreateInput
+func buildCreateInput(ctx context.Context, factory *cmdutil.Factory, opts createOptions) (*api.IssueCreateInput, error) {
+	if strings.TrimSpace(opts.Title) == "" {
+		return nil, errors.New("a title is required (use --title)")
+	}
+	if opts.Team == "" {
+		return nil, errors.New("a team is required (use --team)")
+	}
+
+	team, err := factory.Resolver.Team(ctx, opts.Team)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &api.IssueCreateInput{
+		TeamID: team.ID,
+		Title:  strings.TrimSpace(opts.Title),
+	}
+
+	if opts.Description != "" {
+		input.Description = &opts.Description
+	}
+
+	if opts.Assignee != "" {
+		user, err := factory.Resolver.User(ctx, opts.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		input.AssigneeID = &user.ID
+	}
+
+	for _, ref := range opts.Labels {
+		label, err := factory.Resolver.Label(ctx, ref, &team.ID)
+		if err != nil {
+			return nil, err
+		}
+		input.LabelIDs = append(input.LabelIDs, label.ID)
+	}
+
+	if opts.Priority != "" {
+		priority, err := filter.ParsePriority(opts.Priority)
+		if err != nil {
+			return nil, err
+		}
+		input.Priority = &priority
+	}
+
+	if opts.Estimate != "" {
+		estimate, err := parseEstimate(opts.Estimate)
+		if err != nil {
+			return nil, err
+		}
+		input.Estimate = &estimate
+	}
+
+	if opts.Project != "" {
+		project, err := factory.Resolver.Project(ctx, opts.Project)
+		if err != nil {
+			return nil, err
+		}
+		input.ProjectID = &project.ID
+	}
+
+	if opts.Cycle != "" {
+		cycle, err := factory.Resolver.Cycle(ctx, opts.Cycle, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		input.CycleID = &cycle.ID
+	}
+
+	if opts.Parent != "" {
+		parentID, err := resolveParent(ctx, factory, opts.Parent)
+		if err != nil {
+			return nil, err
+		}
+		input.ParentID = &parentID
+	}
+
+	if opts.Due != "" {
+		if err := validateDueDate(opts.Due); err != nil {
+			return nil, err
+		}
+		input.DueDate = &opts.Due
+	}
+
+	return input, nil
+}
+
+// parseEstimate parses an estimate in whole points
+func parseEstimate(s string) (int, error) {
+	estimate, err := strconv.Atoi(s)
+	if err != nil || estimate < 0 {
+		return 0, fmt.Errorf("invalid estimate %q (expected a whole number of points)", s)
+	}
+	return estimate, nil
+}
+
+// validateDueDate checks that s is a calendar date as Linear expects
+func validateDueDate(s string) error {
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return fmt.Errorf("invalid due date %q (expected YYYY-MM-DD)", s)
+	}
+	return nil
+}
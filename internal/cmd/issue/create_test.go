@@ -0,0 +1,173 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func newCreateMockClient(created *api.IssueCreateInput) *api.MockClient {
+	engTeam := api.Team{ID: "team-eng", Key: "ENG", Name: "Engineering"}
+	return &api.MockClient{
+		GetViewerFunc: func(ctx context.Context) (*api.User, error) {
+			return &api.User{ID: "user-me"}, nil
+		},
+		GetTeamsFunc: func(ctx context.Context, opts api.ListOptions) ([]api.Team, error) {
+			return []api.Team{engTeam}, nil
+		},
+		GetLabelsFunc: func(ctx context.Context, opts api.LabelListOptions) ([]api.Label, error) {
+			return []api.Label{{ID: "label-bug", Name: "Bug"}}, nil
+		},
+		GetActiveCycleFunc: func(ctx context.Context, teamID string) (*api.Cycle, error) {
+			return &api.Cycle{ID: "cycle-7", Number: 7}, nil
+		},
+		GetIssueFunc: func(ctx context.Context, id string) (*api.Issue, error) {
+			return &api.Issue{ID: "issue-parent", Identifier: id}, nil
+		},
+		CreateIssueFunc: func(ctx context.Context, input api.IssueCreateInput) (*api.Issue, error) {
+			*created = input
+			return &api.Issue{
+				ID:         "issue-new",
+				Identifier: "ENG-42",
+				Title:      input.Title,
+				URL:        "https://linear.app/acme/issue/ENG-42",
+			}, nil
+		},
+	}
+}
+
+func TestRunCreateWithFactory(t *testing.T) {
+	var created api.IssueCreateInput
+	factory := cmdutil.NewFactoryWithClient(newCreateMockClient(&created), false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runCreateWithFactory(factory, createOptions{
+		Team:        "ENG",
+		Title:       "Fix login",
+		Description: "Steps to reproduce",
+		Assignee:    "@me",
+		Labels:      []string{"bug"},
+		Priority:    "high",
+		Estimate:    "3",
+		Cycle:       "current",
+		Parent:      "ENG-10",
+		Due:         "2026-11-01",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "team-eng", created.TeamID)
+	assert.Equal(t, "Fix login", created.Title)
+	assert.Equal(t, "Steps to reproduce", *created.Description)
+	assert.Equal(t, "user-me", *created.AssigneeID)
+	assert.Equal(t, []string{"label-bug"}, created.LabelIDs)
+	assert.Equal(t, 2, *created.Priority)
+	assert.Equal(t, 3, *created.Estimate)
+	assert.Equal(t, "cycle-7", *created.CycleID)
+	assert.Equal(t, "issue-parent", *created.ParentID)
+	assert.Equal(t, "2026-11-01", *created.DueDate)
+	assert.Nil(t, created.ProjectID)
+
+	assert.Contains(t, buf.String(), "ENG-42")
+	assert.Contains(t, buf.String(), "https://linear.app/acme/issue/ENG-42")
+}
+
+func TestRunCreateWithFactory_ParentID(t *testing.T) {
+	var created api.IssueCreateInput
+	client := newCreateMockClient(&created)
+	client.GetIssueFunc = func(ctx context.Context, id string) (*api.Issue, error) {
+		t.Fatalf("GetIssue(%q) called for a parent ID", id)
+		return nil, nil
+	}
+	factory := cmdutil.NewFactoryWithClient(client, false)
+	factory.Formatter.SetWriter(&bytes.Buffer{})
+
+	parentID := "4f0a7c2e-1b3d-4e5f-8a9b-0c1d2e3f4a5b"
+	err := runCreateWithFactory(factory, createOptions{Team: "ENG", Title: "Fix login", Parent: parentID})
+
+	require.NoError(t, err)
+	assert.Equal(t, parentID, *created.ParentID)
+}
+
+func TestRunCreateWithFactory_JSONOutput(t *testing.T) {
+	var created api.IssueCreateInput
+	factory := cmdutil.NewFactoryWithClient(newCreateMockClient(&created), true)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runCreateWithFactory(factory, createOptions{Team: "ENG", Title: "Fix login"})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"identifier": "ENG-42"`)
+}
+
+func TestRunCreateWithFactory_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    createOptions
+		wantErr string
+	}{
+		{
+			name:    "missing title",
+			opts:    createOptions{Team: "ENG"},
+			wantErr: "a title is required (use --title)",
+		},
+		{
+			name:    "missing team",
+			opts:    createOptions{Title: "Fix login"},
+			wantErr: "a team is required (use --team)",
+		},
+		{
+			name:    "invalid priority",
+			opts:    createOptions{Team: "ENG", Title: "Fix login", Priority: "critical"},
+			wantErr: `invalid priority "critical" (expected 0-4 or none, urgent, high, medium, low)`,
+		},
+		{
+			name:    "invalid due date",
+			opts:    createOptions{Team: "ENG", Title: "Fix login", Due: "tomorrow"},
+			wantErr: `invalid due date "tomorrow" (expected YYYY-MM-DD)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created api.IssueCreateInput
+			factory := cmdutil.NewFactoryWithClient(newCreateMockClient(&created), false)
+
+			err := runCreateWithFactory(factory, tt.opts)
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTemplate_RoundTrip(t *testing.T) {
+	opts := createOptions{
+		Team:        "ENG",
+		Assignee:    "@me",
+		Labels:      []string{"bug"},
+		Description: "Steps to reproduce",
+	}
+
+	rendered := renderTemplate(opts)
+	assert.Contains(t, rendered, "title: \"\"\n")
+
+	edited := bytes.Replace([]byte(rendered), []byte(`title: ""`), []byte("title: Fix login"), 1)
+	var got createOptions
+	require.NoError(t, got.applyTemplate(string(edited)))
+
+	opts.Title = "Fix login"
+	assert.Equal(t, opts, got)
+}
+
+func TestApplyTemplate_Invalid(t *testing.T) {
+	var opts createOptions
+
+	assert.EqualError(t, opts.applyTemplate("just a description"), "invalid template: missing front matter")
+	assert.EqualError(t, opts.applyTemplate("---\ntitle: x\n"), "invalid template: unterminated front matter")
+}
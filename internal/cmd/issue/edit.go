@@ -0,0 +1,365 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/filter"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/internal/resolve"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// editOptions holds the changes given on the command line, before references
+// are resolved to IDs. Empty fields are left unchanged.
+type editOptions struct {
+	Title        string
+	Description  string
+	State        string
+	Assignee     string
+	Priority     string
+	Estimate     string
+	Due          string
+	Project      string
+	Cycle        string
+	Parent       string
+	AddLabels    []string
+	RemoveLabels []string
+}
+
+// NewCmdEdit creates the issue edit command
+func NewCmdEdit() *cobra.Command {
+	var opts editOptions
+	var descriptionFile string
+
+	cmd := &cobra.Command{
+		Use:   "edit <issue-id>",
+		Short: "Edit an issue",
+		Long: `Change fields of an existing issue. Only the fields given are updated.
+
+--assignee, --estimate, --due, --project, --cycle and --parent take none to
+clear the field.`,
+		Example: `  lnr issue edit ENG-123 --state "In Review" --assignee @me
+  lnr issue edit ENG-123 --assignee none --cycle none
+  lnr issue edit ENG-123 --add-label bug --remove-label triage
+  lnr issue edit ENG-123 --description-file - < notes.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if descriptionFile != "" {
+				description, err := readDescription(descriptionFile)
+				if err != nil {
+					return err
+				}
+				opts.Description = description
+			}
+			return runEdit(cmd.Context(), jsonOutput, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Title, "title", "", "New title")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "New description in markdown")
+	cmd.Flags().StringVar(&descriptionFile, "description-file", "", "Read the description from a file, or - for stdin")
+	cmd.Flags().StringVar(&opts.State, "state", "", "Workflow state name or ID")
+	cmd.Flags().StringVar(&opts.Assignee, "assignee", "", "Assignee email, name, @me or ID, or none to unassign")
+	cmd.Flags().StringVar(&opts.Priority, "priority", "", "Priority: urgent, high, medium, low, none or 0-4")
+	cmd.Flags().StringVar(&opts.Estimate, "estimate", "", "Estimate in points, or none to clear it")
+	cmd.Flags().StringVar(&opts.Due, "due", "", "Due date (YYYY-MM-DD), or none to clear it")
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Project name, slug or ID, or none to remove it from its project")
+	cmd.Flags().StringVar(&opts.Cycle, "cycle", "", "Cycle number, current, next, previous or ID, or none to remove it from its cycle")
+	cmd.Flags().StringVar(&opts.Parent, "parent", "", "Parent issue identifier (e.g., ENG-10), or none to make it top-level")
+	cmd.Flags().StringSliceVar(&opts.AddLabels, "add-label", nil, "Add a label by name or ID (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.RemoveLabels, "remove-label", nil, "Remove a label by name or ID (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("description", "description-file")
+
+	return cmd
+}
+
+func runEdit(ctx context.Context, jsonOutput bool, issueID string, opts editOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runEditWithFactory(factory, issueID, opts)
+}
+
+func runEditWithFactory(factory *cmdutil.Factory, issueID string, opts editOptions) error {
+	ctx := factory.Context
+	before, err := factory.Client.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	input, err := buildUpdateInput(ctx, factory, before, opts)
+	if err != nil {
+		return err
+	}
+
+	after, err := factory.Client.UpdateIssue(ctx, before.ID, *input)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	fields := append([]output.DetailField{
+		{Label: "Updated", Value: fmt.Sprintf("%s %s", after.Identifier, after.Title)},
+	}, issueDiff(before, after)...)
+
+	return factory.Formatter.PrintDetail(fields, after)
+}
+
+// buildUpdateInput validates opts and resolves its references into an
+// IssueUpdateInput. States, labels and cycles are scoped to the issue's team.
+func buildUpdateInput(ctx context.Context, factory *cmdutil.Factory, issue *api.Issue, opts editOptions) (*api.IssueUpdateInput, error) {
+	input := &api.IssueUpdateInput{}
+	changed := false
+
+	var teamID *string
+	if issue.Team != nil {
+		teamID = &issue.Team.ID
+	}
+
+	if opts.Title != "" {
+		title := strings.TrimSpace(opts.Title)
+		input.Title = &title
+		changed = true
+	}
+
+	if opts.Description != "" {
+		input.Description = &opts.Description
+		changed = true
+	}
+
+	if opts.State != "" {
+		state, err := factory.Resolver.State(ctx, opts.State, teamID)
+		if err != nil {
+			return nil, err
+		}
+		input.StateID = &state.ID
+		changed = true
+	}
+
+	if isNone(opts.Assignee) {
+		input.Clear = append(input.Clear, "assigneeId")
+		changed = true
+	} else if opts.Assignee != "" {
+		user, err := factory.Resolver.User(ctx, opts.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		input.AssigneeID = &user.ID
+		changed = true
+	}
+
+	if opts.Priority != "" {
+		priority, err := filter.ParsePriority(opts.Priority)
+		if err != nil {
+			return nil, err
+		}
+		input.Priority = &priority
+		changed = true
+	}
+
+	if isNone(opts.Estimate) {
+		input.Clear = append(input.Clear, "estimate")
+		changed = true
+	} else if opts.Estimate != "" {
+		estimate, err := parseEstimate(opts.Estimate)
+		if err != nil {
+			return nil, err
+		}
+		input.Estimate = &estimate
+		changed = true
+	}
+
+	if isNone(opts.Due) {
+		input.Clear = append(input.Clear, "dueDate")
+		changed = true
+	} else if opts.Due != "" {
+		if err := validateDueDate(opts.Due); err != nil {
+			return nil, err
+		}
+		input.DueDate = &opts.Due
+		changed = true
+	}
+
+	if isNone(opts.Project) {
+		input.Clear = append(input.Clear, "projectId")
+		changed = true
+	} else if opts.Project != "" {
+		project, err := factory.Resolver.Project(ctx, opts.Project)
+		if err != nil {
+			return nil, err
+		}
+		input.ProjectID = &project.ID
+		changed = true
+	}
+
+	if isNone(opts.Cycle) {
+		input.Clear = append(input.Clear, "cycleId")
+		changed = true
+	} else if opts.Cycle != "" {
+		if teamID == nil {
+			return nil, errors.New("cannot resolve a cycle for an issue without a team")
+		}
+		cycle, err := factory.Resolver.Cycle(ctx, opts.Cycle, *teamID)
+		if err != nil {
+			return nil, err
+		}
+		input.CycleID = &cycle.ID
+		changed = true
+	}
+
+	if isNone(opts.Parent) {
+		input.Clear = append(input.Clear, "parentId")
+		changed = true
+	} else if opts.Parent != "" {
+		parentID, err := resolveParent(ctx, factory, opts.Parent)
+		if err != nil {
+			return nil, err
+		}
+		input.ParentID = &parentID
+		changed = true
+	}
+
+	for _, ref := range opts.AddLabels {
+		label, err := factory.Resolver.Label(ctx, ref, teamID)
+		if err != nil {
+			return nil, err
+		}
+		input.AddedLabelIDs = append(input.AddedLabelIDs, label.ID)
+		changed = true
+	}
+
+	for _, ref := range opts.RemoveLabels {
+		label, err := factory.Resolver.Label(ctx, ref, teamID)
+		if err != nil {
+			return nil, err
+		}
+		for _, added := range input.AddedLabelIDs {
+			if added == label.ID {
+				return nil, fmt.Errorf("label %q is both added and removed", ref)
+			}
+		}
+		input.RemovedLabelIDs = append(input.RemovedLabelIDs, label.ID)
+		changed = true
+	}
+
+	if !changed {
+		return nil, errors.New("nothing to update (see lnr issue edit --help for the fields that can be changed)")
+	}
+
+	return input, nil
+}
+
+// isNone reports whether a flag value asks for a field to be cleared
+func isNone(value string) bool {
+	return strings.EqualFold(value, "none")
+}
+
+// resolveParent returns the ID of the parent issue ref, looking it up
+// unless it already is one
+func resolveParent(ctx context.Context, factory *cmdutil.Factory, ref string) (string, error) {
+	if resolve.IsID(ref) {
+		return ref, nil
+	}
+	parent, err := factory.Client.GetIssue(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to get parent issue: %w", err)
+	}
+	return parent.ID, nil
+}
+
+// issueDiff returns a "before → after" field for each displayed field that
+// differs between the two versions of an issue
+func issueDiff(before, after *api.Issue) []output.DetailField {
+	var fields []output.DetailField
+	add := func(label, from, to string) {
+		if from != to {
+			fields = append(fields, output.DetailField{Label: label, Value: fmt.Sprintf("%s → %s", from, to)})
+		}
+	}
+
+	add("Title", before.Title, after.Title)
+	if before.Description != after.Description {
+		fields = append(fields, output.DetailField{Label: "Description", Value: "(changed)"})
+	}
+	add("State", displayState(before.State), displayState(after.State))
+	add("Assignee", displayUser(before.Assignee), displayUser(after.Assignee))
+	add("Priority", output.PriorityLabel(before.Priority), output.PriorityLabel(after.Priority))
+	add("Estimate", displayEstimate(before.Estimate), displayEstimate(after.Estimate))
+	add("Due", displayDue(before.DueDate), displayDue(after.DueDate))
+	add("Project", displayProject(before.Project), displayProject(after.Project))
+	add("Cycle", displayCycle(before.Cycle), displayCycle(after.Cycle))
+	add("Parent", displayParent(before.Parent), displayParent(after.Parent))
+	add("Labels", displayLabels(before.Labels), displayLabels(after.Labels))
+
+	return fields
+}
+
+func displayState(s *api.WorkflowState) string {
+	if s == nil {
+		return "-"
+	}
+	return s.Name
+}
+
+func displayUser(u *api.User) string {
+	if u == nil {
+		return "-"
+	}
+	return u.Name
+}
+
+func displayEstimate(e *float64) string {
+	if e == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*e, 'f', -1, 64)
+}
+
+func displayDue(d *string) string {
+	if d == nil {
+		return "-"
+	}
+	return *d
+}
+
+func displayProject(p *api.Project) string {
+	if p == nil {
+		return "-"
+	}
+	return p.Name
+}
+
+func displayCycle(c *api.Cycle) string {
+	if c == nil {
+		return "-"
+	}
+	if c.Name != "" {
+		return c.Name
+	}
+	return fmt.Sprintf("Cycle %d", c.Number)
+}
+
+func displayParent(p *api.Issue) string {
+	if p == nil {
+		return "-"
+	}
+	return p.Identifier
+}
+
+func displayLabels(labels []api.Label) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return strings.Join(names, ", ")
+}
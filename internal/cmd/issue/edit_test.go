@@ -0,0 +1,165 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func newEditMockClient(updated *api.IssueUpdateInput) *api.MockClient {
+	engTeam := api.Team{ID: "team-eng", Key: "ENG", Name: "Engineering"}
+	before := &api.Issue{
+		ID:         "issue-1",
+		Identifier: "ENG-123",
+		Title:      "Fix login",
+		Priority:   3,
+		Team:       &engTeam,
+		State:      &api.WorkflowState{ID: "state-todo", Name: "Todo"},
+		Labels:     []api.Label{{ID: "label-triage", Name: "triage"}},
+	}
+
+	return &api.MockClient{
+		GetIssueFunc: func(ctx context.Context, id string) (*api.Issue, error) {
+			return before, nil
+		},
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			return []api.WorkflowState{
+				{ID: "state-todo", Name: "Todo", Team: &engTeam},
+				{ID: "state-review", Name: "In Review", Team: &engTeam},
+				{ID: "state-other-review", Name: "In Review", Team: &api.Team{ID: "team-des", Key: "DES"}},
+			}, nil
+		},
+		GetLabelsFunc: func(ctx context.Context, opts api.LabelListOptions) ([]api.Label, error) {
+			return []api.Label{
+				{ID: "label-bug", Name: "bug"},
+				{ID: "label-triage", Name: "triage"},
+			}, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, id string, input api.IssueUpdateInput) (*api.Issue, error) {
+			*updated = input
+			after := *before
+			after.State = &api.WorkflowState{ID: "state-review", Name: "In Review"}
+			after.Priority = 2
+			after.Labels = []api.Label{{ID: "label-bug", Name: "bug"}}
+			return &after, nil
+		},
+	}
+}
+
+func TestRunEditWithFactory(t *testing.T) {
+	var updated api.IssueUpdateInput
+	factory := cmdutil.NewFactoryWithClient(newEditMockClient(&updated), false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runEditWithFactory(factory, "ENG-123", editOptions{
+		State:        "in review",
+		Priority:     "High",
+		AddLabels:    []string{"bug"},
+		RemoveLabels: []string{"triage"},
+	})
+
+	require.NoError(t, err)
+	// The state is resolved within the issue's team
+	assert.Equal(t, "state-review", *updated.StateID)
+	assert.Equal(t, 2, *updated.Priority)
+	assert.Equal(t, []string{"label-bug"}, updated.AddedLabelIDs)
+	assert.Equal(t, []string{"label-triage"}, updated.RemovedLabelIDs)
+	assert.Nil(t, updated.Title)
+
+	out := buf.String()
+	assert.Contains(t, out, "Todo → In Review")
+	assert.Contains(t, out, "Medium → High")
+	assert.Contains(t, out, "triage → bug")
+	assert.NotContains(t, out, "Title:")
+}
+
+func TestRunEditWithFactory_Clear(t *testing.T) {
+	due := "2026-11-01"
+	before := &api.Issue{
+		ID:         "issue-1",
+		Identifier: "ENG-123",
+		Title:      "Fix login",
+		Assignee:   &api.User{ID: "user-ada", Name: "Ada Lovelace"},
+		Project:    &api.Project{ID: "project-1", Name: "Public launch"},
+		DueDate:    &due,
+	}
+	var updated api.IssueUpdateInput
+	client := &api.MockClient{
+		GetIssueFunc: func(ctx context.Context, id string) (*api.Issue, error) {
+			return before, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, id string, input api.IssueUpdateInput) (*api.Issue, error) {
+			updated = input
+			after := *before
+			after.Assignee, after.Project, after.DueDate = nil, nil, nil
+			return &after, nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(client, false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runEditWithFactory(factory, "ENG-123", editOptions{Assignee: "none", Estimate: "none", Project: "None", Due: "none", Cycle: "none", Parent: "none"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"assigneeId", "estimate", "dueDate", "projectId", "cycleId", "parentId"}, updated.Clear)
+	assert.Nil(t, updated.AssigneeID)
+	assert.Nil(t, updated.Estimate)
+	assert.Contains(t, buf.String(), "Ada Lovelace → -")
+	assert.Contains(t, buf.String(), "Public launch → -")
+	assert.Contains(t, buf.String(), "2026-11-01 → -")
+}
+
+func TestRunEditWithFactory_JSONOutput(t *testing.T) {
+	var updated api.IssueUpdateInput
+	factory := cmdutil.NewFactoryWithClient(newEditMockClient(&updated), true)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runEditWithFactory(factory, "ENG-123", editOptions{Priority: "2"})
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"identifier": "ENG-123"`)
+	assert.Contains(t, buf.String(), `"name": "In Review"`)
+}
+
+func TestRunEditWithFactory_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    editOptions
+		wantErr string
+	}{
+		{
+			name:    "no changes",
+			opts:    editOptions{},
+			wantErr: "nothing to update (see lnr issue edit --help for the fields that can be changed)",
+		},
+		{
+			name:    "label added and removed",
+			opts:    editOptions{AddLabels: []string{"bug"}, RemoveLabels: []string{"bug"}},
+			wantErr: `label "bug" is both added and removed`,
+		},
+		{
+			name:    "invalid estimate",
+			opts:    editOptions{Estimate: "lots"},
+			wantErr: `invalid estimate "lots" (expected a whole number of points)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated api.IssueUpdateInput
+			factory := cmdutil.NewFactoryWithClient(newEditMockClient(&updated), false)
+
+			err := runEditWithFactory(factory, "ENG-123", tt.opts)
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -8,13 +8,26 @@ import (
 func NewCmdIssue() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "issue",
-		Short: "View issues",
-		Long:  "Commands for viewing Linear issues.",
+		Short: "Manage issues",
+		Long:  "Commands for viewing, creating and editing Linear issues.",
 	}
 
 	cmd.AddCommand(NewCmdList())
 	cmd.AddCommand(NewCmdView())
 	cmd.AddCommand(NewCmdSearch())
+	cmd.AddCommand(NewCmdCreate())
+	cmd.AddCommand(NewCmdEdit())
+	cmd.AddCommand(NewCmdComment())
+	cmd.AddCommand(NewCmdRelate())
+	cmd.AddCommand(NewCmdUnrelate())
+	cmd.AddCommand(NewCmdTree())
+	cmd.AddCommand(NewCmdBranch())
+	cmd.AddCommand(NewCmdStart())
+	cmd.AddCommand(NewCmdClose())
+	cmd.AddCommand(NewCmdReopen())
+	cmd.AddCommand(NewCmdCancel())
+	cmd.AddCommand(NewCmdMove())
+	cmd.AddCommand(NewCmdBulkEdit())
 
 	return cmd
 }
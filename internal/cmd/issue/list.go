@@ -6,68 +6,189 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/filter"
 	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/internal/resolve"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
+// queryHelp documents the --query syntax for commands that accept it
+const queryHelp = `Queries:
+  --query narrows results with a filter expression. Terms are separated by
+  spaces and all must match; use OR and parentheses to combine alternatives,
+  and - or NOT to negate. Separate values with commas to match any of them.
+
+    assignee:@me              assigned to you (also: a name or email, none)
+    creator:alice@example.com created by a user
+    state:started,unstarted   state type or name, e.g. state:"In Review"
+    team:ENG                  team key or name
+    project:Infra             project name or slug (also: none)
+    cycle:current             cycle number, current, next or previous
+    label:bug                 has a label
+    priority<=2               urgent or high (also: urgent, high, medium, low)
+    estimate>=3               estimate comparison (also: none)
+    updated>7d                updated in the last 7 days (h, d, w, m, y)
+    created:2026-01-31        created on a day (also <, <=, >, >=)
+    due<2w                    due within the next two weeks
+    completed>30d             completed in the last 30 days
+    title:login               title contains text; bare words do the same
+
+  Example:
+    lnr issue list -q 'assignee:@me state:started,unstarted label:bug priority<=2 updated>7d -project:Infra'`
+
+// listFlags holds the issue filter flags shared by list and bulk-edit
+type listFlags struct {
+	team     string
+	assignee string
+	state    string
+	project  string
+	query    string
+	limit    int
+	all      bool
+}
+
+// register adds the filter flags to cmd
+func (f *listFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.team, "team", "", "Filter by team key, name or ID")
+	cmd.Flags().StringVar(&f.assignee, "assignee", "", "Filter by assignee email, name, @me or ID")
+	cmd.Flags().StringVar(&f.state, "state", "", "Filter by workflow state name or ID; without --team a name matches in every team")
+	cmd.Flags().StringVar(&f.project, "project", "", "Filter by project name, slug or ID")
+	cmd.Flags().StringVarP(&f.query, "query", "q", "", "Filter issues with a query expression")
+	cmd.Flags().IntVar(&f.limit, "limit", 50, "Maximum number of issues to return")
+	cmd.Flags().BoolVar(&f.all, "all", false, "Fetch all issues, ignoring --limit")
+}
+
+// options converts the flags into list options, parsing --query. References
+// are left for resolveListOptions.
+func (f *listFlags) options() (api.IssueListOptions, error) {
+	opts := api.IssueListOptions{Limit: f.limit, All: f.all}
+	if f.query != "" {
+		parsed, err := filter.ParseIssueFilter(f.query)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filter = parsed
+	}
+	if f.team != "" {
+		opts.TeamID = &f.team
+	}
+	if f.assignee != "" {
+		opts.AssigneeID = &f.assignee
+	}
+	if f.state != "" {
+		opts.StateID = &f.state
+	}
+	if f.project != "" {
+		opts.ProjectID = &f.project
+	}
+	return opts, nil
+}
+
 // NewCmdList creates the issue list command
 func NewCmdList() *cobra.Command {
-	var teamID string
-	var assigneeID string
-	var stateID string
-	var projectID string
-	var limit int
+	var flags listFlags
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List issues",
-		Long:  "List issues in the Linear workspace with optional filters.",
+		Long: `List issues in the Linear workspace with optional filters.
+
+The team and project settings, such as those in a repository's .lnr.yaml,
+filter the list when --team and --project are not given. Pass --project ""
+to list issues from every project.
+
+` + queryHelp,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			opts := api.IssueListOptions{First: limit}
-			if teamID != "" {
-				opts.TeamID = &teamID
+			opts, err := flags.options()
+			if err != nil {
+				return err
 			}
-			if assigneeID != "" {
-				opts.AssigneeID = &assigneeID
-			}
-			if stateID != "" {
-				opts.StateID = &stateID
-			}
-			if projectID != "" {
-				opts.ProjectID = &projectID
-			}
-			return runList(jsonOutput, opts)
+			return runList(cmd.Context(), jsonOutput, opts)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true", cmdutil.AnnotationContext: "true"},
 	}
 
-	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team ID")
-	cmd.Flags().StringVar(&assigneeID, "assignee", "", "Filter by assignee ID")
-	cmd.Flags().StringVar(&stateID, "state", "", "Filter by state ID")
-	cmd.Flags().StringVar(&projectID, "project", "", "Filter by project ID")
-	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of issues to return")
+	flags.register(cmd)
 
 	return cmd
 }
 
-func runList(jsonOutput bool, opts api.IssueListOptions) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.IssueListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
+	if err := resolveListOptions(ctx, factory, &opts); err != nil {
+		return err
+	}
 	return runListWithFactory(factory, opts)
 }
 
-func runListWithFactory(factory *cmdutil.Factory, opts api.IssueListOptions) error {
-	ctx := context.Background()
-	issues, err := factory.Client.GetIssues(ctx, opts)
+// resolveListOptions replaces the team, assignee, state and project
+// references in opts with IDs. States are scoped to the team when one is set.
+func resolveListOptions(ctx context.Context, factory *cmdutil.Factory, opts *api.IssueListOptions) error {
+	teamID, err := factory.Resolver.TeamID(ctx, opts.TeamID)
 	if err != nil {
-		return fmt.Errorf("failed to list issues: %w", err)
+		return err
+	}
+	opts.TeamID = teamID
+
+	if opts.AssigneeID != nil {
+		user, err := factory.Resolver.User(ctx, *opts.AssigneeID)
+		if err != nil {
+			return err
+		}
+		opts.AssigneeID = &user.ID
+	}
+
+	// Without a team a state name such as "Todo" can belong to several
+	// teams, so it is matched by name on the server instead
+	if opts.StateID != nil && opts.TeamID == nil && !resolve.IsID(*opts.StateID) {
+		opts.StateName, opts.StateID = opts.StateID, nil
+	}
+	if opts.StateID != nil {
+		state, err := factory.Resolver.State(ctx, *opts.StateID, opts.TeamID)
+		if err != nil {
+			return err
+		}
+		opts.StateID = &state.ID
+	}
+
+	if opts.ProjectID != nil {
+		project, err := factory.Resolver.Project(ctx, *opts.ProjectID)
+		if err != nil {
+			return err
+		}
+		opts.ProjectID = &project.ID
+	}
+
+	return nil
+}
+
+func runListWithFactory(factory *cmdutil.Factory, opts api.IssueListOptions) error {
+	ctx := factory.Context
+	// An interrupt or timeout part way through still returns the issues
+	// fetched so far, which are printed before failing
+	issues, listErr := factory.Client.GetIssues(ctx, opts)
+	if listErr != nil && len(issues) == 0 {
+		return fmt.Errorf("failed to list issues: %w", listErr)
 	}
 
 	// Warn if results might be truncated
-	output.WarnIfTruncated(len(issues), opts.First)
+	if !opts.All {
+		output.WarnIfTruncated(len(issues), opts.Limit)
+	}
+
+	headers, rows := issueRows(issues)
+	if err := factory.Formatter.Print(headers, rows, issues); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(issues), "issues")
+}
 
+// issueRows returns the table used to list issues
+func issueRows(issues []api.Issue) ([]string, [][]string) {
 	headers := []string{"ID", "TITLE", "STATE", "ASSIGNEE", "PRIORITY"}
 	rows := make([][]string, len(issues))
 	for i, issue := range issues {
@@ -88,5 +209,5 @@ func runListWithFactory(factory *cmdutil.Factory, opts api.IssueListOptions) err
 		}
 	}
 
-	return factory.Formatter.Print(headers, rows, issues)
+	return headers, rows
 }
@@ -3,6 +3,7 @@ package issue
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,12 +14,12 @@ import (
 
 func TestRunListWithFactory(t *testing.T) {
 	tests := []struct {
-		name        string
-		mockIssues  []api.Issue
-		mockError   error
-		opts        api.IssueListOptions
-		wantErr     bool
-		wantOutput  string
+		name       string
+		mockIssues []api.Issue
+		mockError  error
+		opts       api.IssueListOptions
+		wantErr    bool
+		wantOutput string
 	}{
 		{
 			name: "lists issues successfully",
@@ -38,21 +39,21 @@ func TestRunListWithFactory(t *testing.T) {
 					Assignee:   nil,
 				},
 			},
-			opts:       api.IssueListOptions{First: 50},
+			opts:       api.IssueListOptions{Limit: 50},
 			wantErr:    false,
 			wantOutput: "ENG-123",
 		},
 		{
 			name:       "handles empty results",
 			mockIssues: []api.Issue{},
-			opts:       api.IssueListOptions{First: 50},
+			opts:       api.IssueListOptions{Limit: 50},
 			wantErr:    false,
 			wantOutput: "No results found",
 		},
 		{
 			name:      "handles API error",
 			mockError: assert.AnError,
-			opts:      api.IssueListOptions{First: 50},
+			opts:      api.IssueListOptions{Limit: 50},
 			wantErr:   true,
 		},
 	}
@@ -90,6 +91,30 @@ func TestRunListWithFactory(t *testing.T) {
 	}
 }
 
+func TestRunListWithFactory_Interrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockClient := &api.MockClient{
+		GetIssuesFunc: func(ctx context.Context, opts api.IssueListOptions) ([]api.Issue, error) {
+			// The first page arrived before the interrupt
+			return []api.Issue{{Identifier: "ENG-1", Title: "Fetched"}}, ctx.Err()
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, true)
+	factory.Context = ctx
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runListWithFactory(factory, api.IssueListOptions{All: true})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "stopped after 1 issues: context canceled")
+	var issues []api.Issue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &issues))
+	assert.Equal(t, "ENG-1", issues[0].Identifier)
+}
+
 func TestRunListWithFactory_WithFilters(t *testing.T) {
 	teamID := "team-123"
 	assigneeID := "user-456"
@@ -117,7 +142,7 @@ func TestRunListWithFactory_WithFilters(t *testing.T) {
 	opts := api.IssueListOptions{
 		TeamID:     &teamID,
 		AssigneeID: &assigneeID,
-		First:      50,
+		Limit:      50,
 	}
 
 	err := runListWithFactory(factory, opts)
@@ -143,7 +168,7 @@ func TestRunListWithFactory_JSONOutput(t *testing.T) {
 	var buf bytes.Buffer
 	factory.Formatter.SetWriter(&buf)
 
-	err := runListWithFactory(factory, api.IssueListOptions{First: 50})
+	err := runListWithFactory(factory, api.IssueListOptions{Limit: 50})
 	require.NoError(t, err)
 
 	// Verify JSON output contains expected fields
@@ -151,3 +176,56 @@ func TestRunListWithFactory_JSONOutput(t *testing.T) {
 	assert.Contains(t, output, `"identifier"`)
 	assert.Contains(t, output, `"ENG-123"`)
 }
+
+func TestResolveListOptions(t *testing.T) {
+	engTeam := api.Team{ID: "team-eng", Key: "ENG", Name: "Engineering"}
+	designTeam := api.Team{ID: "team-des", Key: "DES", Name: "Design"}
+
+	mockClient := &api.MockClient{
+		GetViewerFunc: func(ctx context.Context) (*api.User, error) {
+			return &api.User{ID: "user-me"}, nil
+		},
+		GetTeamsFunc: func(ctx context.Context, opts api.ListOptions) ([]api.Team, error) {
+			return []api.Team{engTeam, designTeam}, nil
+		},
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			return []api.WorkflowState{
+				{ID: "state-eng-todo", Name: "Todo", Team: &engTeam},
+				{ID: "state-des-todo", Name: "Todo", Team: &designTeam},
+			}, nil
+		},
+		GetProjectsFunc: func(ctx context.Context, opts api.ProjectListOptions) ([]api.Project, error) {
+			return []api.Project{{ID: "project-launch", Name: "Q3 Launch"}}, nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+
+	team, assignee, state, project := "ENG", "@me", "todo", "Q3 Launch"
+	opts := api.IssueListOptions{TeamID: &team, AssigneeID: &assignee, StateID: &state, ProjectID: &project}
+
+	err := resolveListOptions(context.Background(), factory, &opts)
+	require.NoError(t, err)
+	assert.Equal(t, "team-eng", *opts.TeamID)
+	assert.Equal(t, "user-me", *opts.AssigneeID)
+	assert.Equal(t, "state-eng-todo", *opts.StateID)
+	assert.Equal(t, "project-launch", *opts.ProjectID)
+}
+
+func TestResolveListOptions_StateWithoutTeam(t *testing.T) {
+	mockClient := &api.MockClient{
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			t.Fatal("states should not be looked up without a team")
+			return nil, nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+
+	state := "In Progress"
+	opts := api.IssueListOptions{StateID: &state}
+
+	err := resolveListOptions(context.Background(), factory, &opts)
+	require.NoError(t, err)
+	assert.Nil(t, opts.StateID)
+	require.NotNil(t, opts.StateName)
+	assert.Equal(t, "In Progress", *opts.StateName)
+}
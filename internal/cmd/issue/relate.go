@@ -0,0 +1,116 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// relateOptions holds the relation flags for issue relate. Exactly one is set.
+type relateOptions struct {
+	Blocks      string
+	BlockedBy   string
+	Related     string
+	DuplicateOf string
+}
+
+// NewCmdRelate creates the issue relate command
+func NewCmdRelate() *cobra.Command {
+	var opts relateOptions
+
+	cmd := &cobra.Command{
+		Use:   "relate <issue-id>",
+		Short: "Link an issue to another issue",
+		Long:  "Mark an issue as blocking, blocked by, related to or a duplicate of another issue.",
+		Example: `  lnr issue relate ENG-1 --blocks ENG-2
+  lnr issue relate ENG-1 --blocked-by ENG-3
+  lnr issue relate ENG-4 --duplicate-of ENG-1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runRelate(cmd.Context(), jsonOutput, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Blocks, "blocks", "", "Issue that this issue blocks")
+	cmd.Flags().StringVar(&opts.BlockedBy, "blocked-by", "", "Issue that blocks this issue")
+	cmd.Flags().StringVar(&opts.Related, "related", "", "Issue that is related to this issue")
+	cmd.Flags().StringVar(&opts.DuplicateOf, "duplicate-of", "", "Issue that this issue duplicates")
+	cmd.MarkFlagsMutuallyExclusive("blocks", "blocked-by", "related", "duplicate-of")
+	cmd.MarkFlagsOneRequired("blocks", "blocked-by", "related", "duplicate-of")
+
+	return cmd
+}
+
+func runRelate(ctx context.Context, jsonOutput bool, issueID string, opts relateOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runRelateWithFactory(factory, issueID, opts)
+}
+
+func runRelateWithFactory(factory *cmdutil.Factory, issueID string, opts relateOptions) error {
+	// Blocked by is stored as a blocks relation on the other issue
+	source, target, relationType := issueID, "", ""
+	switch {
+	case opts.Blocks != "":
+		target, relationType = opts.Blocks, api.RelationBlocks
+	case opts.BlockedBy != "":
+		source, target, relationType = opts.BlockedBy, issueID, api.RelationBlocks
+	case opts.Related != "":
+		target, relationType = opts.Related, api.RelationRelated
+	case opts.DuplicateOf != "":
+		target, relationType = opts.DuplicateOf, api.RelationDuplicate
+	default:
+		return errors.New("one of --blocks, --blocked-by, --related or --duplicate-of is required")
+	}
+
+	ctx := factory.Context
+	from, err := factory.Client.GetIssue(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", source, err)
+	}
+	to, err := factory.Client.GetIssue(ctx, target)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", target, err)
+	}
+	if from.ID == to.ID {
+		return errors.New("an issue cannot be related to itself")
+	}
+
+	relation, err := factory.Client.CreateIssueRelation(ctx, api.IssueRelationCreateInput{
+		IssueID:        from.ID,
+		RelatedIssueID: to.ID,
+		Type:           relationType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to relate issues: %w", err)
+	}
+
+	fields := []output.DetailField{
+		{Label: "Related", Value: fmt.Sprintf("%s %s %s", from.Identifier, relationVerb(relationType), to.Identifier)},
+	}
+	return factory.Formatter.PrintDetail(fields, relation)
+}
+
+// relationVerb describes a relation type in a sentence, e.g. "blocks"
+func relationVerb(relationType string) string {
+	switch relationType {
+	case api.RelationBlocks:
+		return "blocks"
+	case api.RelationBlockedBy:
+		return "is blocked by"
+	case api.RelationDuplicate:
+		return "duplicates"
+	case api.RelationDuplicatedBy:
+		return "is duplicated by"
+	default:
+		return "is related to"
+	}
+}
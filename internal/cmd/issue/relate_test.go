@@ -0,0 +1,156 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// issuesByIdentifier serves GetIssue from a fixed set of issues
+func issuesByIdentifier(issues ...*api.Issue) func(ctx context.Context, id string) (*api.Issue, error) {
+	return func(ctx context.Context, id string) (*api.Issue, error) {
+		for _, issue := range issues {
+			if issue.Identifier == id || issue.ID == id {
+				return issue, nil
+			}
+		}
+		return nil, assert.AnError
+	}
+}
+
+func TestRunRelateWithFactory(t *testing.T) {
+	eng1 := &api.Issue{ID: "issue-1", Identifier: "ENG-1"}
+	eng2 := &api.Issue{ID: "issue-2", Identifier: "ENG-2"}
+
+	tests := []struct {
+		name       string
+		opts       relateOptions
+		want       api.IssueRelationCreateInput
+		wantOutput string
+	}{
+		{
+			name:       "blocks",
+			opts:       relateOptions{Blocks: "ENG-2"},
+			want:       api.IssueRelationCreateInput{IssueID: "issue-1", RelatedIssueID: "issue-2", Type: api.RelationBlocks},
+			wantOutput: "ENG-1 blocks ENG-2",
+		},
+		{
+			name:       "blocked by is stored on the blocker",
+			opts:       relateOptions{BlockedBy: "ENG-2"},
+			want:       api.IssueRelationCreateInput{IssueID: "issue-2", RelatedIssueID: "issue-1", Type: api.RelationBlocks},
+			wantOutput: "ENG-2 blocks ENG-1",
+		},
+		{
+			name:       "duplicate of",
+			opts:       relateOptions{DuplicateOf: "ENG-2"},
+			want:       api.IssueRelationCreateInput{IssueID: "issue-1", RelatedIssueID: "issue-2", Type: api.RelationDuplicate},
+			wantOutput: "ENG-1 duplicates ENG-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created api.IssueRelationCreateInput
+			mockClient := &api.MockClient{
+				GetIssueFunc: issuesByIdentifier(eng1, eng2),
+				CreateIssueRelationFunc: func(ctx context.Context, input api.IssueRelationCreateInput) (*api.IssueRelation, error) {
+					created = input
+					return &api.IssueRelation{ID: "rel-1", Type: input.Type}, nil
+				},
+			}
+			factory := cmdutil.NewFactoryWithClient(mockClient, false)
+			var buf bytes.Buffer
+			factory.Formatter.SetWriter(&buf)
+
+			err := runRelateWithFactory(factory, "ENG-1", tt.opts)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, created)
+			assert.Contains(t, buf.String(), tt.wantOutput)
+		})
+	}
+}
+
+func TestRunRelateWithFactory_Self(t *testing.T) {
+	eng1 := &api.Issue{ID: "issue-1", Identifier: "ENG-1"}
+	factory := cmdutil.NewFactoryWithClient(&api.MockClient{GetIssueFunc: issuesByIdentifier(eng1)}, false)
+
+	err := runRelateWithFactory(factory, "ENG-1", relateOptions{Related: "issue-1"})
+
+	assert.EqualError(t, err, "an issue cannot be related to itself")
+}
+
+func TestRunUnrelateWithFactory(t *testing.T) {
+	eng2 := &api.Issue{ID: "issue-2", Identifier: "ENG-2"}
+	eng3 := &api.Issue{ID: "issue-3", Identifier: "ENG-3"}
+	eng1 := &api.Issue{
+		ID:         "issue-1",
+		Identifier: "ENG-1",
+		Relations: []api.IssueRelation{
+			{ID: "rel-1", Type: api.RelationBlocks, Issue: eng2},
+			{ID: "rel-2", Type: api.RelationRelated, Issue: eng3},
+			{ID: "rel-3", Type: api.RelationBlockedBy, Issue: eng2},
+		},
+	}
+
+	var deleted []string
+	mockClient := &api.MockClient{
+		GetIssueFunc: issuesByIdentifier(eng1, eng2, eng3),
+		DeleteIssueRelationFunc: func(ctx context.Context, id string) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	require.NoError(t, runUnrelateWithFactory(factory, "ENG-1", "ENG-2"))
+	assert.Equal(t, []string{"rel-1", "rel-3"}, deleted)
+	assert.Contains(t, buf.String(), "Removed 2 relation(s) between ENG-1 and ENG-2")
+
+	err := runUnrelateWithFactory(factory, "ENG-2", "ENG-3")
+	assert.EqualError(t, err, "ENG-2 has no relation to ENG-3")
+}
+
+func TestRunTreeWithFactory(t *testing.T) {
+	todo := &api.WorkflowState{Name: "Todo"}
+	done := &api.WorkflowState{Name: "Done"}
+	children := map[string][]api.Issue{
+		"issue-1": {
+			{ID: "issue-2", Identifier: "ENG-2", Title: "Backend", State: done},
+			{ID: "issue-3", Identifier: "ENG-3", Title: "Frontend", State: todo},
+		},
+		"issue-2": {
+			{ID: "issue-4", Identifier: "ENG-4", Title: "Schema", State: done},
+			// A cycle back to the root is ignored
+			{ID: "issue-1", Identifier: "ENG-1", Title: "Epic", State: todo},
+		},
+	}
+
+	mockClient := &api.MockClient{
+		GetIssueFunc: issuesByIdentifier(&api.Issue{ID: "issue-1", Identifier: "ENG-1", Title: "Epic", State: todo}),
+		GetSubIssuesFunc: func(ctx context.Context, id string) ([]api.Issue, error) {
+			return children[id], nil
+		},
+	}
+	factory := cmdutil.NewFactoryWithClient(mockClient, false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	require.NoError(t, runTreeWithFactory(factory, "ENG-1", 0))
+	assert.Equal(t, `ENG-1 Epic [Todo]
+├── ENG-2 Backend [Done]
+│   └── ENG-4 Schema [Done]
+└── ENG-3 Frontend [Todo]
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, runTreeWithFactory(factory, "ENG-1", 1))
+	assert.NotContains(t, buf.String(), "ENG-4")
+}
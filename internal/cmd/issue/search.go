@@ -6,65 +6,63 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/filter"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
 // NewCmdSearch creates the issue search command
 func NewCmdSearch() *cobra.Command {
+	var filterQuery string
 	var limit int
+	var all bool
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
 		Short: "Search issues",
-		Long:  "Search for issues matching the given query.",
+		Long:  "Search for issues whose title matches the given text.\n\n" + queryHelp,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			opts := api.IssueListOptions{First: limit}
-			return runSearch(jsonOutput, args[0], opts)
+			opts := api.IssueListOptions{Limit: limit, All: all}
+			if filterQuery != "" {
+				f, err := filter.ParseIssueFilter(filterQuery)
+				if err != nil {
+					return err
+				}
+				opts.Filter = f
+			}
+			return runSearch(cmd.Context(), jsonOutput, args[0], opts)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
+	cmd.Flags().StringVarP(&filterQuery, "query", "q", "", "Narrow results with a query expression")
 	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of issues to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all matching issues, ignoring --limit")
 
 	return cmd
 }
 
-func runSearch(jsonOutput bool, query string, opts api.IssueListOptions) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runSearch(ctx context.Context, jsonOutput bool, query string, opts api.IssueListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	issues, err := factory.Client.SearchIssues(ctx, query, opts)
-	if err != nil {
-		return fmt.Errorf("failed to search issues: %w", err)
+	issues, listErr := factory.Client.SearchIssues(ctx, query, opts)
+	if listErr != nil && len(issues) == 0 {
+		return fmt.Errorf("failed to search issues: %w", listErr)
 	}
 
 	// Warn if results might be truncated
-	output.WarnIfTruncated(len(issues), opts.First)
-
-	headers := []string{"ID", "TITLE", "STATE", "ASSIGNEE", "PRIORITY"}
-	rows := make([][]string, len(issues))
-	for i, issue := range issues {
-		stateName := "-"
-		if issue.State != nil {
-			stateName = issue.State.Name
-		}
-		assigneeName := "-"
-		if issue.Assignee != nil {
-			assigneeName = issue.Assignee.Name
-		}
-		rows[i] = []string{
-			issue.Identifier,
-			output.Truncate(issue.Title, 50),
-			stateName,
-			assigneeName,
-			output.PriorityLabel(issue.Priority),
-		}
+	if !opts.All {
+		output.WarnIfTruncated(len(issues), opts.Limit)
 	}
 
-	return factory.Formatter.Print(headers, rows, issues)
+	headers, rows := issueRows(issues)
+	if err := factory.Formatter.Print(headers, rows, issues); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(issues), "issues")
 }
@@ -0,0 +1,197 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// targetState picks the state to move an issue to
+type targetState func(ctx context.Context, factory *cmdutil.Factory, issue *api.Issue) (*api.WorkflowState, error)
+
+// transitionResult is the outcome of moving a single issue
+type transitionResult struct {
+	Issue string `json:"issue"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+	Moved bool   `json:"moved"`
+	Error string `json:"error,omitempty"`
+}
+
+// NewCmdStart creates the issue start command
+func NewCmdStart() *cobra.Command {
+	return newTransitionCmd("start", "Move issues to their team's first started state", "started")
+}
+
+// NewCmdClose creates the issue close command
+func NewCmdClose() *cobra.Command {
+	return newTransitionCmd("close", "Move issues to their team's first completed state", "completed")
+}
+
+// NewCmdReopen creates the issue reopen command
+func NewCmdReopen() *cobra.Command {
+	return newTransitionCmd("reopen", "Move issues to their team's first unstarted state", "unstarted")
+}
+
+// NewCmdCancel creates the issue cancel command
+func NewCmdCancel() *cobra.Command {
+	return newTransitionCmd("cancel", "Move issues to their team's first canceled state", "canceled")
+}
+
+// newTransitionCmd creates a command that moves issues to the first state of
+// stateType in each issue's team workflow
+func newTransitionCmd(name, short, stateType string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   name + " <issue-id>...",
+		Short: short,
+		Long: short + `.
+
+Each issue is moved independently; failures are reported per issue without
+stopping the rest.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runTransition(cmd.Context(), jsonOutput, args, stateOfType(stateType))
+		},
+	}
+
+	return cmd
+}
+
+// NewCmdMove creates the issue move command
+func NewCmdMove() *cobra.Command {
+	var state string
+
+	cmd := &cobra.Command{
+		Use:   "move <issue-id>... --state <state>",
+		Short: "Move issues to a workflow state",
+		Long: `Move issues to a workflow state by name or ID, looked up in each issue's team.
+
+Each issue is moved independently; failures are reported per issue without
+stopping the rest.`,
+		Example: `  lnr issue move ENG-1 ENG-2 --state "In Review"`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runTransition(cmd.Context(), jsonOutput, args, stateNamed(state))
+		},
+	}
+
+	cmd.Flags().StringVar(&state, "state", "", "Workflow state name or ID")
+	_ = cmd.MarkFlagRequired("state")
+
+	return cmd
+}
+
+// stateOfType targets the first state of the given type in the issue's team
+func stateOfType(stateType string) targetState {
+	return func(ctx context.Context, factory *cmdutil.Factory, issue *api.Issue) (*api.WorkflowState, error) {
+		if issue.Team == nil {
+			return nil, fmt.Errorf("%s has no team", issue.Identifier)
+		}
+		return factory.Resolver.StateOfType(ctx, issue.Team.ID, stateType)
+	}
+}
+
+// stateNamed targets a state by name or ID in the issue's team
+func stateNamed(ref string) targetState {
+	return func(ctx context.Context, factory *cmdutil.Factory, issue *api.Issue) (*api.WorkflowState, error) {
+		var teamID *string
+		if issue.Team != nil {
+			teamID = &issue.Team.ID
+		}
+		return factory.Resolver.State(ctx, ref, teamID)
+	}
+}
+
+func runTransition(ctx context.Context, jsonOutput bool, issueIDs []string, target targetState) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runTransitionWithFactory(factory, issueIDs, target)
+}
+
+func runTransitionWithFactory(factory *cmdutil.Factory, issueIDs []string, target targetState) error {
+	ctx := factory.Context
+
+	results := make([]transitionResult, 0, len(issueIDs))
+	failed := 0
+	for _, id := range issueIDs {
+		result := moveIssue(ctx, factory, id, target)
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	headers := []string{"ISSUE", "FROM", "TO", "RESULT"}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		status := "unchanged"
+		switch {
+		case r.Error != "":
+			status = "error: " + r.Error
+		case r.Moved:
+			status = "moved"
+		}
+		rows[i] = []string{r.Issue, dashIfEmpty(r.From), dashIfEmpty(r.To), status}
+	}
+
+	if err := factory.Formatter.Print(headers, rows, results); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to move %d of %d issues", failed, len(issueIDs))
+	}
+	return nil
+}
+
+// moveIssue moves one issue to its target state, recording any error in the
+// result rather than returning it
+func moveIssue(ctx context.Context, factory *cmdutil.Factory, id string, target targetState) transitionResult {
+	result := transitionResult{Issue: id}
+
+	issue, err := factory.Client.GetIssue(ctx, id)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Issue = issue.Identifier
+	if issue.State != nil {
+		result.From = issue.State.Name
+	}
+
+	state, err := target(ctx, factory, issue)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.To = state.Name
+
+	if issue.State != nil && issue.State.ID == state.ID {
+		return result
+	}
+
+	updated, err := factory.Client.UpdateIssue(ctx, issue.ID, api.IssueUpdateInput{StateID: &state.ID})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Moved = true
+	if updated.State != nil {
+		result.To = updated.State.Name
+	}
+	return result
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
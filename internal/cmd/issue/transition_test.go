@@ -0,0 +1,100 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func newTransitionMockClient(updated map[string]string) *api.MockClient {
+	engTeam := api.Team{ID: "team-eng", Key: "ENG"}
+	desTeam := api.Team{ID: "team-des", Key: "DES"}
+	states := []api.WorkflowState{
+		{ID: "eng-todo", Name: "Todo", Type: "unstarted", Position: 1, Team: &engTeam},
+		{ID: "eng-review", Name: "In Review", Type: "started", Position: 3, Team: &engTeam},
+		{ID: "eng-progress", Name: "In Progress", Type: "started", Position: 2, Team: &engTeam},
+		{ID: "eng-done", Name: "Done", Type: "completed", Position: 4, Team: &engTeam},
+		{ID: "des-doing", Name: "Doing", Type: "started", Position: 1, Team: &desTeam},
+	}
+	stateByID := map[string]api.WorkflowState{}
+	for _, s := range states {
+		stateByID[s.ID] = s
+	}
+
+	issues := map[string]*api.Issue{
+		"ENG-1": {ID: "issue-1", Identifier: "ENG-1", Team: &engTeam, State: &api.WorkflowState{ID: "eng-todo", Name: "Todo"}},
+		"ENG-2": {ID: "issue-2", Identifier: "ENG-2", Team: &engTeam, State: &api.WorkflowState{ID: "eng-progress", Name: "In Progress"}},
+		"DES-1": {ID: "issue-3", Identifier: "DES-1", Team: &desTeam, State: &api.WorkflowState{ID: "des-doing", Name: "Doing"}},
+	}
+
+	return &api.MockClient{
+		GetIssueFunc: func(ctx context.Context, id string) (*api.Issue, error) {
+			if issue, ok := issues[id]; ok {
+				return issue, nil
+			}
+			return nil, assert.AnError
+		},
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			return states, nil
+		},
+		UpdateIssueFunc: func(ctx context.Context, id string, input api.IssueUpdateInput) (*api.Issue, error) {
+			updated[id] = *input.StateID
+			state := stateByID[*input.StateID]
+			return &api.Issue{ID: id, State: &state}, nil
+		},
+	}
+}
+
+func TestRunTransitionWithFactory_StateOfType(t *testing.T) {
+	updated := map[string]string{}
+	factory := cmdutil.NewFactoryWithClient(newTransitionMockClient(updated), false)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runTransitionWithFactory(factory, []string{"ENG-1", "ENG-2", "DES-1"}, stateOfType("started"))
+
+	require.NoError(t, err)
+	// ENG-1 moves to the first started state by position; the others are
+	// already started
+	assert.Equal(t, map[string]string{"issue-1": "eng-progress"}, updated)
+	assert.Contains(t, buf.String(), "Todo")
+	assert.Contains(t, buf.String(), "moved")
+	assert.Contains(t, buf.String(), "unchanged")
+}
+
+func TestRunTransitionWithFactory_ContinuesAfterFailure(t *testing.T) {
+	updated := map[string]string{}
+	factory := cmdutil.NewFactoryWithClient(newTransitionMockClient(updated), true)
+	var buf bytes.Buffer
+	factory.Formatter.SetWriter(&buf)
+
+	err := runTransitionWithFactory(factory, []string{"ENG-404", "ENG-1", "DES-1"}, stateOfType("completed"))
+
+	assert.EqualError(t, err, "failed to move 2 of 3 issues")
+	assert.Equal(t, map[string]string{"issue-1": "eng-done"}, updated)
+
+	var results []transitionResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.NotEmpty(t, results[0].Error)
+	assert.Equal(t, transitionResult{Issue: "ENG-1", From: "Todo", To: "Done", Moved: true}, results[1])
+	// The design team has no completed state
+	assert.Equal(t, `state "completed" not found`, results[2].Error)
+}
+
+func TestRunTransitionWithFactory_StateNamed(t *testing.T) {
+	updated := map[string]string{}
+	factory := cmdutil.NewFactoryWithClient(newTransitionMockClient(updated), false)
+	factory.Formatter.SetWriter(&bytes.Buffer{})
+
+	err := runTransitionWithFactory(factory, []string{"ENG-1", "ENG-2"}, stateNamed("in review"))
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"issue-1": "eng-review", "issue-2": "eng-review"}, updated)
+}
@@ -0,0 +1,105 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// treeNode is an issue along with its sub-issues
+type treeNode struct {
+	*api.Issue
+	Children []*treeNode `json:"children,omitempty"`
+}
+
+// NewCmdTree creates the issue tree command
+func NewCmdTree() *cobra.Command {
+	var depth int
+
+	cmd := &cobra.Command{
+		Use:   "tree <issue-id>",
+		Short: "Show an issue's sub-issue tree",
+		Long:  "Show an issue and its sub-issues, recursively, with their states.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runTree(cmd.Context(), jsonOutput, args[0], depth)
+		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
+	}
+
+	cmd.Flags().IntVar(&depth, "depth", 0, "Maximum depth of sub-issues to show (0 for unlimited)")
+
+	return cmd
+}
+
+func runTree(ctx context.Context, jsonOutput bool, issueID string, depth int) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runTreeWithFactory(factory, issueID, depth)
+}
+
+func runTreeWithFactory(factory *cmdutil.Factory, issueID string, depth int) error {
+	ctx := factory.Context
+	issue, err := factory.Client.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	root := &treeNode{Issue: issue}
+	visited := map[string]bool{issue.ID: true}
+	if err := buildTree(ctx, factory.Client, root, 1, depth, visited); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s [%s]\n", issue.Identifier, issue.Title, displayState(issue.State))
+	writeTree(&b, root.Children, "")
+
+	return factory.Formatter.PrintText(b.String(), root)
+}
+
+// buildTree fetches the sub-issues of node down to maxDepth levels. visited
+// guards against cycles.
+func buildTree(ctx context.Context, client api.Client, node *treeNode, level, maxDepth int, visited map[string]bool) error {
+	if maxDepth > 0 && level > maxDepth {
+		return nil
+	}
+
+	children, err := client.GetSubIssues(ctx, node.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get sub-issues of %s: %w", node.Identifier, err)
+	}
+
+	for i := range children {
+		if visited[children[i].ID] {
+			continue
+		}
+		visited[children[i].ID] = true
+
+		child := &treeNode{Issue: &children[i]}
+		if err := buildTree(ctx, client, child, level+1, maxDepth, visited); err != nil {
+			return err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return nil
+}
+
+// writeTree writes nodes with box-drawing branches
+func writeTree(b *strings.Builder, nodes []*treeNode, prefix string) {
+	for i, n := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(b, "%s%s%s %s [%s]\n", prefix, branch, n.Identifier, n.Title, displayState(n.State))
+		writeTree(b, n.Children, prefix+indent)
+	}
+}
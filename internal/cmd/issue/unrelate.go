@@ -0,0 +1,65 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+// NewCmdUnrelate creates the issue unrelate command
+func NewCmdUnrelate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unrelate <issue-id> <other-issue-id>",
+		Short: "Remove links between two issues",
+		Long:  "Remove every relation between two issues, whichever issue it was created on.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return runUnrelate(cmd.Context(), jsonOutput, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func runUnrelate(ctx context.Context, jsonOutput bool, issueID, otherID string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
+	if err != nil {
+		return err
+	}
+	return runUnrelateWithFactory(factory, issueID, otherID)
+}
+
+func runUnrelateWithFactory(factory *cmdutil.Factory, issueID, otherID string) error {
+	ctx := factory.Context
+	issue, err := factory.Client.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	other, err := factory.Client.GetIssue(ctx, otherID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", otherID, err)
+	}
+
+	var removed []string
+	for _, r := range issue.Relations {
+		if r.Issue == nil || r.Issue.ID != other.ID {
+			continue
+		}
+		if err := factory.Client.DeleteIssueRelation(ctx, r.ID); err != nil {
+			return fmt.Errorf("failed to remove relation: %w", err)
+		}
+		removed = append(removed, r.ID)
+	}
+
+	if len(removed) == 0 {
+		return fmt.Errorf("%s has no relation to %s", issue.Identifier, other.Identifier)
+	}
+
+	return factory.Formatter.PrintText(
+		fmt.Sprintf("Removed %d relation(s) between %s and %s\n", len(removed), issue.Identifier, other.Identifier),
+		map[string]interface{}{"removed": removed},
+	)
+}
@@ -6,12 +6,15 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
 // NewCmdView creates the issue view command
 func NewCmdView() *cobra.Command {
+	var showComments bool
+
 	cmd := &cobra.Command{
 		Use:   "view <issue-id>",
 		Short: "View issue details",
@@ -19,20 +22,26 @@ func NewCmdView() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runView(jsonOutput, args[0])
+			return runView(cmd.Context(), jsonOutput, args[0], showComments)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
+	cmd.Flags().BoolVarP(&showComments, "comments", "c", false, "Show the issue's comment threads")
+
 	return cmd
 }
 
-func runView(jsonOutput bool, issueID string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runView(ctx context.Context, jsonOutput bool, issueID string, showComments bool) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
+	return runViewWithFactory(factory, issueID, showComments)
+}
 
-	ctx := context.Background()
+func runViewWithFactory(factory *cmdutil.Factory, issueID string, showComments bool) error {
+	ctx := factory.Context
 	issue, err := factory.Client.GetIssue(ctx, issueID)
 	if err != nil {
 		return fmt.Errorf("failed to get issue: %w", err)
@@ -63,6 +72,11 @@ func runView(jsonOutput bool, issueID string) error {
 		cycleName = issue.Cycle.Name
 	}
 
+	parentIdentifier := "-"
+	if issue.Parent != nil {
+		parentIdentifier = issue.Parent.Identifier
+	}
+
 	labelNames := make([]string, len(issue.Labels))
 	for i, l := range issue.Labels {
 		labelNames[i] = l.Name
@@ -78,11 +92,71 @@ func runView(jsonOutput bool, issueID string) error {
 		{Label: "Team", Value: issue.Team.Name},
 		{Label: "Project", Value: projectName},
 		{Label: "Cycle", Value: cycleName},
+		{Label: "Parent", Value: parentIdentifier},
+		{Label: "Sub-issues", Value: linkedIssues(issue.Children)},
 		{Label: "Labels", Value: strings.Join(labelNames, ", ")},
 		{Label: "Due Date", Value: output.EmptyIfNil(issue.DueDate)},
 		{Label: "URL", Value: issue.URL},
-		{Label: "Description", Value: issue.Description},
 	}
+	fields = append(fields, relationFields(issue.Relations)...)
+	fields = append(fields, output.DetailField{Label: "Description", Value: issue.Description})
+
+	if !showComments {
+		return factory.Formatter.PrintDetail(fields, issue)
+	}
+
+	comments, err := factory.Client.GetComments(ctx, issue.ID, api.ListOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	if factory.Formatter.IsJSON() {
+		return factory.Formatter.PrintJSON(struct {
+			*api.Issue
+			Comments []api.Comment `json:"comments"`
+		}{issue, comments})
+	}
+
+	if err := factory.Formatter.PrintDetail(fields, issue); err != nil {
+		return err
+	}
+	return factory.Formatter.PrintText(fmt.Sprintf("\nComments (%d)\n\n%s", len(comments), renderComments(comments)), nil)
+}
 
-	return factory.Formatter.PrintDetail(fields, issue)
+// relationLabels orders and names the relation types shown in issue view
+var relationLabels = []struct {
+	Type  string
+	Label string
+}{
+	{api.RelationBlocks, "Blocks"},
+	{api.RelationBlockedBy, "Blocked by"},
+	{api.RelationRelated, "Related"},
+	{api.RelationDuplicate, "Duplicate of"},
+	{api.RelationDuplicatedBy, "Duplicated by"},
+}
+
+// relationFields returns a field for each type of relation the issue has
+func relationFields(relations []api.IssueRelation) []output.DetailField {
+	var fields []output.DetailField
+	for _, rl := range relationLabels {
+		var issues []api.Issue
+		for _, r := range relations {
+			if r.Type == rl.Type && r.Issue != nil {
+				issues = append(issues, *r.Issue)
+			}
+		}
+		if len(issues) > 0 {
+			fields = append(fields, output.DetailField{Label: rl.Label, Value: linkedIssues(issues)})
+		}
+	}
+	return fields
+}
+
+// linkedIssues lists issues by identifier with their states
+func linkedIssues(issues []api.Issue) string {
+	parts := make([]string, len(issues))
+	for i, issue := range issues {
+		parts[i] = fmt.Sprintf("%s (%s)", issue.Identifier, displayState(issue.State))
+	}
+	return strings.Join(parts, ", ")
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
@@ -12,6 +13,8 @@ import (
 // NewCmdList creates the label list command
 func NewCmdList() *cobra.Command {
 	var teamID string
+	var limit int
+	var all bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -19,33 +22,42 @@ func NewCmdList() *cobra.Command {
 		Long:  "List all issue labels in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			var teamPtr *string
+			opts := api.LabelListOptions{Limit: limit, All: all}
 			if teamID != "" {
-				teamPtr = &teamID
+				opts.TeamID = &teamID
 			}
-			return runList(jsonOutput, teamPtr)
+			return runList(cmd.Context(), jsonOutput, opts)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
-	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team ID")
+	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team key, name or ID")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of labels to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all labels, ignoring --limit")
 
 	return cmd
 }
 
-func runList(jsonOutput bool, teamID *string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.LabelListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	labels, err := factory.Client.GetLabels(ctx, teamID)
+	opts.TeamID, err = factory.Resolver.TeamID(ctx, opts.TeamID)
 	if err != nil {
-		return fmt.Errorf("failed to list labels: %w", err)
+		return err
+	}
+
+	labels, listErr := factory.Client.GetLabels(ctx, opts)
+	if listErr != nil && len(labels) == 0 {
+		return fmt.Errorf("failed to list labels: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API limit is 100)
-	output.WarnIfTruncated(len(labels), 100)
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(labels), opts.Limit)
+	}
 
 	headers := []string{"NAME", "COLOR", "TEAM"}
 	rows := make([][]string, len(labels))
@@ -57,5 +69,8 @@ func runList(jsonOutput bool, teamID *string) error {
 		rows[i] = []string{l.Name, l.Color, teamName}
 	}
 
-	return factory.Formatter.Print(headers, rows, labels)
+	if err := factory.Formatter.Print(headers, rows, labels); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(labels), "labels")
 }
@@ -16,6 +16,7 @@ func NewCmdList() *cobra.Command {
 	var teamID string
 	var state string
 	var limit int
+	var all bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -23,42 +24,46 @@ func NewCmdList() *cobra.Command {
 		Long:  "List all projects in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			opts := api.ProjectListOptions{First: limit}
+			opts := api.ProjectListOptions{Limit: limit, All: all}
 			if teamID != "" {
 				opts.TeamID = &teamID
 			}
 			if state != "" {
 				opts.State = &state
 			}
-			return runList(jsonOutput, opts)
+			return runList(cmd.Context(), jsonOutput, opts)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
-	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team ID")
+	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team key, name or ID")
 	cmd.Flags().StringVar(&state, "state", "", "Filter by state (e.g., started, completed, canceled)")
 	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of projects to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all projects, ignoring --limit")
 
 	return cmd
 }
 
-func runList(jsonOutput bool, opts api.ProjectListOptions) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.ProjectListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	projects, err := factory.Client.GetProjects(ctx, opts)
+	opts.TeamID, err = factory.Resolver.TeamID(ctx, opts.TeamID)
 	if err != nil {
-		return fmt.Errorf("failed to list projects: %w", err)
+		return err
+	}
+
+	projects, listErr := factory.Client.GetProjects(ctx, opts)
+	if listErr != nil && len(projects) == 0 {
+		return fmt.Errorf("failed to list projects: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API default limit is 50)
-	limit := opts.First
-	if limit == 0 {
-		limit = 50
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(projects), opts.Limit)
 	}
-	output.WarnIfTruncated(len(projects), limit)
 
 	headers := []string{"NAME", "STATE", "PROGRESS", "LEAD", "TEAMS"}
 	rows := make([][]string, len(projects))
@@ -80,5 +85,8 @@ func runList(jsonOutput bool, opts api.ProjectListOptions) error {
 		}
 	}
 
-	return factory.Formatter.Print(headers, rows, projects)
+	if err := factory.Formatter.Print(headers, rows, projects); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(projects), "projects")
 }
@@ -13,27 +13,32 @@ import (
 // NewCmdView creates the project view command
 func NewCmdView() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "view <project-id>",
+		Use:   "view <project>",
 		Short: "View project details",
-		Long:  "View details of a specific project.",
+		Long:  "View details of a specific project by name, slug or ID.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runView(jsonOutput, args[0])
+			return runView(cmd.Context(), jsonOutput, args[0])
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
 	return cmd
 }
 
-func runView(jsonOutput bool, projectID string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runView(ctx context.Context, jsonOutput bool, projectRef string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	project, err := factory.Client.GetProject(ctx, projectID)
+	resolved, err := factory.Resolver.Project(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+
+	project, err := factory.Client.GetProject(ctx, resolved.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get project: %w", err)
 	}
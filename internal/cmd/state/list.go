@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
@@ -12,6 +13,8 @@ import (
 // NewCmdList creates the state list command
 func NewCmdList() *cobra.Command {
 	var teamID string
+	var limit int
+	var all bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -19,33 +22,42 @@ func NewCmdList() *cobra.Command {
 		Long:  "List all workflow states in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			var teamPtr *string
+			opts := api.WorkflowStateListOptions{Limit: limit, All: all}
 			if teamID != "" {
-				teamPtr = &teamID
+				opts.TeamID = &teamID
 			}
-			return runList(jsonOutput, teamPtr)
+			return runList(cmd.Context(), jsonOutput, opts)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
-	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team ID")
+	cmd.Flags().StringVar(&teamID, "team", "", "Filter by team key, name or ID")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of workflow states to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all workflow states, ignoring --limit")
 
 	return cmd
 }
 
-func runList(jsonOutput bool, teamID *string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.WorkflowStateListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	states, err := factory.Client.GetWorkflowStates(ctx, teamID)
+	opts.TeamID, err = factory.Resolver.TeamID(ctx, opts.TeamID)
 	if err != nil {
-		return fmt.Errorf("failed to list workflow states: %w", err)
+		return err
+	}
+
+	states, listErr := factory.Client.GetWorkflowStates(ctx, opts)
+	if listErr != nil && len(states) == 0 {
+		return fmt.Errorf("failed to list workflow states: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API limit is 100)
-	output.WarnIfTruncated(len(states), 100)
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(states), opts.Limit)
+	}
 
 	headers := []string{"NAME", "TYPE", "TEAM"}
 	rows := make([][]string, len(states))
@@ -57,5 +69,8 @@ func runList(jsonOutput bool, teamID *string) error {
 		rows[i] = []string{s.Name, s.Type, teamName}
 	}
 
-	return factory.Formatter.Print(headers, rows, states)
+	if err := factory.Formatter.Print(headers, rows, states); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(states), "workflow states")
 }
@@ -5,39 +5,48 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
 // NewCmdList creates the team list command
 func NewCmdList() *cobra.Command {
+	var limit int
+	var all bool
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List teams",
 		Long:  "List all teams in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runList(jsonOutput)
+			return runList(cmd.Context(), jsonOutput, api.ListOptions{Limit: limit, All: all})
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of teams to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all teams, ignoring --limit")
+
 	return cmd
 }
 
-func runList(jsonOutput bool) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.ListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	teams, err := factory.Client.GetTeams(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to list teams: %w", err)
+	teams, listErr := factory.Client.GetTeams(ctx, opts)
+	if listErr != nil && len(teams) == 0 {
+		return fmt.Errorf("failed to list teams: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API limit is 100)
-	output.WarnIfTruncated(len(teams), 100)
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(teams), opts.Limit)
+	}
 
 	headers := []string{"KEY", "NAME", "DESCRIPTION"}
 	rows := make([][]string, len(teams))
@@ -49,5 +58,8 @@ func runList(jsonOutput bool) error {
 		}
 	}
 
-	return factory.Formatter.Print(headers, rows, teams)
+	if err := factory.Formatter.Print(headers, rows, teams); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(teams), "teams")
 }
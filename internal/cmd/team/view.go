@@ -12,27 +12,32 @@ import (
 // NewCmdView creates the team view command
 func NewCmdView() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "view <team-id>",
+		Use:   "view <team>",
 		Short: "View team details",
-		Long:  "View details of a specific team.",
+		Long:  "View details of a specific team by key, name or ID.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runView(jsonOutput, args[0])
+			return runView(cmd.Context(), jsonOutput, args[0])
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
 	return cmd
 }
 
-func runView(jsonOutput bool, teamID string) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runView(ctx context.Context, jsonOutput bool, teamRef string) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	team, err := factory.Client.GetTeam(ctx, teamID)
+	resolved, err := factory.Resolver.Team(ctx, teamRef)
+	if err != nil {
+		return err
+	}
+
+	team, err := factory.Client.GetTeam(ctx, resolved.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get team: %w", err)
 	}
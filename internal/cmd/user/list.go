@@ -5,39 +5,48 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/stustirling/lnr/internal/api"
 	"github.com/stustirling/lnr/internal/output"
 	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
 // NewCmdList creates the user list command
 func NewCmdList() *cobra.Command {
+	var limit int
+	var all bool
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List users",
 		Long:  "List all users in the Linear workspace.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runList(jsonOutput)
+			return runList(cmd.Context(), jsonOutput, api.ListOptions{Limit: limit, All: all})
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of users to return")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all users, ignoring --limit")
+
 	return cmd
 }
 
-func runList(jsonOutput bool) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runList(ctx context.Context, jsonOutput bool, opts api.ListOptions) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	users, err := factory.Client.GetUsers(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to list users: %w", err)
+	users, listErr := factory.Client.GetUsers(ctx, opts)
+	if listErr != nil && len(users) == 0 {
+		return fmt.Errorf("failed to list users: %w", listErr)
 	}
 
-	// Warn if results might be truncated (API limit is 100)
-	output.WarnIfTruncated(len(users), 100)
+	// Warn if results might be truncated
+	if !opts.All {
+		output.WarnIfTruncated(len(users), opts.Limit)
+	}
 
 	headers := []string{"NAME", "EMAIL", "ACTIVE", "ADMIN"}
 	rows := make([][]string, len(users))
@@ -53,5 +62,8 @@ func runList(jsonOutput bool) error {
 		rows[i] = []string{u.Name, u.Email, active, admin}
 	}
 
-	return factory.Formatter.Print(headers, rows, users)
+	if err := factory.Formatter.Print(headers, rows, users); err != nil {
+		return err
+	}
+	return cmdutil.Incomplete(listErr, len(users), "users")
 }
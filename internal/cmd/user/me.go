@@ -17,20 +17,20 @@ func NewCmdMe() *cobra.Command {
 		Long:  "Display information about the currently authenticated user.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonOutput, _ := cmd.Flags().GetBool("json")
-			return runMe(jsonOutput)
+			return runMe(cmd.Context(), jsonOutput)
 		},
+		Annotations: map[string]string{cmdutil.AnnotationPager: "true"},
 	}
 
 	return cmd
 }
 
-func runMe(jsonOutput bool) error {
-	factory, err := cmdutil.NewFactory(jsonOutput)
+func runMe(ctx context.Context, jsonOutput bool) error {
+	factory, err := cmdutil.NewFactory(ctx, jsonOutput)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
 	user, err := factory.Client.GetViewer(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get current user: %w", err)
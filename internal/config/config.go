@@ -2,36 +2,188 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 const (
 	// EnvAPIKey is the environment variable name for the Linear API key
 	EnvAPIKey = "LINEAR_API_KEY"
+
+	// EnvAPIURL is the environment variable name for the Linear API endpoint
+	EnvAPIURL = "LINEAR_API_URL"
+
+	// EnvNoCache disables the on-disk metadata cache when set to a true value
+	EnvNoCache = "LNR_NO_CACHE"
+
+	// EnvRetries is the environment variable name for the number of retries
+	// after rate limits and transient failures
+	EnvRetries = "LNR_RETRIES"
+
+	// EnvDebug logs API traffic to stderr when set to a true value
+	EnvDebug = "LNR_DEBUG"
+
+	// EnvDebugFile is the environment variable name for a file to append
+	// an API trace log to
+	EnvDebugFile = "LNR_DEBUG_FILE"
+
+	// EnvTimeout is the environment variable name for how long a command
+	// may spend talking to Linear, as a duration such as 30s or 5m
+	EnvTimeout = "LNR_TIMEOUT"
+
+	// EnvRecord is the environment variable name for a cassette file to
+	// record API traffic to
+	EnvRecord = "LNR_RECORD"
+
+	// EnvReplay is the environment variable name for a cassette file to
+	// answer API requests from instead of Linear
+	EnvReplay = "LNR_REPLAY"
 )
 
 var (
 	// ErrNoAPIKey is returned when the API key is not set
-	ErrNoAPIKey = errors.New("LINEAR_API_KEY environment variable is not set")
+	ErrNoAPIKey = errors.New("no API key: set LINEAR_API_KEY or run lnr auth login")
 )
 
 // Config holds the application configuration
 type Config struct {
 	APIKey string
+	// APIURL overrides the GraphQL endpoint when set
+	APIURL string
+	// NoCache disables the on-disk metadata cache
+	NoCache bool
+	// Retries overrides the number of retries when set; zero disables them
+	Retries *int
+	// Debug logs API traffic to stderr
+	Debug bool
+	// DebugFile, when set, receives a JSON trace of API traffic
+	DebugFile string
+	// Timeout, when set, limits the whole command and replaces the default
+	// per-request timeout
+	Timeout time.Duration
+	// Record, when set, is a cassette file API traffic is recorded to
+	Record string
+	// Replay, when set, is a cassette file API requests are answered from.
+	// No API key is needed.
+	Replay string
+	// Profile is the name of the active profile, or "" if there is none
+	Profile string
+	// OAuth, when set, is the profile's OAuth login, used instead of an
+	// API key
+	OAuth *OAuth
+	// CredentialSource says where APIKey or OAuth came from: LINEAR_API_KEY,
+	// a profile or a credential helper
+	CredentialSource string
+	// Settings are the user's preferences from config files and the
+	// environment
+	Settings *Settings
 }
 
-// Load reads configuration from environment variables
+// Load reads configuration from environment variables, config files and
+// the active profile
 func Load() (*Config, error) {
-	apiKey := os.Getenv(EnvAPIKey)
-	if apiKey == "" {
-		return nil, ErrNoAPIKey
+	return LoadWith(Overrides{})
+}
+
+// LoadWith reads configuration as Load does, with the global flags in o
+// taking precedence over the environment
+func LoadWith(o Overrides) (*Config, error) {
+	record, replay := CassettesFor(o)
+	if record != "" && replay != "" {
+		return nil, fmt.Errorf("%s and %s cannot both be set", EnvRecord, EnvReplay)
+	}
+
+	settings, err := LoadSettingsWith(o)
+	if err != nil {
+		return nil, err
+	}
+
+	// The environment overrides the profile, so a one-off key works
+	// without logging in. The endpoint is a setting like any other, but one
+	// a repository cannot choose, as the key would be sent to it.
+	apiKey, apiURL := os.Getenv(EnvAPIKey), settings.UserGet(KeyAPIURL).Value
+	source := EnvAPIKey
+	var oauth *OAuth
+	profile := settings.Profile()
+	if profile != "" {
+		if !settings.User.HasProfile(profile) {
+			return nil, fmt.Errorf("unknown profile %q: run lnr auth login --profile %s", profile, profile)
+		}
+		if apiKey == "" {
+			apiKey, _ = settings.User.ProfileGet(profile, ProfileAPIKey)
+			source = "profile " + profile
+		}
+		if apiKey == "" {
+			if oauth = ReadOAuth(settings.User, profile); oauth != nil {
+				source = "profile " + profile + " (OAuth)"
+			}
+		}
+	}
+	// A repository must not choose a command to run
+	if helper := settings.UserGet(KeyCredentialHelper).Value; apiKey == "" && oauth == nil && helper != "" && replay == "" {
+		if apiKey, err = RunCredentialHelper(helper, profile); err != nil {
+			return nil, err
+		}
+		source = "credential helper " + helper
+	}
+	if apiKey == "" && oauth == nil {
+		if replay == "" {
+			return nil, ErrNoAPIKey
+		}
+		source = ""
+	}
+
+	noCache, _ := strconv.ParseBool(os.Getenv(EnvNoCache))
+	noCache = noCache || o.NoCache
+
+	retries := o.Retries
+	if value := os.Getenv(EnvRetries); value != "" && retries == nil {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be a whole number of at least 0", EnvRetries, value)
+		}
+		retries = &n
+	}
+
+	debug, debugFile := DebugFor(o)
+
+	timeout, err := TimeoutFor(o)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Config{
-		APIKey: apiKey,
+		APIKey:           apiKey,
+		APIURL:           apiURL,
+		NoCache:          noCache,
+		Retries:          retries,
+		Debug:            debug,
+		DebugFile:        debugFile,
+		Timeout:          timeout,
+		Record:           record,
+		Replay:           replay,
+		Profile:          profile,
+		Settings:         settings,
+		OAuth:            oauth,
+		CredentialSource: source,
 	}, nil
 }
 
+// ParseTimeout parses a value of EnvTimeout. An empty value means no
+// timeout and returns zero.
+func ParseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive duration such as 30s or 5m", EnvTimeout, value)
+	}
+	return d, nil
+}
+
 // MustLoad loads configuration and panics if it fails
 func MustLoad() *Config {
 	cfg, err := Load()
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,98 @@ func TestLoad_WithAPIKey(t *testing.T) {
 	assert.Equal(t, "test-api-key", cfg.APIKey)
 }
 
+func TestLoad_WithAPIURL(t *testing.T) {
+	t.Setenv(EnvAPIKey, "test-api-key")
+	t.Setenv(EnvAPIURL, "http://localhost:8080/graphql")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/graphql", cfg.APIURL)
+}
+
+func TestLoad_NoCache(t *testing.T) {
+	t.Setenv(EnvAPIKey, "test-api-key")
+
+	t.Setenv(EnvNoCache, "")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.NoCache)
+
+	t.Setenv(EnvNoCache, "1")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.NoCache)
+}
+
+func TestLoad_Retries(t *testing.T) {
+	t.Setenv(EnvAPIKey, "test-api-key")
+
+	t.Setenv(EnvRetries, "")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.Retries)
+
+	t.Setenv(EnvRetries, "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Retries)
+	assert.Equal(t, 0, *cfg.Retries)
+
+	t.Setenv(EnvRetries, "-1")
+	_, err = Load()
+	assert.ErrorContains(t, err, "invalid LNR_RETRIES")
+}
+
+func TestLoad_Debug(t *testing.T) {
+	t.Setenv(EnvAPIKey, "test-api-key")
+	t.Setenv(EnvDebug, "true")
+	t.Setenv(EnvDebugFile, "/tmp/lnr-trace.log")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, "/tmp/lnr-trace.log", cfg.DebugFile)
+}
+
+func TestLoad_Timeout(t *testing.T) {
+	t.Setenv(EnvAPIKey, "test-api-key")
+
+	t.Setenv(EnvTimeout, "")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Timeout)
+
+	t.Setenv(EnvTimeout, "5m")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.Timeout)
+
+	for _, value := range []string{"5", "-1s", "0s"} {
+		t.Setenv(EnvTimeout, value)
+		_, err = Load()
+		assert.ErrorContains(t, err, "invalid LNR_TIMEOUT", value)
+	}
+}
+
+func TestLoad_Cassettes(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvReplay, "testdata/demo.json")
+
+	// Replaying needs no API key
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "testdata/demo.json", cfg.Replay)
+
+	t.Setenv(EnvRecord, "out.json")
+	_, err = Load()
+	assert.ErrorContains(t, err, "cannot both be set")
+
+	t.Setenv(EnvReplay, "")
+	_, err = Load()
+	assert.ErrorIs(t, err, ErrNoAPIKey)
+}
+
 func TestLoad_WithoutAPIKey(t *testing.T) {
 	// Set up - ensure env var is not set
 	t.Setenv(EnvAPIKey, "")
@@ -40,3 +133,33 @@ func TestMustLoad_Panics(t *testing.T) {
 		MustLoad()
 	})
 }
+
+func TestLoadWith_Overrides(t *testing.T) {
+	t.Setenv(EnvConfigHome, t.TempDir())
+	t.Setenv(EnvAPIKey, "test-api-key")
+	t.Setenv(EnvRetries, "5")
+	t.Setenv(EnvTimeout, "1m")
+	t.Setenv(EnvDebugFile, "env.log")
+	t.Setenv(EnvNoCache, "")
+	t.Setenv(EnvDebug, "")
+
+	retries := 0
+	cfg, err := LoadWith(Overrides{NoCache: true, Debug: true, DebugFile: "flag.log", Retries: &retries, Timeout: 10 * time.Second})
+	require.NoError(t, err)
+	assert.True(t, cfg.NoCache)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, "flag.log", cfg.DebugFile)
+	require.NotNil(t, cfg.Retries)
+	assert.Equal(t, 0, *cfg.Retries)
+	assert.Equal(t, 10*time.Second, cfg.Timeout)
+
+	// Unset overrides leave the environment in charge
+	cfg, err = LoadWith(Overrides{})
+	require.NoError(t, err)
+	assert.Equal(t, "env.log", cfg.DebugFile)
+	assert.Equal(t, 5, *cfg.Retries)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+
+	_, err = LoadWith(Overrides{Profile: "work"})
+	assert.ErrorContains(t, err, `unknown profile "work"`)
+}
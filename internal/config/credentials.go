@@ -0,0 +1,61 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ErrCredentialHelper is returned when the credential helper fails or
+// prints no key
+var ErrCredentialHelper = errors.New("credential helper failed")
+
+// helperKeys caches the key each helper printed, so a helper that asks for
+// a password or touch runs at most once per process
+var helperKeys = struct {
+	sync.Mutex
+	keys map[string]string
+}{keys: map[string]string{}}
+
+// RunCredentialHelper runs a credential helper command, such as
+// "op read op://Private/Linear/credential", and returns the API key it
+// prints. The command runs in the shell with LNR_PROFILE set to profile, and
+// can prompt on the terminal.
+func RunCredentialHelper(helper, profile string) (string, error) {
+	cacheKey := profile + "\x00" + helper
+	helperKeys.Lock()
+	defer helperKeys.Unlock()
+	if key, ok := helperKeys.keys[cacheKey]; ok {
+		return key, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", helper)
+	} else {
+		cmd = exec.Command("sh", "-c", helper)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), EnvProfile+"="+profile)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrCredentialHelper, helper, err)
+	}
+
+	// The key is the first line, so helpers may print more after it
+	line, _ := bufio.NewReader(&stdout).ReadString('\n')
+	key := strings.TrimSpace(line)
+	if key == "" {
+		return "", fmt.Errorf("%w: %q printed no API key", ErrCredentialHelper, helper)
+	}
+	helperKeys.keys[cacheKey] = key
+	return key, nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCredentialHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands as helpers")
+	}
+
+	// The helper counts its runs and prints a key for the profile it is
+	// run for, then a line that is not part of the key
+	runs := filepath.Join(t.TempDir(), "runs")
+	helper := `echo run >> ` + runs + `; echo "  key-for-$LNR_PROFILE  "; echo extra`
+	for range 2 {
+		key, err := RunCredentialHelper(helper, "work")
+		require.NoError(t, err)
+		assert.Equal(t, "key-for-work", key)
+	}
+	key, err := RunCredentialHelper(helper, "home")
+	require.NoError(t, err)
+	assert.Equal(t, "key-for-home", key)
+
+	data, err := os.ReadFile(runs)
+	require.NoError(t, err)
+	assert.Equal(t, "run\nrun\n", string(data), "results are cached per profile")
+
+	_, err = RunCredentialHelper("exit 3", "")
+	assert.ErrorIs(t, err, ErrCredentialHelper)
+	assert.EqualError(t, err, `credential helper failed: "exit 3": exit status 3`)
+
+	_, err = RunCredentialHelper("true", "")
+	assert.ErrorIs(t, err, ErrCredentialHelper)
+	assert.EqualError(t, err, `credential helper failed: "true" printed no API key`)
+}
+
+func TestLoad_CredentialSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell command as the helper")
+	}
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	chdir(t, t.TempDir())
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvReplay, "")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "lnr", "config.yaml"), []byte(`credential_helper: echo helper-key
+profiles:
+  work:
+    api_key: work-key
+  vault:
+    credential_helper: echo vault-key
+`), 0o600))
+
+	tests := []struct {
+		name       string
+		profile    string
+		env        string
+		wantKey    string
+		wantSource string
+	}{
+		{"environment", "work", "env-key", "env-key", EnvAPIKey},
+		{"stored in profile", "work", "", "work-key", "profile work"},
+		{"profile helper", "vault", "", "vault-key", "credential helper echo vault-key"},
+		{"top-level helper", "", "", "helper-key", "credential helper echo helper-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvProfile, tt.profile)
+			t.Setenv(EnvAPIKey, tt.env)
+
+			cfg, err := Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKey, cfg.APIKey)
+			assert.Equal(t, tt.wantSource, cfg.CredentialSource)
+		})
+	}
+
+	t.Setenv(EnvProfile, "")
+	t.Setenv("LNR_CREDENTIAL_HELPER", "exit 1")
+	_, err := Load()
+	assert.ErrorIs(t, err, ErrCredentialHelper)
+}
+
+func TestLoad_CredentialHelperNotFromRepo(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell command as the helper")
+	}
+	t.Setenv(EnvConfigHome, t.TempDir())
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvReplay, "")
+	repo := t.TempDir()
+	marker := filepath.Join(t.TempDir(), "ran")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, RepoConfigName),
+		[]byte("credential_helper: touch "+marker+"; echo repo-key\n"), 0o644))
+	chdir(t, repo)
+
+	_, err := Load()
+	assert.ErrorContains(t, err, "credential_helper can only be set in")
+	_, err = os.Stat(marker)
+	assert.True(t, os.IsNotExist(err), "the repository's helper is not run")
+}
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is a YAML config file of top-level keys. Changes made through it keep
+// the file's comments and any keys lnr does not know about.
+type File struct {
+	Path string
+	doc  yaml.Node
+}
+
+// ReadFile reads the config file at path. A missing file reads as empty.
+func ReadFile(path string) (*File, error) {
+	f := &File{Path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &f.doc); err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+	if root := f.root(); root != nil && root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("read config %s: expected a mapping of keys to values", path)
+	}
+	return f, nil
+}
+
+// root returns the top-level mapping, or nil for an empty file
+func (f *File) root() *yaml.Node {
+	if len(f.doc.Content) == 0 {
+		return nil
+	}
+	return f.doc.Content[0]
+}
+
+// Keys returns the file's top-level keys in file order
+func (f *File) Keys() []string {
+	return mapKeys(f.root())
+}
+
+// Get returns the value of a top-level key. A list of values is returned
+// joined with commas.
+func (f *File) Get(key string) (string, bool) {
+	return value(lookup(f.root(), key))
+}
+
+// Set sets a top-level key, adding it to the end of the file if needed
+func (f *File) Set(key, value string) {
+	if f.root() == nil {
+		f.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMapping()}}
+	}
+	set(f.root(), key, value)
+}
+
+// Unset removes a top-level key and reports whether it was there
+func (f *File) Unset(key string) bool {
+	return unset(f.root(), key)
+}
+
+// Profiles returns the names of the profiles in the file, in file order
+func (f *File) Profiles() []string {
+	return mapKeys(lookup(f.root(), profilesKey))
+}
+
+// HasProfile reports whether the file has a profile called name
+func (f *File) HasProfile(name string) bool {
+	return lookup(lookup(f.root(), profilesKey), name) != nil
+}
+
+// ProfileGet returns the value of a key in a profile, as Get does
+func (f *File) ProfileGet(profile, key string) (string, bool) {
+	return value(lookup(lookup(lookup(f.root(), profilesKey), profile), key))
+}
+
+// ProfileSet sets a key in a profile, creating the profile if needed
+func (f *File) ProfileSet(profile, key, value string) {
+	if f.root() == nil {
+		f.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMapping()}}
+	}
+	set(child(child(f.root(), profilesKey), profile), key, value)
+}
+
+// ProfileUnset removes a key from a profile and reports whether it was there
+func (f *File) ProfileUnset(profile, key string) bool {
+	return unset(lookup(lookup(f.root(), profilesKey), profile), key)
+}
+
+// RemoveProfile removes a profile and reports whether it was there
+func (f *File) RemoveProfile(name string) bool {
+	profiles := lookup(f.root(), profilesKey)
+	removed := unset(profiles, name)
+	if profiles != nil && len(profiles.Content) == 0 {
+		unset(f.root(), profilesKey)
+	}
+	return removed
+}
+
+// profilesKey holds the profiles, each a mapping of keys to values
+const profilesKey = "profiles"
+
+func newMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// lookup returns the value of key in a mapping, or nil
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// child returns the mapping under key, replacing anything else there
+func child(mapping *yaml.Node, key string) *yaml.Node {
+	if node := lookup(mapping, key); node != nil && node.Kind == yaml.MappingNode {
+		return node
+	}
+	node := newMapping()
+	setNode(mapping, key, node)
+	return node
+}
+
+// scalar returns the value of a node that holds a single value
+func scalar(node *yaml.Node) (string, bool) {
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// value returns the value of a node that holds a single value or a list of
+// them, which are joined with commas
+func value(node *yaml.Node) (string, bool) {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return scalar(node)
+	}
+	items := make([]string, len(node.Content))
+	for i, item := range node.Content {
+		v, ok := scalar(item)
+		if !ok {
+			return "", false
+		}
+		items[i] = v
+	}
+	return strings.Join(items, ","), true
+}
+
+func mapKeys(mapping *yaml.Node) []string {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	keys := make([]string, 0, len(mapping.Content)/2)
+	for i := 0; i < len(mapping.Content); i += 2 {
+		keys = append(keys, mapping.Content[i].Value)
+	}
+	return keys
+}
+
+func set(mapping *yaml.Node, key, value string) {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	// Keep any comment attached to the old value
+	if old := lookup(mapping, key); old != nil {
+		node.LineComment = old.LineComment
+	}
+	setNode(mapping, key, node)
+}
+
+func setNode(mapping *yaml.Node, key string, node *yaml.Node) {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = node
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, node)
+}
+
+func unset(mapping *yaml.Node, key string) bool {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Save writes the file, creating its directory if needed. The file is only
+// readable by its owner, since it may hold credentials.
+func (f *File) Save() error {
+	var buf bytes.Buffer
+	if f.root() != nil && len(f.root().Content) > 0 {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&f.doc); err != nil {
+			return fmt.Errorf("encode config: %w", err)
+		}
+		_ = enc.Close()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o700); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), ".config-*")
+	if err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
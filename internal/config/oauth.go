@@ -0,0 +1,104 @@
+package config
+
+import (
+	"time"
+)
+
+// Profile keys saved by an OAuth login
+const (
+	ProfileOAuthClientID     = "oauth_client_id"
+	ProfileOAuthClientSecret = "oauth_client_secret"
+	ProfileOAuthAuthorizeURL = "oauth_authorize_url"
+	ProfileOAuthTokenURL     = "oauth_token_url"
+	ProfileOAuthScopes       = "oauth_scopes"
+	ProfileAccessToken       = "access_token"
+	ProfileRefreshToken      = "refresh_token"
+	ProfileTokenExpiry       = "token_expiry"
+)
+
+// OAuth is a profile's OAuth app and tokens, saved by lnr auth login --oauth
+type OAuth struct {
+	ClientID     string
+	ClientSecret string
+	AuthorizeURL string
+	TokenURL     string
+	Scopes       string
+	AccessToken  string
+	RefreshToken string
+	// Expiry is zero when unknown
+	Expiry time.Time
+}
+
+// oauthKeys are the profile keys an OAuth login saves, in the order they
+// are written
+var oauthKeys = []string{
+	ProfileOAuthClientID, ProfileOAuthClientSecret, ProfileOAuthAuthorizeURL, ProfileOAuthTokenURL,
+	ProfileOAuthScopes, ProfileAccessToken, ProfileRefreshToken, ProfileTokenExpiry,
+}
+
+// ReadOAuth returns the OAuth login saved in a profile, or nil if the
+// profile has no access token
+func ReadOAuth(file *File, profile string) *OAuth {
+	get := func(key string) string {
+		value, _ := file.ProfileGet(profile, key)
+		return value
+	}
+	if get(ProfileAccessToken) == "" {
+		return nil
+	}
+	o := &OAuth{
+		ClientID:     get(ProfileOAuthClientID),
+		ClientSecret: get(ProfileOAuthClientSecret),
+		AuthorizeURL: get(ProfileOAuthAuthorizeURL),
+		TokenURL:     get(ProfileOAuthTokenURL),
+		Scopes:       get(ProfileOAuthScopes),
+		AccessToken:  get(ProfileAccessToken),
+		RefreshToken: get(ProfileRefreshToken),
+	}
+	// A bad expiry reads as unknown, so the token is used until rejected
+	o.Expiry, _ = time.Parse(time.RFC3339, get(ProfileTokenExpiry))
+	return o
+}
+
+// WriteOAuth saves an OAuth login in a profile, replacing any earlier one
+func WriteOAuth(file *File, profile string, o *OAuth) {
+	values := map[string]string{
+		ProfileOAuthClientID:     o.ClientID,
+		ProfileOAuthClientSecret: o.ClientSecret,
+		ProfileOAuthAuthorizeURL: o.AuthorizeURL,
+		ProfileOAuthTokenURL:     o.TokenURL,
+		ProfileOAuthScopes:       o.Scopes,
+		ProfileAccessToken:       o.AccessToken,
+		ProfileRefreshToken:      o.RefreshToken,
+	}
+	if !o.Expiry.IsZero() {
+		values[ProfileTokenExpiry] = o.Expiry.UTC().Format(time.RFC3339)
+	}
+	for _, key := range oauthKeys {
+		if values[key] != "" {
+			file.ProfileSet(profile, key, values[key])
+		} else {
+			file.ProfileUnset(profile, key)
+		}
+	}
+}
+
+// SaveOAuthToken stores a refreshed token in a profile of the user config
+// file, which is read afresh so other changes are kept
+func SaveOAuthToken(profile, accessToken, refreshToken string, expiry time.Time) error {
+	path, err := UserConfigPath()
+	if err != nil {
+		return err
+	}
+	file, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	o := ReadOAuth(file, profile)
+	if o == nil {
+		o = &OAuth{}
+	}
+	o.AccessToken, o.RefreshToken, o.Expiry = accessToken, refreshToken, expiry
+	WriteOAuth(file, profile, o)
+	return file.Save()
+}
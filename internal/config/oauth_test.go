@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_OAuth(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	chdir(t, t.TempDir())
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvAPIURL, "")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	userPath := filepath.Join(home, "lnr", "config.yaml")
+	require.NoError(t, os.WriteFile(userPath, []byte(`profile: work
+profiles:
+  work:
+    # from lnr auth login --oauth
+    oauth_client_id: app-id
+    oauth_token_url: http://auth.example/token
+    access_token: access-1
+    refresh_token: refresh-1
+    token_expiry: "2026-01-02T03:04:05Z"
+`), 0o600))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "", cfg.APIKey)
+	assert.Equal(t, "profile work (OAuth)", cfg.CredentialSource)
+	require.NotNil(t, cfg.OAuth)
+	assert.Equal(t, OAuth{
+		ClientID:     "app-id",
+		TokenURL:     "http://auth.example/token",
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, *cfg.OAuth)
+
+	expiry := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, SaveOAuthToken("work", "access-2", "refresh-2", expiry))
+	data, err := os.ReadFile(userPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# from lnr auth login --oauth", "comments are kept")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", cfg.OAuth.AccessToken)
+	assert.Equal(t, "refresh-2", cfg.OAuth.RefreshToken)
+	assert.Equal(t, expiry, cfg.OAuth.Expiry)
+	assert.Equal(t, "app-id", cfg.OAuth.ClientID, "the app is kept")
+
+	t.Setenv(EnvAPIKey, "env-key")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.OAuth, "the environment beats the profile")
+}
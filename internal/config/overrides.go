@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Overrides are the global command-line flags, such as --profile and
+// --no-cache. Each one that is set takes precedence over its environment
+// variable; the zero value overrides nothing.
+type Overrides struct {
+	// Profile overrides LNR_PROFILE and the profile setting
+	Profile string
+	// NoCache overrides LNR_NO_CACHE when true
+	NoCache bool
+	// Debug overrides LNR_DEBUG when true
+	Debug bool
+	// DebugFile overrides LNR_DEBUG_FILE
+	DebugFile string
+	// Retries overrides LNR_RETRIES when set
+	Retries *int
+	// Timeout overrides LNR_TIMEOUT when positive
+	Timeout time.Duration
+	// Record overrides LNR_RECORD
+	Record string
+	// Replay overrides LNR_REPLAY
+	Replay string
+}
+
+type overridesKey struct{}
+
+// WithOverrides returns a copy of ctx that carries o, so commands can pass
+// the global flags on without touching the environment
+func WithOverrides(ctx context.Context, o Overrides) context.Context {
+	return context.WithValue(ctx, overridesKey{}, o)
+}
+
+// OverridesFrom returns the overrides ctx carries, or none
+func OverridesFrom(ctx context.Context) Overrides {
+	if ctx == nil {
+		return Overrides{}
+	}
+	o, _ := ctx.Value(overridesKey{}).(Overrides)
+	return o
+}
+
+// ProfileOverride returns the profile chosen with --profile or LNR_PROFILE,
+// or "" if neither is set
+func ProfileOverride(ctx context.Context) string {
+	if profile := OverridesFrom(ctx).Profile; profile != "" {
+		return profile
+	}
+	return os.Getenv(EnvProfile)
+}
+
+// TimeoutFor returns the --timeout or LNR_TIMEOUT deadline for a command,
+// or zero if there is none
+func TimeoutFor(o Overrides) (time.Duration, error) {
+	if o.Timeout > 0 {
+		return o.Timeout, nil
+	}
+	return ParseTimeout(os.Getenv(EnvTimeout))
+}
+
+// CassettesFor returns the --record and --replay cassette files, or else
+// LNR_RECORD and LNR_REPLAY
+func CassettesFor(o Overrides) (record, replay string) {
+	return stringOverride(o.Record, EnvRecord), stringOverride(o.Replay, EnvReplay)
+}
+
+// DebugFor returns whether --debug or LNR_DEBUG logs API traffic to
+// stderr, and the --debug-file or LNR_DEBUG_FILE trace file
+func DebugFor(o Overrides) (debug bool, file string) {
+	debug, _ = strconv.ParseBool(os.Getenv(EnvDebug))
+	return debug || o.Debug, stringOverride(o.DebugFile, EnvDebugFile)
+}
+
+// stringOverride returns value if it is set, or else the environment
+// variable env
+func stringOverride(value, env string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(env)
+}
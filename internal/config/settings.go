@@ -0,0 +1,323 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvConfigHome is the XDG base directory the user config file lives
+	// in, defaulting to ~/.config
+	EnvConfigHome = "XDG_CONFIG_HOME"
+
+	// RepoConfigName is the name of the repository config file, found by
+	// walking up from the working directory
+	RepoConfigName = ".lnr.yaml"
+
+	// EnvEditor is the environment variable name for the editor command,
+	// which takes precedence over $VISUAL and $EDITOR
+	EnvEditor = "LNR_EDITOR"
+
+	// EnvProfile is the environment variable name for the profile to use
+	EnvProfile = "LNR_PROFILE"
+)
+
+// Setting keys
+const (
+	KeyTeam             = "team"
+	KeyOutput           = "output"
+	KeyLimit            = "limit"
+	KeyEditor           = "editor"
+	KeyPager            = "pager"
+	KeyTimezone         = "timezone"
+	KeyColor            = "color"
+	KeyProfile          = "profile"
+	KeyCredentialHelper = "credential_helper"
+	KeyAPIURL           = "api_url"
+	KeyProject          = "project"
+	KeyLabels           = "labels"
+	KeyBranch           = "branch"
+)
+
+// Keys lnr auth login saves in a profile
+const (
+	// ProfileAPIKey holds a profile's Linear API key. It is not a setting.
+	ProfileAPIKey = "api_key"
+	// ProfileAPIURL holds a profile's GraphQL endpoint, when it is not
+	// Linear's. It is the api_url setting, saved in the profile.
+	ProfileAPIURL = KeyAPIURL
+)
+
+// Key describes a setting that can be set in a config file or the
+// environment
+type Key struct {
+	Name        string
+	Env         string
+	Default     string
+	Description string
+	// Repo allows the key in a repository config file. Keys that choose a
+	// command to run or credentials to use are not, since a cloned
+	// repository must not be able to set them.
+	Repo     bool
+	validate func(string) error
+}
+
+// Keys are the known settings, in the order they are listed
+var Keys = []Key{
+	{Name: KeyTeam, Env: "LNR_TEAM", Repo: true, Description: "Team used when a command is not given one"},
+	{Name: KeyOutput, Env: "LNR_OUTPUT", Repo: true, Default: "table", Description: "Output format, table or json", validate: oneOf("table", "json")},
+	{Name: KeyLimit, Env: "LNR_LIMIT", Repo: true, Description: "Maximum number of results list commands return", validate: positiveInt},
+	{Name: KeyEditor, Env: EnvEditor, Description: "Editor command, before $VISUAL and $EDITOR"},
+	{Name: KeyPager, Env: "LNR_PAGER", Description: "Pager command for terminal output, such as less"},
+	{Name: KeyTimezone, Env: "LNR_TIMEZONE", Description: "Time zone for dates, such as Europe/London", validate: timezone},
+	{Name: KeyColor, Env: "LNR_COLOR", Default: "auto", Description: "Colour output, auto, always or never", validate: oneOf("auto", "always", "never")},
+	{Name: KeyProfile, Env: EnvProfile, Description: "Profile to use, see lnr auth login"},
+	{Name: KeyCredentialHelper, Env: "LNR_CREDENTIAL_HELPER", Description: "Command that prints the API key, used when no key is stored"},
+	{Name: KeyAPIURL, Env: EnvAPIURL, Description: "GraphQL endpoint, when it is not Linear's"},
+	{Name: KeyProject, Env: "LNR_PROJECT", Repo: true, Description: "Project for issue list and issue create when not given one"},
+	{Name: KeyLabels, Env: "LNR_LABELS", Repo: true, Description: "Labels, comma-separated, that issue create adds when given none"},
+	{Name: KeyBranch, Env: "LNR_BRANCH", Repo: true, Default: "{identifier}-{title}", Description: "Branch name format for lnr issue branch", validate: branchFormat},
+}
+
+// BranchFields are the placeholders a branch format can use
+var BranchFields = []string{"identifier", "number", "team", "title"}
+
+// LookupKey returns the setting called name
+func LookupKey(name string) (Key, bool) {
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// Validate checks value is allowed for the key
+func (k Key) Validate(value string) error {
+	if k.validate == nil {
+		return nil
+	}
+	if err := k.validate(value); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", k.Name, value, err)
+	}
+	return nil
+}
+
+func oneOf(values ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if value == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be %s or %s", strings.Join(values[:len(values)-1], ", "), values[len(values)-1])
+	}
+}
+
+func positiveInt(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 1 {
+		return errors.New("must be a whole number of at least 1")
+	}
+	return nil
+}
+
+func timezone(value string) error {
+	if _, err := time.LoadLocation(value); err != nil {
+		return errors.New("must be a time zone such as UTC or Europe/London")
+	}
+	return nil
+}
+
+func branchFormat(value string) error {
+	rest := value
+	for {
+		_, field, ok := strings.Cut(rest, "{")
+		if !ok {
+			return nil
+		}
+		field, rest, ok = strings.Cut(field, "}")
+		if !ok || !slices.Contains(BranchFields, field) {
+			return fmt.Errorf("placeholders must be {%s}", strings.Join(BranchFields, "}, {"))
+		}
+	}
+}
+
+// Value is a resolved setting and where it came from
+type Value struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Source is the environment variable or file the value came from, or
+	// "default"
+	Source string `json:"source"`
+}
+
+// Settings are the settings from the environment and config files
+type Settings struct {
+	// User is the user config file, which may not exist yet
+	User *File
+	// Repo is the nearest repository config file, or nil if there is none
+	Repo *File
+
+	// profile is the --profile flag, which beats every other source
+	profile string
+}
+
+// UserConfigPath returns the path of the user config file,
+// $XDG_CONFIG_HOME/lnr/config.yaml or ~/.config/lnr/config.yaml
+func UserConfigPath() (string, error) {
+	dir := os.Getenv(EnvConfigHome)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("find config directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "lnr", "config.yaml"), nil
+}
+
+// FindRepoConfig returns the path of the nearest RepoConfigName in dir or
+// its parents, or "" if there is none
+func FindRepoConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, RepoConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadSettings reads the user config file and the repository config file
+// for the working directory, and checks their values
+func LoadSettings() (*Settings, error) {
+	return LoadSettingsWith(Overrides{})
+}
+
+// LoadSettingsWith reads settings as LoadSettings does, using the profile
+// in o when it is set
+func LoadSettingsWith(o Overrides) (*Settings, error) {
+	path, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	s := &Settings{profile: o.Profile}
+	if s.User, err = ReadFile(path); err != nil {
+		return nil, err
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if path := FindRepoConfig(wd); path != "" {
+			if s.Repo, err = ReadFile(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.Repo != nil {
+		for _, k := range Keys {
+			if _, ok := s.Repo.Get(k.Name); ok && !k.Repo {
+				return nil, fmt.Errorf("%s: %s can only be set in %s or the environment", s.Repo.Path, k.Name, path)
+			}
+		}
+	}
+	for _, f := range s.files() {
+		for _, k := range Keys {
+			if value, ok := f.Get(k.Name); ok {
+				if err := k.Validate(value); err != nil {
+					return nil, fmt.Errorf("%s: %w", f.Path, err)
+				}
+			}
+		}
+	}
+	for _, name := range s.User.Profiles() {
+		for _, k := range Keys {
+			if value, ok := s.User.ProfileGet(name, k.Name); ok {
+				if err := k.Validate(value); err != nil {
+					return nil, fmt.Errorf("%s: profile %s: %w", s.User.Path, name, err)
+				}
+			}
+		}
+	}
+	for _, k := range Keys {
+		if value := os.Getenv(k.Env); value != "" {
+			if err := k.Validate(value); err != nil {
+				return nil, fmt.Errorf("%s: %w", k.Env, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+// files returns the config files, highest precedence first
+func (s *Settings) files() []*File {
+	if s.Repo != nil {
+		return []*File{s.Repo, s.User}
+	}
+	return []*File{s.User}
+}
+
+// Get resolves a setting. The --profile flag comes first for the profile,
+// then the environment, then the repository config file for keys allowed
+// there, then the active profile, then the rest of the user config file,
+// then the built-in default.
+func (s *Settings) Get(name string) Value {
+	k, _ := LookupKey(name)
+	return s.get(name, k.Repo)
+}
+
+// UserGet resolves a setting as Get does, but ignores the repository config
+// file, which anyone with commit access to the repository controls
+func (s *Settings) UserGet(name string) Value {
+	return s.get(name, false)
+}
+
+func (s *Settings) get(name string, repo bool) Value {
+	k, _ := LookupKey(name)
+	if name == KeyProfile && s.profile != "" {
+		return Value{Key: name, Value: s.profile, Source: "--profile"}
+	}
+	if value := os.Getenv(k.Env); k.Env != "" && value != "" {
+		return Value{Key: name, Value: value, Source: k.Env}
+	}
+	if repo && s.Repo != nil {
+		if value, ok := s.Repo.Get(name); ok {
+			return Value{Key: name, Value: value, Source: s.Repo.Path}
+		}
+	}
+	if name != KeyProfile {
+		if profile := s.Profile(); profile != "" {
+			if value, ok := s.User.ProfileGet(profile, name); ok {
+				return Value{Key: name, Value: value, Source: fmt.Sprintf("%s (profile %s)", s.User.Path, profile)}
+			}
+		}
+	}
+	if value, ok := s.User.Get(name); ok {
+		return Value{Key: name, Value: value, Source: s.User.Path}
+	}
+	return Value{Key: name, Value: k.Default, Source: "default"}
+}
+
+// Profile returns the name of the active profile, or "" if there is none
+func (s *Settings) Profile() string {
+	return s.Get(KeyProfile).Value
+}
+
+// All resolves every known setting
+func (s *Settings) All() []Value {
+	values := make([]Value, len(Keys))
+	for i, k := range Keys {
+		values[i] = s.Get(k.Name)
+	}
+	return values
+}
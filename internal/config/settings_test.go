@@ -0,0 +1,271 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain keeps the tests away from the user's own config file
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "lnr-config-test")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv(EnvConfigHome, dir)
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestFile_SetKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("# My settings\nteam: ENG # the main team\nfuture: {nested: true}\npager: less\n"), 0o600))
+
+	f, err := ReadFile(path)
+	require.NoError(t, err)
+	value, ok := f.Get("team")
+	assert.True(t, ok)
+	assert.Equal(t, "ENG", value)
+	_, ok = f.Get("future")
+	assert.False(t, ok, "only single values are settings")
+
+	f.Set("team", "DES")
+	f.Set("limit", "25")
+	assert.True(t, f.Unset("pager"))
+	assert.False(t, f.Unset("pager"))
+	require.NoError(t, f.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# My settings\nteam: DES # the main team\nfuture: {nested: true}\nlimit: 25\n", string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFile_Missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lnr", "config.yaml")
+	f, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, f.Keys())
+
+	f.Set("team", "ENG")
+	require.NoError(t, f.Save())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "team: ENG\n", string(data))
+}
+
+func TestFile_NotAMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- team\n"), 0o600))
+	_, err := ReadFile(path)
+	assert.ErrorContains(t, err, "expected a mapping")
+}
+
+func TestUserConfigPath(t *testing.T) {
+	t.Setenv(EnvConfigHome, "/xdg")
+	path, err := UserConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/xdg", "lnr", "config.yaml"), path)
+
+	t.Setenv(EnvConfigHome, "")
+	t.Setenv("HOME", "/home/ada")
+	path, err = UserConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/ada", ".config", "lnr", "config.yaml"), path)
+}
+
+func TestLoadSettings_Precedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	userPath := filepath.Join(home, "lnr", "config.yaml")
+	require.NoError(t, os.WriteFile(userPath, []byte("team: ENG\nlimit: 10\npager: less\n"), 0o600))
+
+	repo := t.TempDir()
+	repoPath := filepath.Join(repo, RepoConfigName)
+	require.NoError(t, os.WriteFile(repoPath, []byte("team: DES\nlimit: 20\n"), 0o600))
+	sub := filepath.Join(repo, "src", "app")
+	require.NoError(t, os.MkdirAll(sub, 0o755))
+	chdir(t, sub)
+	t.Setenv("LNR_LIMIT", "30")
+
+	s, err := LoadSettings()
+	require.NoError(t, err)
+	assert.Equal(t, Value{Key: KeyLimit, Value: "30", Source: "LNR_LIMIT"}, s.Get(KeyLimit))
+	assert.Equal(t, Value{Key: KeyTeam, Value: "DES", Source: repoPath}, s.Get(KeyTeam))
+	assert.Equal(t, Value{Key: KeyPager, Value: "less", Source: userPath}, s.Get(KeyPager))
+	assert.Equal(t, Value{Key: KeyColor, Value: "auto", Source: "default"}, s.Get(KeyColor))
+	assert.Len(t, s.All(), len(Keys))
+}
+
+func TestLoadSettings_Invalid(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	chdir(t, t.TempDir())
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "lnr", "config.yaml"), []byte("output: xml\n"), 0o600))
+
+	_, err := LoadSettings()
+	assert.ErrorContains(t, err, `invalid output "xml": must be table or json`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(home, "lnr", "config.yaml"), nil, 0o600))
+	t.Setenv("LNR_COLOR", "sometimes")
+	_, err = LoadSettings()
+	assert.ErrorContains(t, err, `LNR_COLOR: invalid color "sometimes": must be auto, always or never`)
+
+	t.Setenv("LNR_COLOR", "")
+	t.Setenv("LNR_BRANCH", "{user}/{identifier}")
+	_, err = LoadSettings()
+	assert.ErrorContains(t, err, `invalid branch "{user}/{identifier}": placeholders must be {identifier}, {number}, {team}, {title}`)
+	t.Setenv("LNR_BRANCH", "feature/{team}-{number}")
+	_, err = LoadSettings()
+	assert.NoError(t, err)
+}
+
+func TestLoadSettings_RepoKeys(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	repo := t.TempDir()
+	chdir(t, repo)
+
+	for _, k := range Keys {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, RepoConfigName), []byte(k.Name+": less\n"), 0o644))
+		_, err := LoadSettings()
+		if k.Repo {
+			// Only the validation can fail
+			if err != nil {
+				assert.NotContains(t, err.Error(), "can only be set", k.Name)
+			}
+			continue
+		}
+		assert.EqualError(t, err, filepath.Join(repo, RepoConfigName)+": "+k.Name+" can only be set in "+
+			filepath.Join(home, "lnr", "config.yaml")+" or the environment")
+	}
+
+	s := &Settings{User: &File{}, Repo: &File{}}
+	s.Repo.Set(KeyPager, "sh -c evil")
+	s.Repo.Set(KeyTeam, "ENG")
+	assert.Equal(t, "", s.Get(KeyPager).Value, "keys not allowed in the repository are ignored there")
+	assert.Equal(t, "ENG", s.Get(KeyTeam).Value)
+}
+
+func TestFile_List(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RepoConfigName)
+	require.NoError(t, os.WriteFile(path, []byte("labels:\n  - cli\n  - backend\nproject: [a, {b: c}]\n"), 0o600))
+	f, err := ReadFile(path)
+	require.NoError(t, err)
+
+	labels, ok := f.Get(KeyLabels)
+	assert.True(t, ok)
+	assert.Equal(t, "cli,backend", labels)
+	_, ok = f.Get(KeyProject)
+	assert.False(t, ok, "only lists of single values are read")
+}
+
+// chdir changes the working directory until the test ends
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestLoad_Profile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	chdir(t, t.TempDir())
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvAPIURL, "")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	userPath := filepath.Join(home, "lnr", "config.yaml")
+	require.NoError(t, os.WriteFile(userPath, []byte(`profile: work
+team: ENG
+limit: 10
+profiles:
+  work:
+    api_key: work-key
+    api_url: http://work.example/graphql
+    team: OPS
+  home:
+    api_key: home-key
+`), 0o600))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "work", cfg.Profile)
+	assert.Equal(t, "work-key", cfg.APIKey)
+	assert.Equal(t, "http://work.example/graphql", cfg.APIURL)
+	assert.Equal(t, Value{Key: KeyTeam, Value: "OPS", Source: userPath + " (profile work)"}, cfg.Settings.Get(KeyTeam))
+	assert.Equal(t, "10", cfg.Settings.Get(KeyLimit).Value, "profiles fall back to the top-level settings")
+
+	t.Setenv(EnvProfile, "home")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "home-key", cfg.APIKey)
+	assert.Equal(t, "", cfg.APIURL)
+	assert.Equal(t, "ENG", cfg.Settings.Get(KeyTeam).Value)
+
+	t.Setenv(EnvAPIKey, "env-key")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "env-key", cfg.APIKey, "the environment beats the profile")
+
+	t.Setenv(EnvProfile, "play")
+	_, err = Load()
+	assert.EqualError(t, err, `unknown profile "play": run lnr auth login --profile play`)
+}
+
+func TestLoad_APIURLSetting(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	repo := t.TempDir()
+	chdir(t, repo)
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+	t.Setenv(EnvAPIKey, "")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "lnr"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "lnr", "config.yaml"), []byte(`profile: home
+api_url: http://top.example/graphql
+profiles:
+  work:
+    api_key: work-key
+    api_url: http://work.example/graphql
+  home:
+    api_key: home-key
+`), 0o600))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "http://top.example/graphql", cfg.APIURL, "the top-level setting applies to profiles without an endpoint")
+
+	t.Setenv(EnvProfile, "work")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "http://work.example/graphql", cfg.APIURL, "the profile's endpoint comes first")
+
+	t.Setenv(EnvAPIURL, "http://env.example/graphql")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "http://env.example/graphql", cfg.APIURL)
+}
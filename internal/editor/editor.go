@@ -0,0 +1,68 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the user's preferred editor from $LNR_EDITOR, which the
+// editor setting is passed on in, then $VISUAL or $EDITOR, falling back to a
+// platform default
+func Command() string {
+	for _, env := range []string{"LNR_EDITOR", "VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Edit opens the editor on a temporary file containing initial and returns
+// the saved contents. pattern names the temporary file as in os.CreateTemp,
+// so an extension such as "*.md" enables syntax highlighting.
+func Edit(initial, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.WriteString(initial); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := Open(path); err != nil {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+	return string(edited), nil
+}
+
+// Open opens the editor on path and waits for it to exit
+func Open(path string) error {
+	// The editor may include arguments, e.g. "code --wait"
+	args := strings.Fields(Command())
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", args[0], err)
+	}
+	return nil
+}
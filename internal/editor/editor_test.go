@@ -0,0 +1,41 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	t.Setenv("LNR_EDITOR", "")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", Command())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", Command())
+
+	t.Setenv("LNR_EDITOR", "hx")
+	assert.Equal(t, "hx", Command())
+}
+
+func TestEdit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+
+	// The fake editor appends a line to the file it is given
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho edited >> \"$1\"\n"), 0o755))
+	t.Setenv("LNR_EDITOR", "")
+	t.Setenv("VISUAL", script)
+
+	got, err := Edit("original\n", "lnr-*.md")
+
+	require.NoError(t, err)
+	assert.Equal(t, "original\nedited\n", got)
+}
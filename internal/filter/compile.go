@@ -0,0 +1,507 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldKind describes how a field's values are compiled
+type fieldKind int
+
+const (
+	kindUser fieldKind = iota
+	kindState
+	kindTeam
+	kindProject
+	kindCycle
+	kindLabel
+	kindPriority
+	kindNumber
+	kindDate
+	kindText
+)
+
+// fieldSpec maps a query field onto the IssueFilter
+type fieldSpec struct {
+	key      string
+	kind     fieldKind
+	nullable bool
+	// future means relative dates count forwards from now, as for due dates
+	future bool
+}
+
+// fields lists the fields understood by the query language
+var fields = map[string]fieldSpec{
+	"assignee":  {key: "assignee", kind: kindUser, nullable: true},
+	"creator":   {key: "creator", kind: kindUser, nullable: true},
+	"state":     {key: "state", kind: kindState},
+	"team":      {key: "team", kind: kindTeam},
+	"project":   {key: "project", kind: kindProject, nullable: true},
+	"cycle":     {key: "cycle", kind: kindCycle, nullable: true},
+	"label":     {key: "labels", kind: kindLabel},
+	"priority":  {key: "priority", kind: kindPriority},
+	"estimate":  {key: "estimate", kind: kindNumber, nullable: true},
+	"created":   {key: "createdAt", kind: kindDate},
+	"updated":   {key: "updatedAt", kind: kindDate},
+	"completed": {key: "completedAt", kind: kindDate, nullable: true},
+	"due":       {key: "dueDate", kind: kindDate, nullable: true, future: true},
+	"title":     {key: "title", kind: kindText},
+}
+
+// stateTypes are the workflow state types; other state values match by name
+var stateTypes = map[string]bool{
+	"triage":    true,
+	"backlog":   true,
+	"unstarted": true,
+	"started":   true,
+	"completed": true,
+	"canceled":  true,
+}
+
+// priorities maps priority names onto Linear's numeric priorities
+var priorities = map[string]int{
+	"none":        0,
+	"no priority": 0,
+	"urgent":      1,
+	"high":        2,
+	"medium":      3,
+	"low":         4,
+}
+
+// ParsePriority parses a priority name (none, urgent, high, medium, low) or
+// number (0-4) into Linear's numeric priority
+func ParsePriority(s string) (int, error) {
+	if p, ok := priorities[strings.ToLower(s)]; ok {
+		return p, nil
+	}
+	p, err := strconv.Atoi(s)
+	if err != nil || p < 0 || p > 4 {
+		return 0, fmt.Errorf("invalid priority %q (expected 0-4 or none, urgent, high, medium, low)", s)
+	}
+	return p, nil
+}
+
+// negatedOps maps each comparison onto its inverse
+var negatedOps = map[string]string{
+	"eq":  "neq",
+	"neq": "eq",
+	"lt":  "gte",
+	"lte": "gt",
+	"gt":  "lte",
+	"gte": "lt",
+}
+
+// comparators maps query operators onto Linear comparator names
+var comparators = map[string]string{
+	":":  "eq",
+	"=":  "eq",
+	"!=": "neq",
+	"<":  "lt",
+	"<=": "lte",
+	">":  "gt",
+	">=": "gte",
+}
+
+// relativeDate matches durations such as 7d, 2w, 3m, 1y and 12h
+var relativeDate = regexp.MustCompile(`^(\d+)([hdwmy])$`)
+
+// absoluteDate is the layout for dates such as 2026-11-01
+const absoluteDate = "2006-01-02"
+
+// ParseIssueFilter compiles a query into a Linear IssueFilter. An empty
+// query returns nil.
+func ParseIssueFilter(input string) (map[string]interface{}, error) {
+	root, err := parse(input)
+	if err != nil || root == nil {
+		return nil, err
+	}
+	c := &compiler{input: input}
+	return c.compile(root, false)
+}
+
+// compiler turns a parsed query into an IssueFilter
+type compiler struct {
+	input string
+}
+
+func (c *compiler) errorAt(t *termNode, msg string) *ParseError {
+	return &ParseError{Input: c.input, Pos: t.pos, Len: t.len, Msg: msg}
+}
+
+// compile compiles n, pushing negation down to the terms
+func (c *compiler) compile(n node, negated bool) (map[string]interface{}, error) {
+	switch n := n.(type) {
+	case *andNode:
+		return c.compileGroup(n.children, negated, !negated)
+	case *orNode:
+		return c.compileGroup(n.children, negated, negated)
+	case *notNode:
+		return c.compile(n.child, !negated)
+	case *termNode:
+		return c.compileTerm(n, negated)
+	default:
+		return nil, fmt.Errorf("unexpected query node %T", n)
+	}
+}
+
+// compileGroup compiles children and joins them with "and" or "or"
+func (c *compiler) compileGroup(children []node, negated, and bool) (map[string]interface{}, error) {
+	filters := make([]map[string]interface{}, 0, len(children))
+	for _, child := range children {
+		f, err := c.compile(child, negated)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	if and {
+		return allOf(filters), nil
+	}
+	return anyOf(filters), nil
+}
+
+// compileTerm compiles a single comparison
+func (c *compiler) compileTerm(t *termNode, negated bool) (map[string]interface{}, error) {
+	if t.field == "" {
+		return c.compileText(t, "title", negated), nil
+	}
+
+	spec, ok := fields[t.field]
+	if !ok {
+		return nil, c.errorAt(t, fmt.Sprintf("unknown field %q (expected one of %s)", t.field, fieldNames()))
+	}
+
+	cmp := comparators[t.op]
+	ordered := cmp != "eq" && cmp != "neq"
+	if ordered && spec.kind != kindPriority && spec.kind != kindNumber && spec.kind != kindDate {
+		return nil, c.errorAt(t, fmt.Sprintf("operator %q is not supported for %q", t.op, t.field))
+	}
+	if ordered && len(t.values) > 1 {
+		return nil, c.errorAt(t, fmt.Sprintf("operator %q takes a single value", t.op))
+	}
+
+	// != is negated equality
+	if cmp == "neq" {
+		cmp = "eq"
+		negated = !negated
+	}
+
+	switch spec.kind {
+	case kindPriority:
+		return c.compilePriority(t, spec, cmp, negated)
+	case kindNumber:
+		return c.compileNumber(t, spec, cmp, negated)
+	case kindDate:
+		return c.compileDate(t, spec, cmp, negated)
+	case kindText:
+		return c.compileText(t, spec.key, negated), nil
+	}
+
+	// Relation fields: any of the values matches
+	matches := make([]map[string]interface{}, 0, len(t.values))
+	for _, v := range t.values {
+		m, err := c.compileRelation(t, spec, v, negated)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	if negated {
+		return allOf(matches), nil
+	}
+	return anyOf(matches), nil
+}
+
+// compileRelation compiles a match against a related entity
+func (c *compiler) compileRelation(t *termNode, spec fieldSpec, value string, negated bool) (map[string]interface{}, error) {
+	lower := strings.ToLower(value)
+
+	if spec.nullable && lower == "none" {
+		return map[string]interface{}{spec.key: map[string]interface{}{"null": !negated}}, nil
+	}
+
+	var inner map[string]interface{}
+	switch spec.kind {
+	case kindUser:
+		if lower == "@me" {
+			inner = map[string]interface{}{"isMe": map[string]interface{}{"eq": !negated}}
+		} else {
+			inner = matchText(value, negated, "email", "name", "displayName")
+		}
+	case kindState:
+		if stateTypes[lower] {
+			inner = compare("type", "eq", lower, negated)
+		} else {
+			inner = matchText(value, negated, "name")
+		}
+	case kindTeam:
+		inner = matchText(value, negated, "key", "name")
+	case kindProject:
+		inner = anyOrAll(negated,
+			matchText(value, negated, "name"),
+			compare("slugId", "eq", value, negated),
+		)
+	case kindCycle:
+		switch lower {
+		case "current", "active":
+			inner = map[string]interface{}{"isActive": map[string]interface{}{"eq": !negated}}
+		case "next":
+			inner = map[string]interface{}{"isNext": map[string]interface{}{"eq": !negated}}
+		case "previous":
+			inner = map[string]interface{}{"isPrevious": map[string]interface{}{"eq": !negated}}
+		default:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, c.errorAt(t, fmt.Sprintf("invalid cycle %q (expected a number, current, next or previous)", value))
+			}
+			inner = compare("number", "eq", number, negated)
+		}
+	case kindLabel:
+		// Labels are a collection: some label matches, or no label does
+		if negated {
+			return map[string]interface{}{spec.key: map[string]interface{}{"every": matchText(value, true, "name")}}, nil
+		}
+		return map[string]interface{}{spec.key: map[string]interface{}{"some": matchText(value, false, "name")}}, nil
+	}
+
+	filter := map[string]interface{}{spec.key: inner}
+	if negated && spec.nullable {
+		// Issues without the relation also fail to match the value
+		return anyOf([]map[string]interface{}{
+			{spec.key: map[string]interface{}{"null": true}},
+			filter,
+		}), nil
+	}
+	return filter, nil
+}
+
+// compilePriority compiles a priority comparison. Lower numbers are more
+// urgent, except that 0 means no priority and ranks below everything.
+func (c *compiler) compilePriority(t *termNode, spec fieldSpec, cmp string, negated bool) (map[string]interface{}, error) {
+	values := make([]int, 0, len(t.values))
+	for _, v := range t.values {
+		p, err := ParsePriority(v)
+		if err != nil {
+			return nil, c.errorAt(t, err.Error())
+		}
+		values = append(values, p)
+	}
+
+	if negated {
+		cmp = negatedOps[cmp]
+	}
+
+	switch cmp {
+	case "eq", "neq":
+		matches := make([]map[string]interface{}, 0, len(values))
+		for _, p := range values {
+			matches = append(matches, compare(spec.key, cmp, p, false))
+		}
+		if cmp == "neq" {
+			return allOf(matches), nil
+		}
+		return anyOf(matches), nil
+	case "lt", "lte":
+		// More urgent than, which never includes unprioritised issues
+		return map[string]interface{}{spec.key: map[string]interface{}{cmp: values[0], "gte": 1}}, nil
+	default:
+		// Less urgent than, which always includes unprioritised issues
+		return anyOf([]map[string]interface{}{
+			compare(spec.key, cmp, values[0], false),
+			compare(spec.key, "eq", 0, false),
+		}), nil
+	}
+}
+
+// compileNumber compiles a numeric comparison such as estimate>=3
+func (c *compiler) compileNumber(t *termNode, spec fieldSpec, cmp string, negated bool) (map[string]interface{}, error) {
+	if spec.nullable && len(t.values) == 1 && strings.EqualFold(t.values[0], "none") && cmp == "eq" {
+		return map[string]interface{}{spec.key: map[string]interface{}{"null": !negated}}, nil
+	}
+
+	values := make([]float64, 0, len(t.values))
+	for _, v := range t.values {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, c.errorAt(t, fmt.Sprintf("invalid number %q for %q", v, t.field))
+		}
+		values = append(values, n)
+	}
+
+	if negated {
+		cmp = negatedOps[cmp]
+	}
+	matches := make([]map[string]interface{}, 0, len(values))
+	for _, n := range values {
+		matches = append(matches, compare(spec.key, cmp, n, false))
+	}
+	if negated {
+		return c.withNull(spec, true, allOf(matches)), nil
+	}
+	return anyOf(matches), nil
+}
+
+// compileDate compiles a date comparison. Values are either absolute dates
+// (2026-11-01) or durations (7d, 2w, 3m, 1y, 12h) relative to now: in the
+// past for most fields, and in the future for due dates.
+//
+// updated>7d means updated within the last 7 days; due<7d means due within
+// the next 7 days; created:2026-01-01 means created on that day.
+func (c *compiler) compileDate(t *termNode, spec fieldSpec, cmp string, negated bool) (map[string]interface{}, error) {
+	if len(t.values) != 1 {
+		return nil, c.errorAt(t, fmt.Sprintf("%q takes a single date", t.field))
+	}
+	value := t.values[0]
+
+	if spec.nullable && strings.EqualFold(value, "none") && cmp == "eq" {
+		return map[string]interface{}{spec.key: map[string]interface{}{"null": !negated}}, nil
+	}
+
+	if m := relativeDate.FindStringSubmatch(value); m != nil {
+		duration := isoDuration(m[1], m[2])
+		if !spec.future {
+			duration = "-" + duration
+			// For past dates, "within the last N" reads naturally as both
+			// updated:7d and updated>7d
+			if cmp == "eq" {
+				cmp = "gt"
+			}
+		} else if cmp == "eq" {
+			cmp = "lt"
+		}
+		if negated {
+			cmp = negatedOps[cmp]
+		}
+		return c.withNull(spec, negated, compare(spec.key, cmp, duration, false)), nil
+	}
+
+	day, err := time.Parse(absoluteDate, value)
+	if err != nil {
+		return nil, c.errorAt(t, fmt.Sprintf("invalid date %q (expected YYYY-MM-DD or a duration such as 7d)", value))
+	}
+
+	if cmp == "eq" {
+		// Match anywhere within the day
+		start := day.Format(absoluteDate)
+		end := day.AddDate(0, 0, 1).Format(absoluteDate)
+		if negated {
+			return c.withNull(spec, true, anyOf([]map[string]interface{}{
+				compare(spec.key, "lt", start, false),
+				compare(spec.key, "gte", end, false),
+			})), nil
+		}
+		return map[string]interface{}{spec.key: map[string]interface{}{"gte": start, "lt": end}}, nil
+	}
+
+	if negated {
+		cmp = negatedOps[cmp]
+	}
+	return c.withNull(spec, negated, compare(spec.key, cmp, day.Format(absoluteDate), false)), nil
+}
+
+// withNull widens a negated match on a nullable field to include issues
+// where the field is not set
+func (c *compiler) withNull(spec fieldSpec, negated bool, filter map[string]interface{}) map[string]interface{} {
+	if !negated || !spec.nullable {
+		return filter
+	}
+	return anyOf([]map[string]interface{}{
+		{spec.key: map[string]interface{}{"null": true}},
+		filter,
+	})
+}
+
+// compileText compiles a case-insensitive substring match
+func (c *compiler) compileText(t *termNode, key string, negated bool) map[string]interface{} {
+	op := "containsIgnoreCase"
+	if negated {
+		op = "notContainsIgnoreCase"
+	}
+	matches := make([]map[string]interface{}, 0, len(t.values))
+	for _, v := range t.values {
+		matches = append(matches, map[string]interface{}{key: map[string]interface{}{op: v}})
+	}
+	if negated {
+		return allOf(matches)
+	}
+	return anyOf(matches)
+}
+
+// matchText matches value case-insensitively against any of the given
+// attributes, or none of them when negated
+func matchText(value string, negated bool, attrs ...string) map[string]interface{} {
+	op := "eqIgnoreCase"
+	if negated {
+		op = "neqIgnoreCase"
+	}
+	matches := make([]map[string]interface{}, 0, len(attrs))
+	for _, attr := range attrs {
+		matches = append(matches, map[string]interface{}{attr: map[string]interface{}{op: value}})
+	}
+	return anyOrAll(negated, matches...)
+}
+
+// compare builds a single comparator filter, inverting it when negated
+func compare(key, cmp string, value interface{}, negated bool) map[string]interface{} {
+	if negated {
+		cmp = negatedOps[cmp]
+	}
+	return map[string]interface{}{key: map[string]interface{}{cmp: value}}
+}
+
+// anyOrAll joins filters with "and" when negated and "or" otherwise
+func anyOrAll(negated bool, filters ...map[string]interface{}) map[string]interface{} {
+	if negated {
+		return allOf(filters)
+	}
+	return anyOf(filters)
+}
+
+// allOf joins filters with "and", collapsing single filters
+func allOf(filters []map[string]interface{}) map[string]interface{} {
+	return join("and", filters)
+}
+
+// anyOf joins filters with "or", collapsing single filters
+func anyOf(filters []map[string]interface{}) map[string]interface{} {
+	return join("or", filters)
+}
+
+func join(op string, filters []map[string]interface{}) map[string]interface{} {
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	list := make([]interface{}, len(filters))
+	for i, f := range filters {
+		list[i] = f
+	}
+	return map[string]interface{}{op: list}
+}
+
+// isoDuration converts an amount and unit into an ISO 8601 duration
+func isoDuration(amount, unit string) string {
+	switch unit {
+	case "h":
+		return "PT" + amount + "H"
+	case "w":
+		return "P" + amount + "W"
+	case "m":
+		return "P" + amount + "M"
+	case "y":
+		return "P" + amount + "Y"
+	default:
+		return "P" + amount + "D"
+	}
+}
+
+// fieldNames returns the supported field names for error messages
+func fieldNames() string {
+	names := []string{
+		"assignee", "creator", "state", "team", "project", "cycle", "label",
+		"priority", "estimate", "created", "updated", "completed", "due", "title",
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,180 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// m is shorthand for building expected filters
+type m = map[string]interface{}
+
+// l is shorthand for building expected filter lists
+type l = []interface{}
+
+func TestParseIssueFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]interface{}
+	}{
+		{
+			name:  "assignee me",
+			input: "assignee:@me",
+			want:  m{"assignee": m{"isMe": m{"eq": true}}},
+		},
+		{
+			name:  "assignee by email or name",
+			input: "assignee:alice@example.com",
+			want: m{"assignee": m{"or": l{
+				m{"email": m{"eqIgnoreCase": "alice@example.com"}},
+				m{"name": m{"eqIgnoreCase": "alice@example.com"}},
+				m{"displayName": m{"eqIgnoreCase": "alice@example.com"}},
+			}}},
+		},
+		{
+			name:  "unassigned",
+			input: "assignee:none",
+			want:  m{"assignee": m{"null": true}},
+		},
+		{
+			name:  "state types and names",
+			input: `state:started,"In Review"`,
+			want: m{"or": l{
+				m{"state": m{"type": m{"eq": "started"}}},
+				m{"state": m{"name": m{"eqIgnoreCase": "In Review"}}},
+			}},
+		},
+		{
+			name:  "label",
+			input: "label:bug",
+			want:  m{"labels": m{"some": m{"name": m{"eqIgnoreCase": "bug"}}}},
+		},
+		{
+			name:  "negated label",
+			input: "-label:bug",
+			want:  m{"labels": m{"every": m{"name": m{"neqIgnoreCase": "bug"}}}},
+		},
+		{
+			name:  "negated project includes issues without a project",
+			input: "-project:Infra",
+			want: m{"or": l{
+				m{"project": m{"null": true}},
+				m{"project": m{"and": l{
+					m{"name": m{"neqIgnoreCase": "Infra"}},
+					m{"slugId": m{"neq": "Infra"}},
+				}}},
+			}},
+		},
+		{
+			name:  "more urgent than high",
+			input: "priority<=2",
+			want:  m{"priority": m{"lte": 2, "gte": 1}},
+		},
+		{
+			name:  "less urgent than high includes no priority",
+			input: "priority>high",
+			want: m{"or": l{
+				m{"priority": m{"gt": 2}},
+				m{"priority": m{"eq": 0}},
+			}},
+		},
+		{
+			name:  "estimate",
+			input: "estimate>=3",
+			want:  m{"estimate": m{"gte": 3.0}},
+		},
+		{
+			name:  "updated recently",
+			input: "updated>7d",
+			want:  m{"updatedAt": m{"gt": "-P7D"}},
+		},
+		{
+			name:  "due soon",
+			input: "due<2w",
+			want:  m{"dueDate": m{"lt": "P2W"}},
+		},
+		{
+			name:  "created on a day",
+			input: "created:2026-01-31",
+			want:  m{"createdAt": m{"gte": "2026-01-31", "lt": "2026-02-01"}},
+		},
+		{
+			name:  "current cycle",
+			input: "cycle:current",
+			want:  m{"cycle": m{"isActive": m{"eq": true}}},
+		},
+		{
+			name:  "free text",
+			input: `login "dark mode"`,
+			want: m{"and": l{
+				m{"title": m{"containsIgnoreCase": "login"}},
+				m{"title": m{"containsIgnoreCase": "dark mode"}},
+			}},
+		},
+		{
+			name:  "or group with negation pushed down",
+			input: "-(label:bug OR team:ENG)",
+			want: m{"and": l{
+				m{"labels": m{"every": m{"name": m{"neqIgnoreCase": "bug"}}}},
+				m{"team": m{"and": l{
+					m{"key": m{"neqIgnoreCase": "ENG"}},
+					m{"name": m{"neqIgnoreCase": "ENG"}},
+				}}},
+			}},
+		},
+		{
+			name:  "combined",
+			input: "assignee:@me state:started,unstarted priority<=2",
+			want: m{"and": l{
+				m{"assignee": m{"isMe": m{"eq": true}}},
+				m{"or": l{
+					m{"state": m{"type": m{"eq": "started"}}},
+					m{"state": m{"type": m{"eq": "unstarted"}}},
+				}},
+				m{"priority": m{"lte": 2, "gte": 1}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIssueFilter(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseIssueFilter_Empty(t *testing.T) {
+	got, err := ParseIssueFilter("")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestParseIssueFilter_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+		msg   string
+	}{
+		{"label:bug asignee:@me", 10, "unknown field \"asignee\""},
+		{"label<bug", 0, `operator "<" is not supported for "label"`},
+		{"priority:critical", 0, `invalid priority "critical"`},
+		{"updated>yesterday", 0, `invalid date "yesterday"`},
+		{"cycle:soon", 0, `invalid cycle "soon"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseIssueFilter(tt.input)
+			require.Error(t, err)
+
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.pos, parseErr.Pos)
+			assert.Contains(t, parseErr.Msg, tt.msg)
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseError describes a problem with a query, pointing at the offending token
+type ParseError struct {
+	Input string
+	Pos   int
+	Len   int
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	width := e.Len
+	if width < 1 {
+		width = 1
+	}
+	return fmt.Sprintf("invalid query: %s at position %d\n  %s\n  %s%s",
+		e.Msg, e.Pos+1, e.Input, strings.Repeat(" ", e.Pos), strings.Repeat("^", width))
+}
+
+// node is an element of a parsed query
+type node interface{}
+
+// andNode matches when all children match
+type andNode struct {
+	children []node
+}
+
+// orNode matches when any child matches
+type orNode struct {
+	children []node
+}
+
+// notNode inverts its child
+type notNode struct {
+	child node
+}
+
+// termNode is a single comparison such as label:bug or priority<=2. Free
+// text has an empty field.
+type termNode struct {
+	field  string
+	op     string
+	values []string
+	pos    int
+	len    int
+}
+
+// tokenKind identifies the kind of a lexical token
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenEOF
+)
+
+// token is a lexical token along with its position in the input
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators lists the comparison operators, longest first so that <= is
+// preferred over <
+var operators = []string{"!=", "<=", ">=", ":", "=", "<", ">"}
+
+// tokenize splits input into tokens. Words may contain quoted sections,
+// which keep their spaces and parentheses.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: byteOffset(runes, i)})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: byteOffset(runes, i)})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, token{kind: tokenNot, text: "-", pos: byteOffset(runes, i)})
+			i++
+		default:
+			start := i
+			inQuote := false
+			for i < len(runes) {
+				c := runes[i]
+				if c == '"' {
+					inQuote = !inQuote
+				} else if !inQuote && (unicode.IsSpace(c) || c == '(' || c == ')') {
+					break
+				}
+				i++
+			}
+			text := string(runes[start:i])
+			pos := byteOffset(runes, start)
+			if inQuote {
+				return nil, &ParseError{Input: input, Pos: pos, Len: len(text), Msg: "unterminated quote"}
+			}
+			kind := tokenWord
+			switch text {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos})
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// byteOffset converts a rune index into a byte offset for error reporting
+func byteOffset(runes []rune, i int) int {
+	return len(string(runes[:i]))
+}
+
+// parser is a recursive descent parser over a token stream
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+// parse parses a query into a tree of nodes. An empty query returns nil.
+func parse(input string) (node, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{input: input, tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorAt(tok, fmt.Sprintf("unexpected %q", tok.text))
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorAt(tok token, msg string) *ParseError {
+	return &ParseError{Input: p.input, Pos: tok.pos, Len: len(tok.text), Msg: msg}
+}
+
+// parseOr parses: and ( "OR" and )*
+func (p *parser) parseOr() (node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []node{first}
+	for p.peek().kind == tokenOr {
+		p.next()
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, n)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &orNode{children: children}, nil
+}
+
+// parseAnd parses: unary ( ["AND"] unary )*
+func (p *parser) parseAnd() (node, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	children := []node{first}
+	for {
+		tok := p.peek()
+		if tok.kind == tokenAnd {
+			p.next()
+		} else if tok.kind == tokenEOF || tok.kind == tokenOr || tok.kind == tokenRParen {
+			break
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, n)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &andNode{children: children}, nil
+}
+
+// parseUnary parses: ("-" | "NOT") unary | primary
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses: "(" or ")" | term
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokenRParen {
+			return nil, p.errorAt(tok, "unmatched parenthesis")
+		}
+		p.next()
+		return n, nil
+	case tokenWord:
+		return p.parseTerm(tok)
+	case tokenEOF:
+		return nil, p.errorAt(tok, "unexpected end of query")
+	default:
+		return nil, p.errorAt(tok, fmt.Sprintf("unexpected %q", tok.text))
+	}
+}
+
+// parseTerm splits a word into field, operator and comma-separated values
+func (p *parser) parseTerm(tok token) (node, error) {
+	text := tok.text
+	opIndex, op := findOperator(text)
+	if opIndex <= 0 || !isFieldName(text[:opIndex]) {
+		// Free text
+		return &termNode{values: []string{unquote(text)}, pos: tok.pos, len: len(text)}, nil
+	}
+
+	field := strings.ToLower(text[:opIndex])
+	rest := text[opIndex+len(op):]
+	if rest == "" {
+		return nil, &ParseError{Input: p.input, Pos: tok.pos, Len: len(text), Msg: fmt.Sprintf("missing value for %q", field)}
+	}
+
+	var values []string
+	for _, v := range splitValues(rest) {
+		v = unquote(v)
+		if v == "" {
+			return nil, &ParseError{Input: p.input, Pos: tok.pos, Len: len(text), Msg: fmt.Sprintf("empty value for %q", field)}
+		}
+		values = append(values, v)
+	}
+
+	return &termNode{field: field, op: op, values: values, pos: tok.pos, len: len(text)}, nil
+}
+
+// findOperator returns the index and text of the first operator outside quotes
+func findOperator(text string) (int, string) {
+	inQuote := false
+	for i := 0; i < len(text); i++ {
+		if text[i] == '"' {
+			inQuote = !inQuote
+			continue
+		}
+		if inQuote {
+			continue
+		}
+		for _, op := range operators {
+			if strings.HasPrefix(text[i:], op) {
+				return i, op
+			}
+		}
+	}
+	return -1, ""
+}
+
+// isFieldName reports whether s looks like a field name
+func isFieldName(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && r != '_' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// splitValues splits on commas outside quotes
+func splitValues(s string) []string {
+	var values []string
+	inQuote := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuote = !inQuote
+		case ',':
+			if !inQuote {
+				values = append(values, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(values, s[start:])
+}
+
+// unquote removes double quotes from a value
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
@@ -0,0 +1,116 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Empty(t *testing.T) {
+	n, err := parse("   ")
+	require.NoError(t, err)
+	assert.Nil(t, n)
+}
+
+func TestParse_ImplicitAnd(t *testing.T) {
+	n, err := parse("label:bug state:started")
+	require.NoError(t, err)
+
+	and, ok := n.(*andNode)
+	require.True(t, ok)
+	assert.Len(t, and.children, 2)
+}
+
+func TestParse_OrBindsLooserThanAnd(t *testing.T) {
+	n, err := parse("label:bug state:started OR priority:1")
+	require.NoError(t, err)
+
+	or, ok := n.(*orNode)
+	require.True(t, ok)
+	require.Len(t, or.children, 2)
+	_, ok = or.children[0].(*andNode)
+	assert.True(t, ok)
+}
+
+func TestParse_Term(t *testing.T) {
+	n, err := parse(`state:"In Review",started`)
+	require.NoError(t, err)
+
+	term, ok := n.(*termNode)
+	require.True(t, ok)
+	assert.Equal(t, "state", term.field)
+	assert.Equal(t, ":", term.op)
+	assert.Equal(t, []string{"In Review", "started"}, term.values)
+}
+
+func TestParse_Operators(t *testing.T) {
+	tests := []struct {
+		input string
+		field string
+		op    string
+		value string
+	}{
+		{"priority<=2", "priority", "<=", "2"},
+		{"priority<2", "priority", "<", "2"},
+		{"estimate>=3", "estimate", ">=", "3"},
+		{"updated>7d", "updated", ">", "7d"},
+		{"label!=bug", "label", "!=", "bug"},
+		{"Team=ENG", "team", "=", "ENG"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			n, err := parse(tt.input)
+			require.NoError(t, err)
+			term := n.(*termNode)
+			assert.Equal(t, tt.field, term.field)
+			assert.Equal(t, tt.op, term.op)
+			assert.Equal(t, []string{tt.value}, term.values)
+		})
+	}
+}
+
+func TestParse_Negation(t *testing.T) {
+	for _, input := range []string{"-project:Infra", "NOT project:Infra"} {
+		n, err := parse(input)
+		require.NoError(t, err)
+		not, ok := n.(*notNode)
+		require.True(t, ok, input)
+		assert.Equal(t, "project", not.child.(*termNode).field)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+		msg   string
+	}{
+		{`title:"unterminated`, 0, "unterminated quote"},
+		{"(label:bug", 0, "unmatched parenthesis"},
+		{"label:bug )", 10, `unexpected ")"`},
+		{"label:bug OR", 12, "unexpected end of query"},
+		{"state:", 0, `missing value for "state"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := parse(tt.input)
+			require.Error(t, err)
+
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.pos, parseErr.Pos)
+			assert.Equal(t, tt.msg, parseErr.Msg)
+		})
+	}
+}
+
+func TestParseError_PointsAtToken(t *testing.T) {
+	err := &ParseError{Input: "label:bug asignee:@me", Pos: 10, Len: 11, Msg: `unknown field "asignee"`}
+
+	assert.Equal(t, "invalid query: unknown field \"asignee\" at position 11\n"+
+		"  label:bug asignee:@me\n"+
+		"            ^^^^^^^^^^^", err.Error())
+}
@@ -0,0 +1,252 @@
+// Package oauth logs in to Linear through an OAuth app with the
+// authorization code flow and PKCE, and keeps the access token fresh
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Linear's OAuth endpoints and the scopes asked for by default
+const (
+	LinearAuthorizeURL = "https://linear.app/oauth/authorize"
+	LinearTokenURL     = "https://api.linear.app/oauth/token"
+	DefaultScopes      = "read,write"
+)
+
+// CallbackPath is where the loopback server receives the authorization code
+const CallbackPath = "/callback"
+
+// App is an OAuth application and the endpoints it authorizes with
+type App struct {
+	ClientID string
+	// ClientSecret is optional, as PKCE stands in for it
+	ClientSecret string
+	AuthorizeURL string
+	TokenURL     string
+	// Scopes are sent as given, e.g. "read,write" for Linear
+	Scopes string
+	// HTTPClient makes token requests; nil uses a client with a 30s timeout
+	HTTPClient *http.Client
+}
+
+// Token is an access token and what is needed to renew it
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	// Expiry is zero when the server did not say
+	Expiry time.Time
+}
+
+// LoginOptions configures Login
+type LoginOptions struct {
+	// Port is the loopback port to receive the callback on, which has to
+	// match a redirect URI registered for the app. Zero picks a free port.
+	Port int
+	// Open is called with the URL to authorize at, usually to open it in
+	// a browser
+	Open func(authorizeURL string) error
+}
+
+// Login sends the user to authorize the app and waits for the redirect to
+// a server on 127.0.0.1, then exchanges the code for a token. It gives up
+// when ctx is done.
+func (a *App) Login(ctx context.Context, opts LoginOptions) (*Token, error) {
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(opts.Port)))
+	if err != nil {
+		return nil, fmt.Errorf("start callback server: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", ln.Addr(), CallbackPath)
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(CallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case q.Get("state") != state:
+			// Not the redirect we are waiting for; keep waiting
+			http.Error(w, "Unexpected state, try logging in again.", http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("authorization failed: %s", describe(q.Get("error"), q.Get("error_description")))
+		case q.Get("code") == "":
+			res.err = errors.New("authorization failed: no code in the redirect")
+		default:
+			res.code = q.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			_, _ = io.WriteString(w, "lnr is logged in. You can close this tab.\n")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(ln) }()
+	defer func() { _ = server.Close() }()
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {a.ClientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	if a.Scopes != "" {
+		params.Set("scope", a.Scopes)
+	}
+	authorizeURL := a.AuthorizeURL + "?" + params.Encode()
+	if strings.Contains(a.AuthorizeURL, "?") {
+		authorizeURL = a.AuthorizeURL + "&" + params.Encode()
+	}
+	if opts.Open != nil {
+		if err := opts.Open(authorizeURL); err != nil {
+			return nil, err
+		}
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return a.token(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {res.code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	})
+}
+
+// Refresh exchanges a refresh token for a new token. Servers that do not
+// rotate refresh tokens leave the old one in place.
+func (a *App) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	token, err := a.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// tokenResponse is a token endpoint's reply, successful or not
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// token posts a grant to the token endpoint
+func (a *App) token(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", a.ClientID)
+	if a.ClientSecret != "" {
+		form.Set("client_secret", a.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := a.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	decodeErr := json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		if body.Error != "" {
+			return nil, fmt.Errorf("token request: %s", describe(body.Error, body.ErrorDescription))
+		}
+		return nil, fmt.Errorf("token request: %s", resp.Status)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("token request: %w", decodeErr)
+	}
+	if body.AccessToken == "" {
+		return nil, errors.New("token request: no access token in the response")
+	}
+	if body.TokenType != "" && !strings.EqualFold(body.TokenType, "bearer") {
+		return nil, fmt.Errorf("token request: unsupported token type %q", body.TokenType)
+	}
+
+	token := &Token{AccessToken: body.AccessToken, RefreshToken: body.RefreshToken}
+	if body.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// describe formats an OAuth error code and its description
+func describe(code, description string) string {
+	if description == "" {
+		return code
+	}
+	return code + ": " + description
+}
+
+// randomString returns 32 random bytes, encoded to be safe in URLs. It
+// serves as both the PKCE verifier and the state.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate PKCE verifier: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// challenge is the S256 PKCE challenge for verifier
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,133 @@
+package oauth_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/api/apitest"
+	"github.com/stustirling/lnr/internal/oauth"
+	"github.com/stustirling/lnr/internal/oauth/oauthtest"
+)
+
+func newApp(t *testing.T) (*oauth.App, *oauthtest.Server) {
+	t.Helper()
+	srv := oauthtest.NewServer("lnr-test")
+	t.Cleanup(srv.Close)
+	return &oauth.App{
+		ClientID:     "lnr-test",
+		AuthorizeURL: srv.AuthorizeURL,
+		TokenURL:     srv.TokenURL,
+		Scopes:       oauth.DefaultScopes,
+	}, srv
+}
+
+func TestLogin(t *testing.T) {
+	app, srv := newApp(t)
+
+	token, err := app.Login(context.Background(), oauth.LoginOptions{Open: oauthtest.Approve})
+	require.NoError(t, err)
+	assert.True(t, srv.Valid(token.AccessToken))
+	assert.NotEmpty(t, token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+
+	refreshed, err := app.Refresh(context.Background(), token.RefreshToken)
+	require.NoError(t, err)
+	assert.True(t, srv.Valid(refreshed.AccessToken))
+	assert.NotEqual(t, token.RefreshToken, refreshed.RefreshToken)
+
+	_, err = app.Refresh(context.Background(), token.RefreshToken)
+	assert.EqualError(t, err, "token request: invalid_grant: unknown refresh token", "refresh tokens are rotated")
+}
+
+func TestLogin_Errors(t *testing.T) {
+	app, _ := newApp(t)
+	app.ClientID = "someone-else"
+
+	_, err := app.Login(context.Background(), oauth.LoginOptions{Open: oauthtest.Approve})
+	assert.EqualError(t, err, "authorization failed: invalid_client")
+
+	// Nobody approves, so the login waits until it is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = app.Login(ctx, oauth.LoginOptions{Open: func(string) error { return nil }})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTokenSource(t *testing.T) {
+	app, srv := newApp(t)
+	token, err := app.Login(context.Background(), oauth.LoginOptions{Open: oauthtest.Approve})
+	require.NoError(t, err)
+
+	var saved []*oauth.Token
+	source := oauth.NewTokenSource(app, *token, func(t *oauth.Token) error {
+		saved = append(saved, t)
+		return nil
+	})
+	ctx := context.Background()
+
+	current, err := source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, token.AccessToken, current)
+	assert.Empty(t, saved)
+
+	// A rejected token is refreshed once, however many requests saw it
+	renewed, err := source.Refresh(ctx, current)
+	require.NoError(t, err)
+	again, err := source.Refresh(ctx, current)
+	require.NoError(t, err)
+	assert.Equal(t, renewed, again)
+	assert.Equal(t, 1, srv.Refreshes())
+	require.Len(t, saved, 1)
+	assert.Equal(t, renewed, saved[0].AccessToken)
+
+	// Tokens about to expire are refreshed before use
+	expiring := *saved[0]
+	expiring.Expiry = time.Now().Add(10 * time.Second)
+	source = oauth.NewTokenSource(app, expiring, nil)
+	current, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, expiring.AccessToken, current)
+	assert.Equal(t, 2, srv.Refreshes())
+
+	source = oauth.NewTokenSource(app, oauth.Token{AccessToken: "stale"}, nil)
+	_, err = source.Refresh(ctx, "stale")
+	assert.ErrorContains(t, err, "cannot be refreshed")
+}
+
+func TestClient_RefreshesOn401(t *testing.T) {
+	app, srv := newApp(t)
+	token, err := app.Login(context.Background(), oauth.LoginOptions{Open: oauthtest.Approve})
+	require.NoError(t, err)
+
+	linear := httptest.NewServer(srv.Protect(&apitest.Handler{Workspace: apitest.NewDemoWorkspace()}))
+	defer linear.Close()
+	client := api.NewClientWithOptions("", api.ClientOptions{
+		Endpoint:    linear.URL,
+		TokenSource: oauth.NewTokenSource(app, *token, nil),
+	})
+
+	viewer, err := client.GetViewer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", viewer.Name)
+	assert.Equal(t, 0, srv.Refreshes())
+
+	srv.Revoke(token.AccessToken)
+	viewer, err = client.GetViewer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", viewer.Name)
+	assert.Equal(t, 1, srv.Refreshes())
+
+	// With nothing to refresh with, the 401 comes through as such
+	client = api.NewClientWithOptions("", api.ClientOptions{
+		Endpoint:    linear.URL,
+		TokenSource: oauth.NewTokenSource(app, oauth.Token{AccessToken: "revoked"}, nil),
+	})
+	_, err = client.GetViewer(context.Background())
+	assert.ErrorIs(t, err, api.ErrUnauthenticated)
+	assert.ErrorContains(t, err, "run lnr auth login --oauth again")
+}
@@ -0,0 +1,245 @@
+// Package oauthtest provides a stand-in OAuth authorization server for
+// testing logins without Linear
+package oauthtest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paths the handler serves
+const (
+	AuthorizePath = "/oauth/authorize"
+	TokenPath     = "/oauth/token"
+)
+
+// grant is an authorization code waiting to be exchanged
+type grant struct {
+	challenge   string
+	redirectURI string
+}
+
+// Handler is an OAuth authorization server for one client. Its authorize
+// endpoint approves every request at once and redirects back with a code,
+// so following the redirect stands in for the user clicking "Authorize".
+// Codes must be exchanged with the matching PKCE verifier, and refresh
+// tokens are rotated on use.
+type Handler struct {
+	ClientID string
+	// AccessTTL is how long access tokens last; zero means an hour
+	AccessTTL time.Duration
+
+	mu        sync.Mutex
+	next      int
+	codes     map[string]grant
+	access    map[string]time.Time
+	refresh   map[string]bool
+	refreshes int
+}
+
+// NewHandler creates a server for the client clientID
+func NewHandler(clientID string) *Handler {
+	return &Handler{
+		ClientID: clientID,
+		codes:    map[string]grant{},
+		access:   map[string]time.Time{},
+		refresh:  map[string]bool{},
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case AuthorizePath:
+		h.authorize(w, r)
+	case TokenPath:
+		h.token(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirectURI.Hostname() != "127.0.0.1" {
+		http.Error(w, "redirect_uri must be a loopback address", http.StatusBadRequest)
+		return
+	}
+
+	back := redirectURI.Query()
+	back.Set("state", q.Get("state"))
+	switch {
+	case q.Get("client_id") != h.ClientID:
+		back.Set("error", "invalid_client")
+	case q.Get("response_type") != "code":
+		back.Set("error", "unsupported_response_type")
+	case q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256":
+		back.Set("error", "invalid_request")
+		back.Set("error_description", "PKCE with S256 is required")
+	default:
+		h.mu.Lock()
+		code := h.issue("code")
+		h.codes[code] = grant{challenge: q.Get("code_challenge"), redirectURI: redirectURI.String()}
+		h.mu.Unlock()
+		back.Set("code", code)
+	}
+	redirectURI.RawQuery = back.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (h *Handler) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "token requests must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") != h.ClientID {
+		writeError(w, "invalid_client", "")
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		g, ok := h.codes[r.PostForm.Get("code")]
+		delete(h.codes, r.PostForm.Get("code"))
+		if !ok || g.redirectURI != r.PostForm.Get("redirect_uri") {
+			writeError(w, "invalid_grant", "unknown code or redirect_uri")
+			return
+		}
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+			writeError(w, "invalid_grant", "code_verifier does not match the challenge")
+			return
+		}
+	case "refresh_token":
+		if !h.refresh[r.PostForm.Get("refresh_token")] {
+			writeError(w, "invalid_grant", "unknown refresh token")
+			return
+		}
+		delete(h.refresh, r.PostForm.Get("refresh_token"))
+		h.refreshes++
+	default:
+		writeError(w, "unsupported_grant_type", "")
+		return
+	}
+
+	ttl := h.AccessTTL
+	if ttl == 0 {
+		ttl = time.Hour
+	}
+	access, refresh := h.issue("access"), h.issue("refresh")
+	h.access[access] = time.Now().Add(ttl)
+	h.refresh[refresh] = true
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":  access,
+		"refresh_token": refresh,
+		"token_type":    "Bearer",
+		"expires_in":    int(ttl.Seconds()),
+	})
+}
+
+// issue returns a new token with the given prefix; h.mu is held
+func (h *Handler) issue(prefix string) string {
+	h.next++
+	return fmt.Sprintf("%s-%d", prefix, h.next)
+}
+
+func writeError(w http.ResponseWriter, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": description})
+}
+
+// Valid reports whether token is an access token that has not expired or
+// been revoked
+func (h *Handler) Valid(token string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	expiry, ok := h.access[token]
+	return ok && time.Now().Before(expiry)
+}
+
+// Revoke makes an access token invalid, as if it had expired early
+func (h *Handler) Revoke(token string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.access, token)
+}
+
+// Refreshes returns the number of times a token has been refreshed
+func (h *Handler) Refreshes() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.refreshes
+}
+
+// Protect requires requests to next to carry a valid access token, and
+// answers others with 401 as Linear does
+func (h *Handler) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !h.Valid(token) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":[{"message":"Authentication required, not authenticated","extensions":{"code":"AUTHENTICATION_ERROR"}}]}`)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Server is a Handler listening on a local port
+type Server struct {
+	*Handler
+	AuthorizeURL string
+	TokenURL     string
+
+	server *httptest.Server
+}
+
+// NewServer starts a server for the client clientID. Call Close when done.
+func NewServer(clientID string) *Server {
+	h := NewHandler(clientID)
+	srv := httptest.NewServer(h)
+	return &Server{
+		Handler:      h,
+		AuthorizeURL: srv.URL + AuthorizePath,
+		TokenURL:     srv.URL + TokenPath,
+		server:       srv,
+	}
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Approve follows an authorization URL the way a browser would once the
+// user approves, ending at the login's callback. Use it as
+// oauth.LoginOptions.Open.
+func Approve(authorizeURL string) error {
+	resp, err := http.Get(authorizeURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	// The callback reports errors to the login itself, but a request the
+	// authorize endpoint rejects never gets there
+	if resp.Request.URL.Path != "/callback" {
+		return fmt.Errorf("authorize: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// expiryMargin is how long before it expires a token is renewed, so it
+// does not run out mid-request
+const expiryMargin = time.Minute
+
+// TokenSource hands out an app's access token, refreshing it shortly
+// before it expires and whenever the API rejects it. It implements
+// api.TokenSource.
+type TokenSource struct {
+	app  *App
+	save func(*Token) error
+	now  func() time.Time
+
+	mu    sync.Mutex
+	token Token
+}
+
+// NewTokenSource starts from token. save, when not nil, is called with
+// every refreshed token so it can be stored for next time.
+func NewTokenSource(app *App, token Token, save func(*Token) error) *TokenSource {
+	return &TokenSource{app: app, save: save, now: time.Now, token: token}
+}
+
+// Token returns the current access token, refreshing it first if it is
+// about to expire
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiry := s.token.Expiry
+	if !expiry.IsZero() && s.now().Add(expiryMargin).After(expiry) && s.token.RefreshToken != "" {
+		if err := s.refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+	return s.token.AccessToken, nil
+}
+
+// Refresh renews the token after the API rejected it. If another request
+// has renewed it in the meantime, that token is returned instead.
+func (s *TokenSource) Refresh(ctx context.Context, rejected string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.AccessToken != rejected {
+		return s.token.AccessToken, nil
+	}
+	if err := s.refresh(ctx); err != nil {
+		return "", err
+	}
+	return s.token.AccessToken, nil
+}
+
+// refresh renews the token; s.mu is held
+func (s *TokenSource) refresh(ctx context.Context) error {
+	if s.token.RefreshToken == "" {
+		return errors.New("OAuth access token expired and cannot be refreshed; run lnr auth login --oauth again")
+	}
+	token, err := s.app.Refresh(ctx, s.token.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("refresh OAuth token: %w; run lnr auth login --oauth again if this persists", err)
+	}
+	s.token = *token
+	if s.save != nil {
+		// The old refresh token may no longer work, so losing the new
+		// one would log the profile out
+		if err := s.save(token); err != nil {
+			return fmt.Errorf("save refreshed OAuth token: %w", err)
+		}
+	}
+	return nil
+}
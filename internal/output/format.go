@@ -23,6 +23,7 @@ const (
 type Formatter struct {
 	format Format
 	writer io.Writer
+	color  bool
 }
 
 // NewFormatter creates a new formatter
@@ -42,6 +43,25 @@ func (f *Formatter) SetWriter(w io.Writer) {
 	f.writer = w
 }
 
+// SetColor turns colour on or off; it is off by default
+func (f *Formatter) SetColor(color bool) {
+	f.color = color
+}
+
+// IsJSON reports whether output is formatted as JSON
+func (f *Formatter) IsJSON() bool {
+	return f.format == FormatJSON
+}
+
+// PrintText outputs text as is, or jsonData when formatting as JSON
+func (f *Formatter) PrintText(text string, jsonData interface{}) error {
+	if f.format == FormatJSON {
+		return f.PrintJSON(jsonData)
+	}
+	_, err := io.WriteString(f.writer, text)
+	return err
+}
+
 // PrintJSON outputs data as formatted JSON
 func (f *Formatter) PrintJSON(data interface{}) error {
 	encoder := json.NewEncoder(f.writer)
@@ -56,7 +76,8 @@ func (f *Formatter) PrintTable(headers []string, rows [][]string) {
 		return
 	}
 
-	w := tabwriter.NewWriter(f.writer, 0, 0, 2, ' ', 0)
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
 
 	// Print header
 	_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
@@ -67,8 +88,22 @@ func (f *Formatter) PrintTable(headers []string, rows [][]string) {
 	}
 
 	_ = w.Flush()
+
+	table := buf.String()
+	if f.color {
+		// Colour the aligned header line, as escape codes inside cells
+		// would throw the column widths out
+		header, rest, _ := strings.Cut(table, "\n")
+		table = bold + header + reset + "\n" + rest
+	}
+	_, _ = io.WriteString(f.writer, table)
 }
 
+const (
+	bold  = "\x1b[1m"
+	reset = "\x1b[0m"
+)
+
 // Print outputs data in the configured format
 func (f *Formatter) Print(headers []string, rows [][]string, jsonData interface{}) error {
 	switch f.format {
@@ -164,6 +199,6 @@ func NameOrDash(name *string) string {
 // WarnIfTruncated prints a warning to stderr if results might be truncated
 func WarnIfTruncated(count, limit int) {
 	if count >= limit {
-		_, _ = fmt.Fprintf(os.Stderr, "Warning: Showing %d results. There may be more results available.\n", count)
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: Showing %d results. There may be more results available (use --limit or --all to fetch more).\n", count)
 	}
 }
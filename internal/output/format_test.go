@@ -64,6 +64,18 @@ func TestPrintTable_WithData(t *testing.T) {
 	assert.Contains(t, output, "bar")
 }
 
+func TestPrintTable_Color(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(false)
+	f.SetWriter(&buf)
+	f.SetColor(true)
+
+	f.PrintTable([]string{"NAME", "VALUE"}, [][]string{{"foo", "bar"}})
+
+	// Columns line up as if there were no escape codes
+	assert.Equal(t, "\x1b[1mNAME  VALUE\x1b[0m\nfoo   bar\n", buf.String())
+}
+
 func TestPrintDetail_Table(t *testing.T) {
 	var buf bytes.Buffer
 	f := NewFormatter(false)
@@ -161,3 +173,20 @@ func TestNameOrDash(t *testing.T) {
 	name := "John"
 	assert.Equal(t, "John", NameOrDash(&name))
 }
+
+func TestPrintText(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(false)
+	f.SetWriter(&buf)
+
+	err := f.PrintText("Deleted.\n", map[string]bool{"deleted": true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Deleted.\n", buf.String())
+
+	buf.Reset()
+	f = NewFormatter(true)
+	f.SetWriter(&buf)
+	require.NoError(t, f.PrintText("Deleted.\n", map[string]bool{"deleted": true}))
+	assert.JSONEq(t, `{"deleted": true}`, buf.String())
+}
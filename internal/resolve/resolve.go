@@ -0,0 +1,477 @@
+package resolve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stustirling/lnr/internal/api"
+)
+
+// maxCandidates is the number of suggestions listed in an ambiguity error
+const maxCandidates = 5
+
+// uuidPattern matches Linear entity IDs, which are passed through untouched
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// NotFoundError is returned when a reference matches nothing
+type NotFoundError struct {
+	Kind string
+	Ref  string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %q not found", e.Kind, e.Ref)
+}
+
+// Is lets errors.Is(err, api.ErrNotFound) match unresolved references
+func (e *NotFoundError) Is(target error) bool {
+	return target == api.ErrNotFound
+}
+
+// AmbiguousError is returned when a reference matches more than one entity
+type AmbiguousError struct {
+	Kind       string
+	Ref        string
+	Candidates []string
+	Hint       string
+}
+
+func (e *AmbiguousError) Error() string {
+	candidates := e.Candidates
+	more := ""
+	if len(candidates) > maxCandidates {
+		more = fmt.Sprintf(" (and %d more)", len(candidates)-maxCandidates)
+		candidates = candidates[:maxCandidates]
+	}
+	msg := fmt.Sprintf("ambiguous %s %q: did you mean %s%s?", e.Kind, e.Ref, joinOr(candidates), more)
+	if e.Hint != "" {
+		msg += " " + e.Hint
+	}
+	return msg
+}
+
+// IsID reports whether ref is a Linear entity ID rather than a name
+func IsID(ref string) bool {
+	return uuidPattern.MatchString(ref)
+}
+
+// Resolver turns human-friendly references into Linear entities. Lookups
+// are fetched once and reused for the lifetime of the resolver.
+type Resolver struct {
+	client api.Client
+	// refreshed records the kinds refetched past the cache after a miss,
+	// so each is refetched at most once
+	refreshed map[string]bool
+
+	viewer   *api.User
+	teams    []api.Team
+	users    []api.User
+	states   []api.WorkflowState
+	labels   []api.Label
+	projects []api.Project
+	// cycles and activeCycles are by team ID. A team without an active
+	// cycle maps to nil.
+	cycles       map[string][]api.Cycle
+	activeCycles map[string]*api.Cycle
+}
+
+// refresher is implemented by clients that serve lookups from a cache, such
+// as cache.Client
+type refresher interface {
+	// Refresh returns a copy of ctx whose lookups bypass the cache
+	Refresh(ctx context.Context) context.Context
+}
+
+// New creates a resolver backed by client
+func New(client api.Client) *Resolver {
+	return &Resolver{client: client, refreshed: map[string]bool{}}
+}
+
+// Team resolves a team by ID, key (ENG) or name
+func (r *Resolver) Team(ctx context.Context, ref string) (*api.Team, error) {
+	if IsID(ref) {
+		return &api.Team{ID: ref}, nil
+	}
+
+	return lookup(ctx, r, "team", r.loadTeams, func() (*api.Team, error) {
+		return match("team", ref, r.teams, "", func(t api.Team) string {
+			return fmt.Sprintf("%s (%s)", t.Key, t.Name)
+		},
+			func(t api.Team) bool { return strings.EqualFold(t.Key, ref) },
+			func(t api.Team) bool { return strings.EqualFold(t.Name, ref) },
+			func(t api.Team) bool { return hasPrefixFold(t.Name, ref) },
+		)
+	})
+}
+
+// loadTeams fetches every team once, or again when refresh is set
+func (r *Resolver) loadTeams(ctx context.Context, refresh bool) error {
+	if r.teams != nil && !refresh {
+		return nil
+	}
+	teams, err := r.client.GetTeams(ctx, api.ListOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("resolve team: %w", err)
+	}
+	r.teams = teams
+	return nil
+}
+
+// TeamID resolves an optional team reference to an ID, passing nil through
+func (r *Resolver) TeamID(ctx context.Context, ref *string) (*string, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	team, err := r.Team(ctx, *ref)
+	if err != nil {
+		return nil, err
+	}
+	return &team.ID, nil
+}
+
+// User resolves a user by ID, @me, email, display name or name
+func (r *Resolver) User(ctx context.Context, ref string) (*api.User, error) {
+	if IsID(ref) {
+		return &api.User{ID: ref}, nil
+	}
+	if ref == "@me" {
+		if r.viewer == nil {
+			viewer, err := r.client.GetViewer(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("resolve user: %w", err)
+			}
+			r.viewer = viewer
+		}
+		return r.viewer, nil
+	}
+
+	return lookup(ctx, r, "user", r.loadUsers, func() (*api.User, error) {
+		return match("user", ref, r.users, "", func(u api.User) string {
+			return fmt.Sprintf("%s <%s>", u.Name, u.Email)
+		},
+			func(u api.User) bool { return strings.EqualFold(u.Email, ref) },
+			func(u api.User) bool { return strings.EqualFold(u.DisplayName, ref) },
+			func(u api.User) bool { return strings.EqualFold(u.Name, ref) },
+			func(u api.User) bool {
+				local, _, _ := strings.Cut(u.Email, "@")
+				return strings.EqualFold(local, ref)
+			},
+			func(u api.User) bool { return hasPrefixFold(u.Name, ref) || hasPrefixFold(u.DisplayName, ref) },
+		)
+	})
+}
+
+// loadUsers fetches every user once, or again when refresh is set
+func (r *Resolver) loadUsers(ctx context.Context, refresh bool) error {
+	if r.users != nil && !refresh {
+		return nil
+	}
+	users, err := r.client.GetUsers(ctx, api.ListOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("resolve user: %w", err)
+	}
+	r.users = users
+	return nil
+}
+
+// State resolves a workflow state by ID or name. When teamID is set, only
+// that team's states are considered.
+func (r *Resolver) State(ctx context.Context, ref string, teamID *string) (*api.WorkflowState, error) {
+	if IsID(ref) {
+		return &api.WorkflowState{ID: ref}, nil
+	}
+
+	return lookup(ctx, r, "state", r.loadStates, func() (*api.WorkflowState, error) {
+		candidates := r.states
+		hint := ""
+		if teamID != nil {
+			candidates = make([]api.WorkflowState, 0, len(r.states))
+			for _, s := range r.states {
+				if s.Team != nil && s.Team.ID == *teamID {
+					candidates = append(candidates, s)
+				}
+			}
+		} else {
+			hint = "Use --team to choose a team."
+		}
+
+		return match("state", ref, candidates, hint, func(s api.WorkflowState) string {
+			if s.Team != nil {
+				return fmt.Sprintf("%s (%s)", s.Name, s.Team.Key)
+			}
+			return s.Name
+		},
+			func(s api.WorkflowState) bool { return strings.EqualFold(s.Name, ref) },
+			func(s api.WorkflowState) bool { return hasPrefixFold(s.Name, ref) },
+		)
+	})
+}
+
+// StateOfType returns the first state of a team's workflow with the given
+// type, such as started or completed, in workflow order
+func (r *Resolver) StateOfType(ctx context.Context, teamID, stateType string) (*api.WorkflowState, error) {
+	if err := r.loadStates(ctx, false); err != nil {
+		return nil, err
+	}
+
+	var first *api.WorkflowState
+	for i, s := range r.states {
+		if s.Team == nil || s.Team.ID != teamID || s.Type != stateType {
+			continue
+		}
+		if first == nil || s.Position < first.Position {
+			first = &r.states[i]
+		}
+	}
+	if first == nil {
+		return nil, &NotFoundError{Kind: "state", Ref: stateType}
+	}
+	return first, nil
+}
+
+// loadStates fetches every workflow state once, or again when refresh is set
+func (r *Resolver) loadStates(ctx context.Context, refresh bool) error {
+	if r.states != nil && !refresh {
+		return nil
+	}
+	states, err := r.client.GetWorkflowStates(ctx, api.WorkflowStateListOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("resolve state: %w", err)
+	}
+	r.states = states
+	return nil
+}
+
+// Label resolves a label by ID or name. When teamID is set, only that
+// team's labels and workspace labels are considered.
+func (r *Resolver) Label(ctx context.Context, ref string, teamID *string) (*api.Label, error) {
+	if IsID(ref) {
+		return &api.Label{ID: ref}, nil
+	}
+
+	return lookup(ctx, r, "label", r.loadLabels, func() (*api.Label, error) {
+		candidates := r.labels
+		hint := ""
+		if teamID != nil {
+			candidates = make([]api.Label, 0, len(r.labels))
+			for _, l := range r.labels {
+				if l.Team == nil || l.Team.ID == *teamID {
+					candidates = append(candidates, l)
+				}
+			}
+		} else {
+			hint = "Use --team to choose a team."
+		}
+
+		return match("label", ref, candidates, hint, func(l api.Label) string {
+			if l.Team != nil {
+				return fmt.Sprintf("%s (%s)", l.Name, l.Team.Key)
+			}
+			return l.Name
+		},
+			func(l api.Label) bool { return strings.EqualFold(l.Name, ref) },
+			func(l api.Label) bool { return hasPrefixFold(l.Name, ref) },
+		)
+	})
+}
+
+// loadLabels fetches every label once, or again when refresh is set
+func (r *Resolver) loadLabels(ctx context.Context, refresh bool) error {
+	if r.labels != nil && !refresh {
+		return nil
+	}
+	labels, err := r.client.GetLabels(ctx, api.LabelListOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("resolve label: %w", err)
+	}
+	r.labels = labels
+	return nil
+}
+
+// Project resolves a project by ID, name, slug ID or URL slug
+func (r *Resolver) Project(ctx context.Context, ref string) (*api.Project, error) {
+	if IsID(ref) {
+		return &api.Project{ID: ref}, nil
+	}
+	if r.projects == nil {
+		projects, err := r.client.GetProjects(ctx, api.ProjectListOptions{All: true})
+		if err != nil {
+			return nil, fmt.Errorf("resolve project: %w", err)
+		}
+		r.projects = projects
+	}
+
+	return match("project", ref, r.projects, "", func(p api.Project) string {
+		return p.Name
+	},
+		func(p api.Project) bool { return strings.EqualFold(p.Name, ref) },
+		func(p api.Project) bool {
+			return p.SlugID != "" && (strings.EqualFold(p.SlugID, ref) || strings.EqualFold(urlSlug(p.URL), ref))
+		},
+		func(p api.Project) bool { return hasPrefixFold(p.Name, ref) },
+	)
+}
+
+// Cycle resolves a team's cycle by ID, number, name, or one of current,
+// next and previous
+func (r *Resolver) Cycle(ctx context.Context, ref string, teamID string) (*api.Cycle, error) {
+	if IsID(ref) {
+		return &api.Cycle{ID: ref}, nil
+	}
+	switch strings.ToLower(ref) {
+	case "current", "active":
+		cycle, err := r.activeCycle(ctx, teamID)
+		if err != nil {
+			return nil, err
+		}
+		if cycle == nil {
+			return nil, &NotFoundError{Kind: "cycle", Ref: ref}
+		}
+		c := *cycle
+		return &c, nil
+	}
+
+	cycles, ok := r.cycles[teamID]
+	if !ok {
+		var err error
+		cycles, err = r.client.GetCycles(ctx, api.CycleListOptions{TeamID: &teamID, All: true})
+		if err != nil {
+			return nil, fmt.Errorf("resolve cycle: %w", err)
+		}
+		if r.cycles == nil {
+			r.cycles = map[string][]api.Cycle{}
+		}
+		r.cycles[teamID] = cycles
+	}
+
+	now := time.Now()
+	switch strings.ToLower(ref) {
+	case "next":
+		var next *api.Cycle
+		for i, c := range cycles {
+			if c.StartsAt.After(now) && (next == nil || c.StartsAt.Before(next.StartsAt)) {
+				next = &cycles[i]
+			}
+		}
+		if next == nil {
+			return nil, &NotFoundError{Kind: "cycle", Ref: ref}
+		}
+		c := *next
+		return &c, nil
+	case "previous":
+		var previous *api.Cycle
+		for i, c := range cycles {
+			if c.EndsAt.Before(now) && (previous == nil || c.EndsAt.After(previous.EndsAt)) {
+				previous = &cycles[i]
+			}
+		}
+		if previous == nil {
+			return nil, &NotFoundError{Kind: "cycle", Ref: ref}
+		}
+		c := *previous
+		return &c, nil
+	}
+
+	return match("cycle", ref, cycles, "", func(c api.Cycle) string {
+		return fmt.Sprintf("%d (%s)", c.Number, c.Name)
+	},
+		func(c api.Cycle) bool { return strconv.Itoa(c.Number) == ref },
+		func(c api.Cycle) bool { return c.Name != "" && strings.EqualFold(c.Name, ref) },
+	)
+}
+
+// activeCycle returns a team's active cycle, or nil if it has none
+func (r *Resolver) activeCycle(ctx context.Context, teamID string) (*api.Cycle, error) {
+	if cycle, ok := r.activeCycles[teamID]; ok {
+		return cycle, nil
+	}
+	cycle, err := r.client.GetActiveCycle(ctx, teamID)
+	if errors.Is(err, api.ErrNoActiveCycle) {
+		cycle, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve cycle: %w", err)
+	}
+	if r.activeCycles == nil {
+		r.activeCycles = map[string]*api.Cycle{}
+	}
+	r.activeCycles[teamID] = cycle
+	return cycle, nil
+}
+
+// lookup loads a kind's list and runs find over it. When find reports
+// nothing found and the list may have come from a cache, the list is
+// refetched past the cache once and find runs again, so an entity created
+// since the cache was filled, such as in the web UI, is not reported
+// missing until the cache expires.
+func lookup[T any](ctx context.Context, r *Resolver, kind string, load func(context.Context, bool) error, find func() (*T, error)) (*T, error) {
+	if err := load(ctx, false); err != nil {
+		return nil, err
+	}
+	item, err := find()
+
+	var notFound *NotFoundError
+	c, cached := r.client.(refresher)
+	if !errors.As(err, &notFound) || !cached || r.refreshed[kind] {
+		return item, err
+	}
+	r.refreshed[kind] = true
+	if err := load(c.Refresh(ctx), true); err != nil {
+		return nil, err
+	}
+	return find()
+}
+
+// match returns the single item accepted by the first tier that accepts any
+// items, or an error when no tier matches or a tier matches several items
+func match[T any](kind, ref string, items []T, hint string, describe func(T) string, tiers ...func(T) bool) (*T, error) {
+	for _, accept := range tiers {
+		var matches []T
+		for _, item := range items {
+			if accept(item) {
+				matches = append(matches, item)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return &matches[0], nil
+		default:
+			candidates := make([]string, len(matches))
+			for i, m := range matches {
+				candidates[i] = describe(m)
+			}
+			return nil, &AmbiguousError{Kind: kind, Ref: ref, Candidates: candidates, Hint: hint}
+		}
+	}
+	return nil, &NotFoundError{Kind: kind, Ref: ref}
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// urlSlug returns the last path segment of a URL
+func urlSlug(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	if i := strings.LastIndex(url, "/"); i != -1 {
+		return url[i+1:]
+	}
+	return url
+}
+
+// joinOr joins items as "a, b or c"
+func joinOr(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
@@ -0,0 +1,330 @@
+package resolve
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/cache"
+)
+
+var (
+	engTeam    = api.Team{ID: "team-eng", Key: "ENG", Name: "Engineering"}
+	emailTeam  = api.Team{ID: "team-eml", Key: "EML", Name: "Email"}
+	designTeam = api.Team{ID: "team-des", Key: "DES", Name: "Design"}
+)
+
+func newTestResolver() (*Resolver, *int) {
+	teamCalls := 0
+	client := &api.MockClient{
+		GetViewerFunc: func(ctx context.Context) (*api.User, error) {
+			return &api.User{ID: "user-me", Name: "Me"}, nil
+		},
+		GetTeamsFunc: func(ctx context.Context, opts api.ListOptions) ([]api.Team, error) {
+			teamCalls++
+			return []api.Team{engTeam, emailTeam, designTeam}, nil
+		},
+		GetUsersFunc: func(ctx context.Context, opts api.ListOptions) ([]api.User, error) {
+			return []api.User{
+				{ID: "user-alice", Name: "Alice Smith", DisplayName: "alice", Email: "alice@example.com"},
+				{ID: "user-alex", Name: "Alex Jones", DisplayName: "alexj", Email: "alex@example.com"},
+			}, nil
+		},
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			return []api.WorkflowState{
+				{ID: "state-eng-todo", Name: "Todo", Team: &engTeam},
+				{ID: "state-des-todo", Name: "Todo", Team: &designTeam},
+				{ID: "state-eng-review", Name: "In Review", Team: &engTeam},
+			}, nil
+		},
+		GetLabelsFunc: func(ctx context.Context, opts api.LabelListOptions) ([]api.Label, error) {
+			return []api.Label{
+				{ID: "label-bug", Name: "Bug"},
+				{ID: "label-eng-infra", Name: "Infra", Team: &engTeam},
+				{ID: "label-des-infra", Name: "Infra", Team: &designTeam},
+			}, nil
+		},
+		GetProjectsFunc: func(ctx context.Context, opts api.ProjectListOptions) ([]api.Project, error) {
+			return []api.Project{
+				{ID: "project-launch", Name: "Q3 Launch", SlugID: "abc123", URL: "https://linear.app/acme/project/q3-launch-abc123"},
+			}, nil
+		},
+	}
+	return New(client), &teamCalls
+}
+
+func TestTeam(t *testing.T) {
+	r, calls := newTestResolver()
+	ctx := context.Background()
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"ENG", "team-eng"},
+		{"eng", "team-eng"},
+		{"Design", "team-des"},
+		{"des", "team-des"},
+		{"Engin", "team-eng"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			team, err := r.Team(ctx, tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, team.ID)
+		})
+	}
+
+	// Teams are only fetched once
+	assert.Equal(t, 1, *calls)
+}
+
+func TestTeam_Ambiguous(t *testing.T) {
+	r, _ := newTestResolver()
+
+	_, err := r.Team(context.Background(), "E")
+
+	var ambiguous *AmbiguousError
+	require.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, []string{"ENG (Engineering)", "EML (Email)"}, ambiguous.Candidates)
+	assert.EqualError(t, err, `ambiguous team "E": did you mean ENG (Engineering) or EML (Email)?`)
+}
+
+func TestTeam_NotFound(t *testing.T) {
+	r, _ := newTestResolver()
+
+	_, err := r.Team(context.Background(), "OPS")
+
+	var notFound *NotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.EqualError(t, err, `team "OPS" not found`)
+}
+
+func TestUser_RefetchesPastCacheWhenNotFound(t *testing.T) {
+	users := []api.User{{ID: "user-alice", Name: "Alice Smith", Email: "alice@example.com"}}
+	calls := 0
+	mock := &api.MockClient{
+		GetUsersFunc: func(ctx context.Context, opts api.ListOptions) ([]api.User, error) {
+			calls++
+			return users, nil
+		},
+	}
+	client := cache.NewClient(mock, cache.NewStore(t.TempDir()))
+	ctx := context.Background()
+
+	_, err := New(client).User(ctx, "alice")
+	require.NoError(t, err)
+
+	// Bob joined after the users were cached
+	users = append(users, api.User{ID: "user-bob", Name: "Bob Brown", Email: "bob@example.com"})
+	r := New(client)
+	user, err := r.User(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, "user-bob", user.ID)
+	assert.Equal(t, 2, calls)
+
+	// A name that really is missing is refetched only once
+	_, err = r.User(ctx, "carol")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+	_, err = r.User(ctx, "dave")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+	assert.Equal(t, 2, calls)
+}
+
+func TestUser_NotFoundWithoutCacheFetchesOnce(t *testing.T) {
+	calls := 0
+	r := New(&api.MockClient{
+		GetUsersFunc: func(ctx context.Context, opts api.ListOptions) ([]api.User, error) {
+			calls++
+			return nil, nil
+		},
+	})
+
+	_, err := r.User(context.Background(), "bob")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTeam_IDPassthrough(t *testing.T) {
+	r, calls := newTestResolver()
+	id := "0f8e7d6c-5b4a-4321-8765-0123456789ab"
+
+	team, err := r.Team(context.Background(), id)
+
+	require.NoError(t, err)
+	assert.Equal(t, id, team.ID)
+	assert.Equal(t, 0, *calls)
+}
+
+func TestTeamID_Nil(t *testing.T) {
+	r, _ := newTestResolver()
+
+	id, err := r.TeamID(context.Background(), nil)
+
+	require.NoError(t, err)
+	assert.Nil(t, id)
+}
+
+func TestUser(t *testing.T) {
+	r, _ := newTestResolver()
+	ctx := context.Background()
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"@me", "user-me"},
+		{"alice@example.com", "user-alice"},
+		{"alexj", "user-alex"},
+		{"Alice Smith", "user-alice"},
+		{"alex", "user-alex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			user, err := r.User(ctx, tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, user.ID)
+		})
+	}
+}
+
+func TestUser_Ambiguous(t *testing.T) {
+	r, _ := newTestResolver()
+
+	_, err := r.User(context.Background(), "Al")
+
+	var ambiguous *AmbiguousError
+	require.True(t, errors.As(err, &ambiguous))
+	assert.Len(t, ambiguous.Candidates, 2)
+}
+
+func TestState_ScopedToTeam(t *testing.T) {
+	r, _ := newTestResolver()
+	teamID := "team-des"
+
+	state, err := r.State(context.Background(), "todo", &teamID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "state-des-todo", state.ID)
+}
+
+func TestState_AmbiguousWithoutTeam(t *testing.T) {
+	r, _ := newTestResolver()
+
+	_, err := r.State(context.Background(), "Todo", nil)
+
+	assert.EqualError(t, err, `ambiguous state "Todo": did you mean Todo (ENG) or Todo (DES)? Use --team to choose a team.`)
+}
+
+func TestLabel(t *testing.T) {
+	r, _ := newTestResolver()
+	ctx := context.Background()
+	teamID := "team-eng"
+
+	label, err := r.Label(ctx, "infra", &teamID)
+	require.NoError(t, err)
+	assert.Equal(t, "label-eng-infra", label.ID)
+
+	// Workspace labels are available to every team
+	label, err = r.Label(ctx, "bug", &teamID)
+	require.NoError(t, err)
+	assert.Equal(t, "label-bug", label.ID)
+}
+
+func TestProject(t *testing.T) {
+	r, _ := newTestResolver()
+	ctx := context.Background()
+
+	for _, ref := range []string{"Q3 Launch", "abc123", "q3-launch-abc123", "q3"} {
+		t.Run(ref, func(t *testing.T) {
+			project, err := r.Project(ctx, ref)
+			require.NoError(t, err)
+			assert.Equal(t, "project-launch", project.ID)
+		})
+	}
+}
+
+func TestAmbiguousError_TruncatesCandidates(t *testing.T) {
+	err := &AmbiguousError{
+		Kind:       "user",
+		Ref:        "a",
+		Candidates: []string{"a1", "a2", "a3", "a4", "a5", "a6", "a7"},
+	}
+
+	assert.Equal(t, `ambiguous user "a": did you mean a1, a2, a3, a4 or a5 (and 2 more)?`, err.Error())
+}
+
+func TestCycle(t *testing.T) {
+	var activeCalls, listCalls int
+	client := &api.MockClient{
+		GetActiveCycleFunc: func(ctx context.Context, teamID string) (*api.Cycle, error) {
+			assert.Equal(t, "team-eng", teamID)
+			activeCalls++
+			return &api.Cycle{ID: "cycle-current", Number: 12}, nil
+		},
+		GetCyclesFunc: func(ctx context.Context, opts api.CycleListOptions) ([]api.Cycle, error) {
+			listCalls++
+			return []api.Cycle{
+				{ID: "cycle-11", Number: 11},
+				{ID: "cycle-12", Number: 12},
+			}, nil
+		},
+	}
+	r := New(client)
+	ctx := context.Background()
+
+	cycle, err := r.Cycle(ctx, "current", "team-eng")
+	require.NoError(t, err)
+	assert.Equal(t, "cycle-current", cycle.ID)
+
+	cycle, err = r.Cycle(ctx, "11", "team-eng")
+	require.NoError(t, err)
+	assert.Equal(t, "cycle-11", cycle.ID)
+
+	_, err = r.Cycle(ctx, "99", "team-eng")
+	assert.EqualError(t, err, `cycle "99" not found`)
+
+	// Each team's cycles are fetched once
+	_, err = r.Cycle(ctx, "current", "team-eng")
+	require.NoError(t, err)
+	_, err = r.Cycle(ctx, "12", "team-eng")
+	require.NoError(t, err)
+	assert.Equal(t, 1, activeCalls)
+	assert.Equal(t, 1, listCalls)
+}
+
+func TestCycle_NoActiveCycle(t *testing.T) {
+	client := &api.MockClient{
+		GetActiveCycleFunc: func(ctx context.Context, teamID string) (*api.Cycle, error) {
+			return nil, api.ErrNoActiveCycle
+		},
+	}
+
+	_, err := New(client).Cycle(context.Background(), "current", "team-eng")
+
+	var notFound *NotFoundError
+	assert.True(t, errors.As(err, &notFound))
+}
+
+func TestStateOfType(t *testing.T) {
+	client := &api.MockClient{
+		GetWorkflowStatesFunc: func(ctx context.Context, opts api.WorkflowStateListOptions) ([]api.WorkflowState, error) {
+			return []api.WorkflowState{
+				{ID: "state-review", Name: "In Review", Type: "started", Position: 3, Team: &engTeam},
+				{ID: "state-progress", Name: "In Progress", Type: "started", Position: 2, Team: &engTeam},
+				{ID: "state-des-progress", Name: "Doing", Type: "started", Position: 1, Team: &designTeam},
+			}, nil
+		},
+	}
+	r := New(client)
+
+	state, err := r.StateOfType(context.Background(), "team-eng", "started")
+	require.NoError(t, err)
+	assert.Equal(t, "state-progress", state.ID)
+
+	_, err = r.StateOfType(context.Background(), "team-eng", "canceled")
+	assert.EqualError(t, err, `state "canceled" not found`)
+}
@@ -1,13 +1,100 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/stustirling/lnr/cmd"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/pkg/cmdutil"
 )
 
+// Exit codes. Scripts can rely on these to tell failures apart.
+const (
+	exitOK              = 0
+	exitError           = 1   // Any other failure
+	exitUsage           = 2   // Invalid flags, arguments or subcommand
+	exitNotFound        = 3   // An issue, team, user or other entity does not exist
+	exitUnauthenticated = 4   // No API key, or Linear rejected it
+	exitForbidden       = 5   // The key may not access the entity
+	exitRateLimited     = 6   // Linear's rate limit was hit and retries ran out
+	exitValidation      = 7   // Linear rejected the input
+	exitTimeout         = 8   // --timeout expired
+	exitInterrupted     = 130 // Cancelled with Ctrl-C or SIGTERM (128 + SIGINT)
+)
+
+// errorKinds maps error kinds to their name in JSON errors and exit code
+var errorKinds = []struct {
+	err  error
+	name string
+	code int
+}{
+	{api.ErrNotFound, "not_found", exitNotFound},
+	{api.ErrUnauthenticated, "unauthenticated", exitUnauthenticated},
+	{config.ErrNoAPIKey, "unauthenticated", exitUnauthenticated},
+	{config.ErrCredentialHelper, "unauthenticated", exitUnauthenticated},
+	{api.ErrForbidden, "forbidden", exitForbidden},
+	{api.ErrRateLimited, "rate_limited", exitRateLimited},
+	{api.ErrValidation, "validation", exitValidation},
+	{context.DeadlineExceeded, "timeout", exitTimeout},
+	{context.Canceled, "interrupted", exitInterrupted},
+}
+
 func main() {
-	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+	err := cmd.Execute()
+	if err == nil {
+		os.Exit(exitOK)
+	}
+
+	kind, code := classify(err)
+	if cmd.JSONOutput() {
+		writeJSONError(os.Stderr, err, kind)
+	} else {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	os.Exit(code)
+}
+
+// classify returns the kind of err and its exit code
+func classify(err error) (string, int) {
+	var flagErr *cmdutil.FlagError
+	if errors.As(err, &flagErr) {
+		return "usage", exitUsage
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k.name, k.code
+		}
+	}
+	return "error", exitError
+}
+
+// jsonError is how errors are written to stderr with --json
+type jsonError struct {
+	Error struct {
+		Kind    string           `json:"kind"`
+		Message string           `json:"message"`
+		Code    string           `json:"code,omitempty"`
+		Fields  []api.FieldError `json:"fields,omitempty"`
+	} `json:"error"`
+}
+
+func writeJSONError(w io.Writer, err error, kind string) {
+	var out jsonError
+	out.Error.Kind = kind
+	out.Error.Message = err.Error()
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		out.Error.Code = apiErr.Code
+		out.Error.Fields = apiErr.Fields
 	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(out)
 }
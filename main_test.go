@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/resolve"
+	"github.com/stustirling/lnr/pkg/cmdutil"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind string
+		wantCode int
+	}{
+		{"plain error", errors.New("boom"), "error", exitError},
+		{"flag error", &cmdutil.FlagError{Err: errors.New("unknown flag: --nope")}, "usage", exitUsage},
+		{"not found", fmt.Errorf("failed to get issue: %w", &api.APIError{Kind: api.ErrNotFound}), "not_found", exitNotFound},
+		{"unresolved reference", &resolve.NotFoundError{Kind: "team", Ref: "NOPE"}, "not_found", exitNotFound},
+		{"unauthenticated", &api.APIError{Kind: api.ErrUnauthenticated}, "unauthenticated", exitUnauthenticated},
+		{"no API key", config.ErrNoAPIKey, "unauthenticated", exitUnauthenticated},
+		{"credential helper", fmt.Errorf("%w: %q: exit status 1", config.ErrCredentialHelper, "pass linear"), "unauthenticated", exitUnauthenticated},
+		{"forbidden", &api.APIError{Kind: api.ErrForbidden}, "forbidden", exitForbidden},
+		{"rate limited", &api.APIError{Kind: api.ErrRateLimited}, "rate_limited", exitRateLimited},
+		{"validation", &api.APIError{Kind: api.ErrValidation}, "validation", exitValidation},
+		{"timeout", fmt.Errorf("failed to list issues: %w", context.DeadlineExceeded), "timeout", exitTimeout},
+		{"interrupted", cmdutil.Incomplete(context.Canceled, 250, "issues"), "interrupted", exitInterrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, code := classify(tt.err)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.wantCode, code)
+		})
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	err := fmt.Errorf("failed to create issue: %w", &api.APIError{
+		Kind:    api.ErrValidation,
+		Message: "Argument Validation Error",
+		Code:    "INVALID_INPUT",
+		Fields:  []api.FieldError{{Field: "title", Message: "title must be a string"}},
+	})
+
+	var buf bytes.Buffer
+	writeJSONError(&buf, err, "validation")
+
+	var got jsonError
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "validation", got.Error.Kind)
+	assert.Equal(t, "failed to create issue: Argument Validation Error (title: title must be a string)", got.Error.Message)
+	assert.Equal(t, "INVALID_INPUT", got.Error.Code)
+	assert.Equal(t, []api.FieldError{{Field: "title", Message: "title must be a string"}}, got.Error.Fields)
+}
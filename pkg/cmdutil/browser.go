@@ -0,0 +1,29 @@
+package cmdutil
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// OpenBrowser opens url in the user's web browser, or the command in
+// $BROWSER when it is set. It returns once the browser has been started.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch browser := os.Getenv("BROWSER"); {
+	case browser != "":
+		cmd = exec.Command(browser, url)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("open", url)
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the launcher without waiting for it
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/stustirling/lnr/internal/config"
+)
+
+// newDebugLogger returns a logger for API traffic: text on stderr with
+// --debug, and JSON appended to the trace file with --debug-file. It
+// returns nil when neither is set. The trace file stays open until the
+// process exits.
+func newDebugLogger(cfg *config.Config, stderr io.Writer) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+
+	var handlers []slog.Handler
+	if cfg.Debug {
+		handlers = append(handlers, slog.NewTextHandler(stderr, opts))
+	}
+	if cfg.DebugFile != "" {
+		f, err := os.OpenFile(cfg.DebugFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open debug file: %w", err)
+		}
+		handlers = append(handlers, slog.NewJSONHandler(f, opts))
+	}
+
+	switch len(handlers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return slog.New(handlers[0]), nil
+	default:
+		return slog.New(teeHandler(handlers)), nil
+	}
+}
+
+// DebugLogger returns the logger the global flags in ctx ask for, for
+// commands that create a client without a factory. It returns nil when
+// debugging is off.
+func DebugLogger(ctx context.Context, stderr io.Writer) (*slog.Logger, error) {
+	debug, file := config.DebugFor(config.OverridesFrom(ctx))
+	return newDebugLogger(&config.Config{Debug: debug, DebugFile: file}, stderr)
+}
+
+// teeHandler sends every record to each of its handlers
+type teeHandler []slog.Handler
+
+func (h teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(h))
+	for i, handler := range h {
+		out[i] = handler.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (h teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(h))
+	for i, handler := range h {
+		out[i] = handler.WithGroup(name)
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stustirling/lnr/internal/config"
+)
+
+func TestNewDebugLogger(t *testing.T) {
+	logger, err := newDebugLogger(&config.Config{}, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Nil(t, logger)
+
+	var stderr bytes.Buffer
+	trace := filepath.Join(t.TempDir(), "trace.log")
+	logger, err = newDebugLogger(&config.Config{Debug: true, DebugFile: trace}, &stderr)
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	logger.LogAttrs(context.Background(), slog.LevelDebug, "graphql request", slog.String("operation", "GetIssue"))
+
+	assert.Contains(t, stderr.String(), `msg="graphql request" operation=GetIssue`)
+	data, err := os.ReadFile(trace)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"msg":"graphql request","operation":"GetIssue"`)
+}
+
+func TestNewDebugLogger_BadFile(t *testing.T) {
+	_, err := newDebugLogger(&config.Config{DebugFile: filepath.Join(t.TempDir(), "missing", "trace.log")}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to open debug file")
+}
@@ -0,0 +1,28 @@
+package cmdutil
+
+import "fmt"
+
+// FlagError is returned when a command is invoked with invalid flags or
+// arguments
+type FlagError struct {
+	Err error
+}
+
+func (e *FlagError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FlagError) Unwrap() error {
+	return e.Err
+}
+
+// Incomplete wraps the error that cut a listing short. List commands print
+// the results fetched before an interrupt or timeout and then return this,
+// so the exit status still shows the output is partial. It returns nil when
+// err is nil.
+func Incomplete(err error, fetched int, noun string) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("stopped after %d %s: %w", fetched, noun, err)
+}
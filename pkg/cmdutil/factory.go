@@ -1,31 +1,96 @@
 package cmdutil
 
 import (
+	"context"
+	"os"
+	"strings"
+
 	"github.com/stustirling/lnr/internal/api"
+	"github.com/stustirling/lnr/internal/cache"
 	"github.com/stustirling/lnr/internal/config"
+	"github.com/stustirling/lnr/internal/oauth"
 	"github.com/stustirling/lnr/internal/output"
+	"github.com/stustirling/lnr/internal/resolve"
 )
 
 // Factory provides dependencies for commands
 type Factory struct {
+	// Context is cancelled on interrupt or when --timeout expires. Every
+	// API call a command makes should use it.
+	Context   context.Context
 	Config    *config.Config
 	Client    api.Client
+	Resolver  *resolve.Resolver
 	Formatter *output.Formatter
 }
 
-// NewFactory creates a new factory with dependencies
-func NewFactory(jsonOutput bool) (*Factory, error) {
-	cfg, err := config.Load()
+// NewFactory creates a new factory with dependencies. ctx is the command's
+// context, usually cmd.Context(), and carries the global flags.
+func NewFactory(ctx context.Context, jsonOutput bool) (*Factory, error) {
+	cfg, err := config.LoadWith(config.OverridesFrom(ctx))
 	if err != nil {
 		return nil, err
 	}
 
-	client := api.NewClient(cfg.APIKey)
+	logger, err := newDebugLogger(cfg, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	if logger != nil && cfg.Settings != nil && cfg.Settings.Repo != nil {
+		repo := cfg.Settings.Repo
+		logger.Debug("applied context file", "path", repo.Path, "keys", strings.Join(repo.Keys(), ","))
+	}
+
+	// The context deadline bounds the whole command, so give single
+	// requests as long as the command has rather than the usual limit
+	opts := api.ClientOptions{Endpoint: cfg.APIURL, Timeout: cfg.Timeout, Logger: logger}
+	if cfg.Retries != nil {
+		// ClientOptions treats zero as "use the default"
+		opts.MaxRetries = *cfg.Retries
+		if opts.MaxRetries == 0 {
+			opts.MaxRetries = -1
+		}
+	}
+
+	// Cached entries belong to the workspace the credentials are for. OAuth
+	// tokens change on every refresh, so the profile stands in for them.
+	cacheKey, accessToken := cfg.APIKey, ""
+	if cfg.OAuth != nil {
+		app := &oauth.App{ClientID: cfg.OAuth.ClientID, ClientSecret: cfg.OAuth.ClientSecret, TokenURL: cfg.OAuth.TokenURL}
+		token := oauth.Token{AccessToken: cfg.OAuth.AccessToken, RefreshToken: cfg.OAuth.RefreshToken, Expiry: cfg.OAuth.Expiry}
+		opts.TokenSource = oauth.NewTokenSource(app, token, func(t *oauth.Token) error {
+			return config.SaveOAuthToken(cfg.Profile, t.AccessToken, t.RefreshToken, t.Expiry)
+		})
+		cacheKey, accessToken = "oauth\x00"+cfg.Profile, cfg.OAuth.AccessToken
+	}
+
+	switch {
+	case cfg.Replay != "":
+		transport, err := api.NewReplayTransport(cfg.Replay)
+		if err != nil {
+			return nil, err
+		}
+		opts.Transport = transport
+	case cfg.Record != "":
+		opts.Transport = api.NewRecordTransport(cfg.Record, nil, cfg.APIKey, accessToken)
+	}
+
+	var client api.Client = api.NewClientWithOptions(cfg.APIKey, opts)
+	// Cached lookups would be missing from a recording, and replayed data
+	// must not end up in the cache
+	if !cfg.NoCache && opts.Transport == nil {
+		if root, err := cache.DefaultDir(); err == nil {
+			client = cache.NewClient(client, cache.NewStore(cache.WorkspaceDir(root, cfg.APIURL, cacheKey)))
+		}
+	}
 	formatter := output.NewFormatter(jsonOutput)
+	formatter.SetColor(ColorEnabled(cfg.Settings.Get(config.KeyColor).Value))
 
 	return &Factory{
+		Context:   ctx,
 		Config:    cfg,
 		Client:    client,
+		Resolver:  resolve.New(client),
 		Formatter: formatter,
 	}, nil
 }
@@ -33,8 +98,10 @@ func NewFactory(jsonOutput bool) (*Factory, error) {
 // NewFactoryWithClient creates a factory with a custom client (for testing)
 func NewFactoryWithClient(client api.Client, jsonOutput bool) *Factory {
 	return &Factory{
+		Context:   context.Background(),
 		Config:    &config.Config{},
 		Client:    client,
+		Resolver:  resolve.New(client),
 		Formatter: output.NewFormatter(jsonOutput),
 	}
 }
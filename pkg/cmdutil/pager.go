@@ -0,0 +1,79 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// AnnotationPager marks a command whose output may go through the pager.
+// Commands that open an editor or ask questions must not be paged.
+const AnnotationPager = "lnr/pager"
+
+// AnnotationContext marks a command whose --project and --label flags
+// default to the project and labels settings, usually from a repository's
+// .lnr.yaml. Commands that change existing issues must not have it.
+const AnnotationContext = "lnr/context"
+
+// paging is set while output goes through a pager, which takes the place of
+// the terminal
+var paging bool
+
+// StdoutIsTerminal reports whether output ends up on a terminal, directly
+// or through the pager
+func StdoutIsTerminal() bool {
+	return paging || IsTerminal(os.Stdout)
+}
+
+// StartPager sends everything written to os.Stdout through command, such
+// as "less", until the returned function is called; that waits for the user
+// to quit the pager. Output that is not going to a terminal is not paged.
+func StartPager(command string) (func() error, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 || args[0] == "cat" || !IsTerminal(os.Stdout) {
+		return func() error { return nil }, nil
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start pager: %w", err)
+	}
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = r
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	// Quit straight away when the output fits on one screen, and keep colours
+	if os.Getenv("LESS") == "" {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := pager.Start(); err != nil {
+		_ = r.Close()
+		_ = w.Close()
+		return nil, fmt.Errorf("failed to start pager %q: %w", args[0], err)
+	}
+	_ = r.Close()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	paging = true
+	return func() error {
+		os.Stdout = stdout
+		paging = false
+		_ = w.Close()
+		return pager.Wait()
+	}, nil
+}
+
+// ColorEnabled reports whether output should be coloured given the color
+// setting: always, never, or auto, which colours terminal output unless
+// NO_COLOR is set
+func ColorEnabled(setting string) bool {
+	switch setting {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return os.Getenv("NO_COLOR") == "" && StdoutIsTerminal()
+}
@@ -0,0 +1,56 @@
+package cmdutil
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Confirm asks a yes/no question on out and reads the answer from in. Anything
+// other than y or yes counts as no.
+func Confirm(in io.Reader, out io.Writer, prompt string) (bool, error) {
+	return ConfirmContext(context.Background(), in, out, prompt)
+}
+
+// ConfirmContext is Confirm, but gives up waiting for an answer when ctx is
+// cancelled, so Ctrl-C at the prompt aborts the command
+func ConfirmContext(ctx context.Context, in io.Reader, out io.Writer, prompt string) (bool, error) {
+	_, _ = fmt.Fprintf(out, "%s [y/N] ", prompt)
+
+	type result struct {
+		answer string
+		err    error
+	}
+	// The read cannot be interrupted, so it is left behind on cancellation;
+	// the process is about to exit anyway
+	done := make(chan result, 1)
+	go func() {
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		done <- result{answer, err}
+	}()
+
+	var r result
+	select {
+	case <-ctx.Done():
+		_, _ = fmt.Fprintln(out)
+		return false, ctx.Err()
+	case r = <-done:
+	}
+	if r.err != nil && r.err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", r.err)
+	}
+	answer := strings.ToLower(strings.TrimSpace(r.answer))
+	return answer == "y" || answer == "yes", nil
+}
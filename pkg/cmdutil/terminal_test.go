@@ -0,0 +1,48 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := Confirm(strings.NewReader(tt.input), &out, "Delete?")
+
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "input %q", tt.input)
+		assert.Equal(t, "Delete? [y/N] ", out.String())
+	}
+}
+
+func TestConfirmContext_Cancelled(t *testing.T) {
+	// A reader that never answers, like a terminal nobody types into
+	in, _ := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	ok, err := ConfirmContext(ctx, in, &out, "Delete?")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, ok)
+	assert.Equal(t, "Delete? [y/N] \n", out.String())
+}